@@ -0,0 +1,119 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/inexio/thola/internal/request"
+	"github.com/labstack/echo/v4"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// ProcessBatch processes all requests of the batch request concurrently with the given number of workers.
+// Requests for the same IP address are serialized with the same per-IP lock as single API requests.
+// The responses are sent to the returned channel as soon as they are completed, the channel is closed
+// after all requests are processed.
+func ProcessBatch(ctx context.Context, b request.BatchRequest, workers int) <-chan request.BatchResponseItem {
+	if workers <= 0 {
+		workers = 1
+	}
+	if workers > len(b.Requests) {
+		workers = len(b.Requests)
+	}
+
+	items := make(chan int)
+	results := make(chan request.BatchResponseItem)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for idx := range items {
+				results <- processBatchItem(ctx, b.Requests[idx], idx)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(items)
+		for idx := range b.Requests {
+			select {
+			case items <- idx:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	return results
+}
+
+func processBatchItem(ctx context.Context, item request.BatchRequestItem, idx int) request.BatchResponseItem {
+	id := item.ID
+	if id == "" {
+		id = strconv.Itoa(idx)
+	}
+	logger := log.Ctx(ctx).With().Str("batch_item", id).Logger()
+	ctx = logger.WithContext(ctx)
+
+	var ip *string
+	if deviceData := item.GetDeviceData(); deviceData != nil {
+		ip = &deviceData.IPAddress
+	}
+
+	res := request.BatchResponseItem{
+		ID:   id,
+		Type: item.Type,
+	}
+	resp, err := processRequestWithIPLock(ctx, item.Request, ip)
+	if err != nil {
+		res.Error = err.Error()
+	} else {
+		res.Response = resp
+	}
+	return res
+}
+
+func batch(ctx echo.Context) error {
+	body, err := ioutil.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+	b, err := request.ParseBatchRequest(body)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+
+	workers := viper.GetInt("api.batch-workers")
+	if b.Workers > 0 && b.Workers < workers {
+		workers = b.Workers
+	}
+
+	logger := log.With().Str("request_id", ctx.Request().Header.Get(echo.HeaderXRequestID)).Logger()
+	reqCtx := logger.WithContext(ctx.Request().Context())
+	log.Ctx(reqCtx).Debug().Int("requests", len(b.Requests)).Int("workers", workers).Msg("incoming batch request")
+
+	resp := ctx.Response()
+	resp.Header().Set(echo.HeaderContentType, "application/x-ndjson")
+	resp.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(resp)
+	for res := range ProcessBatch(reqCtx, b, workers) {
+		if err := enc.Encode(res); err != nil {
+			log.Ctx(reqCtx).Error().Err(err).Msg("failed to write batch response")
+			continue
+		}
+		resp.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+func listDevices(ctx echo.Context) error {
+	r := request.ListDevicesRequest{
+		Vendor: ctx.QueryParam("vendor"),
+		Model:  ctx.QueryParam("model"),
+		Class:  ctx.QueryParam("class"),
+	}
+	resp, err := handleAPIRequest(ctx, &r, nil)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func getDevice(ctx echo.Context) error {
+	r := request.ListDevicesRequest{
+		IPAddress: ctx.Param("ip"),
+	}
+	resp, err := handleAPIRequest(ctx, &r, nil)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	devices := resp.(*request.ListDevicesResponse).Devices
+	if len(devices) == 0 {
+		return returnInFormat(ctx, http.StatusNotFound, tholaerr.OutputError{Error: "Not found: device '" + r.IPAddress + "' is not in the device registry"})
+	}
+	return returnInFormat(ctx, http.StatusOK, devices[0])
+}
+
+func getDeviceHistory(ctx echo.Context) error {
+	r := request.DeviceHistoryRequest{
+		IPAddress: ctx.Param("ip"),
+	}
+	resp, err := handleAPIRequest(ctx, &r, nil)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			return returnInFormat(ctx, http.StatusNotFound, tholaerr.OutputError{Error: "Not found: device '" + r.IPAddress + "' is not in the device registry"})
+		}
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
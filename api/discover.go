@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/inexio/thola/internal/parser"
+	"github.com/inexio/thola/internal/request"
+	"github.com/labstack/echo/v4"
+	"github.com/spf13/viper"
+	"net/http"
+)
+
+func discover(ctx echo.Context) error {
+	r := request.DiscoverRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	// every address is locked while it is discovered, like the IP address of other requests
+	if !viper.GetBool("request.no-ip-lock") {
+		r.SetIPLock(lockIP)
+	}
+	resp, err := handleAPIRequest(ctx, &r, nil)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	if ctx.QueryParam("format") == "csv" {
+		b, err := parser.ToCSV(resp)
+		if err != nil {
+			return handleError(ctx, err)
+		}
+		return ctx.Blob(http.StatusOK, "text/csv", b)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
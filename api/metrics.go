@@ -0,0 +1,30 @@
+package api
+
+import (
+	"github.com/inexio/thola/internal/metrics"
+	"github.com/labstack/echo/v4"
+	"time"
+)
+
+// deviceClassContextKey is the key of the echo context under which handleAPIRequest stores the device class.
+const deviceClassContextKey = "device_class"
+
+func metricsMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				c.Error(err)
+			}
+
+			deviceClass, _ := c.Get(deviceClassContextKey).(string)
+			metrics.ObserveAPIRequest(c.Path(), deviceClass, c.Response().Status, time.Since(start))
+
+			return err
+		}
+	}
+}
+
+var getMetrics = echo.WrapHandler(metrics.Handler())
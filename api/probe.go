@@ -0,0 +1,124 @@
+package api
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/exporter"
+	"github.com/inexio/thola/internal/request"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultProbeModules = "interfaces,cpu,memory"
+
+func probe(ctx echo.Context) error {
+	target := ctx.QueryParam("target")
+	if target == "" {
+		return returnInFormat(ctx, http.StatusBadRequest, tholaerr.OutputError{Error: "Request failed: target parameter is missing"})
+	}
+
+	moduleParam := ctx.QueryParam("module")
+	if moduleParam == "" {
+		moduleParam = defaultProbeModules
+	}
+	var modules []exporter.Module
+	for _, name := range strings.Split(moduleParam, ",") {
+		module, err := exporter.GetModule(name)
+		if err != nil {
+			return returnInFormat(ctx, http.StatusBadRequest, tholaerr.OutputError{Error: "Request failed: " + err.Error()})
+		}
+		modules = append(modules, module)
+	}
+
+	logger := log.With().Str("request_id", ctx.Request().Header.Get(echo.HeaderXRequestID)).Str("target", target).Logger()
+	reqCtx := logger.WithContext(context.Background())
+	log.Ctx(reqCtx).Debug().Str("modules", moduleParam).Msg("incoming probe request")
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(&probeCollector{
+		ctx:     reqCtx,
+		target:  target,
+		timeout: getProbeTimeout(ctx.Request()),
+		modules: modules,
+	})
+	promhttp.HandlerFor(registry, promhttp.HandlerOpts{}).ServeHTTP(ctx.Response(), ctx.Request())
+	return nil
+}
+
+var (
+	probeModuleSuccessDesc  = prometheus.NewDesc("thola_probe_module_success", "Whether the module of the probe was successful.", []string{"module"}, nil)
+	probeModuleDurationDesc = prometheus.NewDesc("thola_probe_module_duration_seconds", "Duration of the module of the probe in seconds.", []string{"module"}, nil)
+	probeSuccessDesc        = prometheus.NewDesc("thola_probe_success", "Whether all modules of the probe were successful.", nil, nil)
+	probeDurationDesc       = prometheus.NewDesc("thola_probe_duration_seconds", "Duration of the probe in seconds.", nil, nil)
+)
+
+// probeCollector reads out the modules of a probe from the target when it is collected.
+type probeCollector struct {
+	ctx     context.Context
+	target  string
+	timeout *int
+	modules []exporter.Module
+}
+
+// Describe sends no descriptions, because the metrics depend on the target. This makes it an unchecked collector.
+func (c *probeCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *probeCollector) Collect(ch chan<- prometheus.Metric) {
+	start := time.Now()
+	success := 1.0
+	for _, module := range c.modules {
+		moduleStart := time.Now()
+		err := probeModule(c.ctx, c.target, c.timeout, module, ch)
+		moduleSuccess := 1.0
+		if err != nil {
+			log.Ctx(c.ctx).Warn().Err(err).Str("module", module.Name()).Msg("probe module failed")
+			success = 0
+			moduleSuccess = 0
+		}
+		ch <- prometheus.MustNewConstMetric(probeModuleSuccessDesc, prometheus.GaugeValue, moduleSuccess, module.Name())
+		ch <- prometheus.MustNewConstMetric(probeModuleDurationDesc, prometheus.GaugeValue, time.Since(moduleStart).Seconds(), module.Name())
+	}
+	ch <- prometheus.MustNewConstMetric(probeSuccessDesc, prometheus.GaugeValue, success)
+	ch <- prometheus.MustNewConstMetric(probeDurationDesc, prometheus.GaugeValue, time.Since(start).Seconds())
+}
+
+func probeModule(ctx context.Context, target string, timeout *int, module exporter.Module, ch chan<- prometheus.Metric) error {
+	r := module.NewRequest(request.BaseRequest{
+		DeviceData: request.DeviceData{
+			IPAddress: target,
+		},
+		Timeout: timeout,
+	})
+
+	res, err := processRequestWithIPLock(ctx, r, &target)
+	if err != nil {
+		return err
+	}
+	return module.Collect(res, ch)
+}
+
+// getProbeTimeout returns the timeout for the requests of a probe.
+// Prometheus sends its scrape timeout in a header, which is used if it is shorter than the configured request timeout.
+// If nil is returned, the configured request timeout is used.
+func getProbeTimeout(r *http.Request) *int {
+	header := r.Header.Get("X-Prometheus-Scrape-Timeout-Seconds")
+	if header == "" {
+		return nil
+	}
+	scrapeTimeout, err := strconv.ParseFloat(header, 64)
+	if err != nil || scrapeTimeout < 1 {
+		return nil
+	}
+	timeout := int(scrapeTimeout)
+	if configTimeout := viper.GetInt("request.timeout"); configTimeout != 0 && configTimeout < timeout {
+		return nil
+	}
+	return &timeout
+}
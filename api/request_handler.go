@@ -5,7 +5,9 @@ import (
 	"crypto/subtle"
 	"fmt"
 	"github.com/inexio/thola/api/statistics"
+	"github.com/inexio/thola/internal/communicator/create"
 	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/metrics"
 	"github.com/inexio/thola/internal/request"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/labstack/echo/v4"
@@ -68,6 +70,8 @@ func StartAPI() {
 
 	e.Use(requestIDMiddleware())
 
+	e.Use(metricsMiddleware())
+
 	e.Use(loggerMiddleware())
 
 	// swagger:operation POST /identify identify identify
@@ -421,6 +425,60 @@ func StartAPI() {
 	//       $ref: '#/definitions/OutputError'
 	e.POST("/check/high-availability", checkHighAvailability)
 
+	// swagger:operation POST /check/bgp check checkBGP
+	// ---
+	// summary: Check the bgp peers of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/CheckBGPRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/CheckResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/check/bgp", checkBGP)
+
+	// swagger:operation POST /check/routing-protocols check checkRoutingProtocols
+	// ---
+	// summary: Check the OSPF neighbors and IS-IS adjacencies of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/CheckRoutingProtocolsRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/CheckResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/check/routing-protocols", checkRoutingProtocols)
+
 	// swagger:operation POST /read/interfaces read readInterfaces
 	// ---
 	// summary: Reads out data of the interfaces of a device.
@@ -691,6 +749,114 @@ func StartAPI() {
 	//       $ref: '#/definitions/OutputError'
 	e.POST("/read/high-availability", readHighAvailability)
 
+	// swagger:operation POST /read/inventory read readInventory
+	// ---
+	// summary: Reads out the physical inventory of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadInventoryRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadInventoryResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/inventory", readInventory)
+
+	// swagger:operation POST /read/neighbors read readNeighbors
+	// ---
+	// summary: Reads out the neighbors of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadNeighborsRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadNeighborsResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/neighbors", readNeighbors)
+
+	// swagger:operation POST /read/bgp read readBGP
+	// ---
+	// summary: Reads out the bgp peers of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadBGPRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadBGPResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/bgp", readBGP)
+
+	// swagger:operation POST /read/routing-protocols read readRoutingProtocols
+	// ---
+	// summary: Reads out the OSPF neighbors and IS-IS adjacencies of a device.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/ReadRoutingProtocolsRequest'
+	// responses:
+	//   200:
+	//     description: Returns the response.
+	//     schema:
+	//       $ref: '#/definitions/ReadRoutingProtocolsResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/read/routing-protocols", readRoutingProtocols)
+
 	// swagger:operation POST /read/available-components read readAvailableComponents
 	// ---
 	// summary: Returns the available components for the device.
@@ -718,6 +884,193 @@ func StartAPI() {
 	//       $ref: '#/definitions/OutputError'
 	e.POST("/read/available-components", readAvailableComponents)
 
+	// swagger:operation POST /batch batch batch
+	// ---
+	// summary: Processes multiple requests of mixed types concurrently.
+	// description: The responses are streamed as newline-delimited JSON as soon as they are completed.
+	// consumes:
+	// - application/json
+	// - application/yaml
+	// produces:
+	// - application/x-ndjson
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Batch request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/BatchRequest'
+	// responses:
+	//   200:
+	//     description: Returns one response per line.
+	//     schema:
+	//       $ref: '#/definitions/BatchResponseItem'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/batch", batch)
+
+	// swagger:operation GET /probe probe probe
+	// ---
+	// summary: Reads out a device and returns the results as Prometheus metrics.
+	// description: The endpoint can be used as a target for Prometheus in the style of the snmp_exporter.
+	// produces:
+	// - text/plain
+	// parameters:
+	// - name: target
+	//   in: query
+	//   description: IP address or hostname of the device.
+	//   required: true
+	//   type: string
+	// - name: module
+	//   in: query
+	//   description: Comma separated list of modules (interfaces, cpu, memory, disk, hardware_health, ups, sbc, server, bgp).
+	//   required: false
+	//   type: string
+	//   default: interfaces,cpu,memory
+	// responses:
+	//   200:
+	//     description: Returns the metrics in the Prometheus text format.
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.GET("/probe", probe)
+
+	// swagger:operation GET /metrics metrics metrics
+	// ---
+	// summary: Returns the runtime metrics of thola in the Prometheus text format.
+	// description: Contains request durations per endpoint, device class and status code, SNMP request, retry and timeout counters, cache hits and misses and the time spent waiting on IP locks.
+	// produces:
+	// - text/plain
+	// responses:
+	//   200:
+	//     description: Returns the metrics in the Prometheus text format.
+	e.GET("/metrics", getMetrics)
+
+	// swagger:operation GET /devices devices listDevices
+	// ---
+	// summary: Lists the devices of the device registry.
+	// description: The device registry contains every device that was identified with its class, properties, first and last identification and the connection data of the last identification.
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: vendor
+	//   in: query
+	//   description: Only list devices of this vendor (case insensitive).
+	//   required: false
+	//   type: string
+	// - name: model
+	//   in: query
+	//   description: Only list devices of this model (case insensitive).
+	//   required: false
+	//   type: string
+	// - name: class
+	//   in: query
+	//   description: Only list devices of this device class.
+	//   required: false
+	//   type: string
+	// responses:
+	//   200:
+	//     description: Returns the devices sorted by their IP address.
+	//     schema:
+	//       $ref: '#/definitions/ListDevicesResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.GET("/devices", listDevices)
+
+	// swagger:operation GET /devices/{ip} devices getDevice
+	// ---
+	// summary: Returns a device of the device registry.
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: ip
+	//   in: path
+	//   description: IP address of the device.
+	//   required: true
+	//   type: string
+	// responses:
+	//   200:
+	//     description: Returns the device.
+	//     schema:
+	//       $ref: '#/definitions/DeviceRecord'
+	//   404:
+	//     description: The device is not in the device registry.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.GET("/devices/:ip", getDevice)
+
+	// swagger:operation GET /devices/{ip}/history devices getDeviceHistory
+	// ---
+	// summary: Returns the changes of the identify properties of a device of the device registry.
+	// produces:
+	// - application/json
+	// - application/xml
+	// parameters:
+	// - name: ip
+	//   in: path
+	//   description: IP address of the device.
+	//   required: true
+	//   type: string
+	// responses:
+	//   200:
+	//     description: Returns the changes, oldest first.
+	//     schema:
+	//       $ref: '#/definitions/DeviceHistoryResponse'
+	//   404:
+	//     description: The device is not in the device registry.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.GET("/devices/:ip/history", getDeviceHistory)
+
+	// swagger:operation POST /discover discover discover
+	// ---
+	// summary: Discovers and identifies all devices of a network.
+	// description: Every address of the network is tried with the given connection data. The devices that answer are identified and stored in the cache and the device registry.
+	// consumes:
+	// - application/json
+	// - application/xml
+	// produces:
+	// - application/json
+	// - application/xml
+	// - text/csv
+	// parameters:
+	// - name: body
+	//   in: body
+	//   description: Request to process.
+	//   required: true
+	//   schema:
+	//     $ref: '#/definitions/DiscoverRequest'
+	// - name: format
+	//   in: query
+	//   description: Set to 'csv' to return the devices as CSV.
+	//   required: false
+	//   type: string
+	// responses:
+	//   200:
+	//     description: Returns the discovered devices.
+	//     schema:
+	//       $ref: '#/definitions/DiscoverResponse'
+	//   400:
+	//     description: Returns an error with more details in the body.
+	//     schema:
+	//       $ref: '#/definitions/OutputError'
+	e.POST("/discover", discover)
+
 	// Start server
 	go func() {
 		var err error
@@ -738,6 +1091,11 @@ func StartAPI() {
 		}
 	}()
 
+	// Reload device classes and mappings when the overlay directory changes
+	watchCtx, stopWatching := context.WithCancel(ctx)
+	defer stopWatching()
+	go create.WatchOverlayDirectory(watchCtx, viper.GetDuration("overlay.reload-interval"))
+
 	// Wait for interrupt signal to gracefully shutdown the server with a timeout of 10 seconds.
 	// Also close the connection to the database.
 	quit := make(chan os.Signal, 1)
@@ -910,6 +1268,30 @@ func checkHighAvailability(ctx echo.Context) error {
 	return returnInFormat(ctx, http.StatusOK, resp)
 }
 
+func checkBGP(ctx echo.Context) error {
+	r := request.CheckBGPRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func checkRoutingProtocols(ctx echo.Context) error {
+	r := request.CheckRoutingProtocolsRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
 func readInterfaces(ctx echo.Context) error {
 	r := request.ReadInterfacesRequest{}
 	if err := ctx.Bind(&r); err != nil {
@@ -1030,6 +1412,54 @@ func readHighAvailability(ctx echo.Context) error {
 	return returnInFormat(ctx, http.StatusOK, resp)
 }
 
+func readInventory(ctx echo.Context) error {
+	r := request.ReadInventoryRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readNeighbors(ctx echo.Context) error {
+	r := request.ReadNeighborsRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readBGP(ctx echo.Context) error {
+	r := request.ReadBGPRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
+func readRoutingProtocols(ctx echo.Context) error {
+	r := request.ReadRoutingProtocolsRequest{}
+	if err := ctx.Bind(&r); err != nil {
+		return err
+	}
+	resp, err := handleAPIRequest(ctx, &r, &r.BaseRequest.DeviceData.IPAddress)
+	if err != nil {
+		return handleError(ctx, err)
+	}
+	return returnInFormat(ctx, http.StatusOK, resp)
+}
+
 func readAvailableComponents(ctx echo.Context) error {
 	r := request.ReadAvailableComponentsRequest{}
 	if err := ctx.Bind(&r); err != nil {
@@ -1069,36 +1499,59 @@ func returnInFormat(ctx echo.Context, statusCode int, resp interface{}) error {
 
 func handleAPIRequest(echoCTX echo.Context, r request.Request, ip *string) (request.Response, error) {
 	logger := log.With().Str("request_id", echoCTX.Request().Header.Get(echo.HeaderXRequestID)).Logger()
-	ctx := logger.WithContext(context.Background())
+	ctx := metrics.NewContextWithDeviceClassRecorder(logger.WithContext(context.Background()))
 	log.Ctx(ctx).Debug().Msg("incoming request")
 
+	res, err := processRequestWithIPLock(ctx, r, ip)
+	if deviceClass, ok := metrics.DeviceClassFromContext(ctx); ok {
+		echoCTX.Set(deviceClassContextKey, deviceClass)
+	}
+	return res, err
+}
+
+func processRequestWithIPLock(ctx context.Context, r request.Request, ip *string) (request.Response, error) {
 	if ip != nil && !viper.GetBool("request.no-ip-lock") {
 		ctx, cancel := request.CheckForTimeout(ctx, r)
 		defer cancel()
 
-		ch := getDeviceChannel(*ip)
-		select {
-		case <-ctx.Done():
-			return r.HandlePreProcessError(errors.New("request timed out while waiting on the IP lock"))
-		case <-ch:
-			log.Ctx(ctx).Debug().Msgf("locked IP '%s'", *ip)
-			defer func() {
-				ch <- struct{}{}
-				log.Ctx(ctx).Debug().Msgf("unlocked IP '%s'", *ip)
-			}()
-			return request.ProcessRequest(ctx, r)
+		unlock, err := lockIP(ctx, *ip)
+		if err != nil {
+			return r.HandlePreProcessError(err)
 		}
+		defer unlock()
+		return request.ProcessRequest(ctx, r)
 	} else {
 		return request.ProcessRequest(ctx, r)
 	}
 }
 
+// lockIP waits until the IP lock of the device is acquired and returns the function that releases it.
+func lockIP(ctx context.Context, ip string) (func(), error) {
+	ch := getDeviceChannel(ip)
+	start := time.Now()
+	select {
+	case <-ctx.Done():
+		metrics.ObserveIPLockWait(metrics.IPLockTimeout, time.Since(start))
+		return nil, errors.New("request timed out while waiting on the IP lock")
+	case <-ch:
+		metrics.ObserveIPLockWait(metrics.IPLockAcquired, time.Since(start))
+		log.Ctx(ctx).Debug().Msgf("locked IP '%s'", ip)
+		return func() {
+			ch <- struct{}{}
+			log.Ctx(ctx).Debug().Msgf("unlocked IP '%s'", ip)
+		}, nil
+	}
+}
+
 func getDeviceChannel(ip string) chan struct{} {
 	deviceChannels.RLock()
 	ch, ok := deviceChannels.channels[ip]
 	deviceChannels.RUnlock()
 	if !ok {
 		deviceChannels.Lock()
+		if deviceChannels.channels == nil {
+			deviceChannels.channels = make(map[string]chan struct{})
+		}
 		if ch, ok = deviceChannels.channels[ip]; !ok {
 			ch = make(chan struct{}, 1)
 			ch <- struct{}{}
@@ -9,6 +9,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"time"
 )
 
 func init() {
@@ -22,6 +23,8 @@ func init() {
 	apiCMD.Flags().String("certfile", "", "Cert file for SSL encryption")
 	apiCMD.Flags().String("keyfile", "", "Key file for SSL encryption")
 	apiCMD.Flags().String("ratelimit", "", "Ratelimit for the API (e.g. 1000 reqs/hour: \"1000-H\")")
+	apiCMD.Flags().Int("batch-workers", 10, "Maximum number of requests of a batch request that are processed at the same time")
+	apiCMD.Flags().Duration("overlay-reload-interval", 5*time.Second, "Interval in which the overlay directory is checked for changes")
 
 	err := viper.BindPFlag("api.port", apiCMD.Flags().Lookup("port"))
 	if err != nil {
@@ -79,6 +82,20 @@ func init() {
 			Msg("Can't bind flag ratelimit")
 		return
 	}
+	err = viper.BindPFlag("api.batch-workers", apiCMD.Flags().Lookup("batch-workers"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag batch-workers")
+		return
+	}
+	err = viper.BindPFlag("overlay.reload-interval", apiCMD.Flags().Lookup("overlay-reload-interval"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag overlay-reload-interval")
+		return
+	}
 }
 
 var apiCMD = &cobra.Command{
@@ -104,6 +121,9 @@ var apiCMD = &cobra.Command{
 		if viper.GetString("api.username") == "" && viper.GetString("api.password") != "" {
 			return errors.New("password but no username for api authorization set")
 		}
+		if viper.GetDuration("overlay.reload-interval") <= 0 {
+			return errors.New("invalid overlay reload interval set")
+		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
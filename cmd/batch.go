@@ -0,0 +1,88 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/inexio/thola/api"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/request"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"io/ioutil"
+	"os"
+)
+
+func init() {
+	rootCMD.AddCommand(batchCMD)
+
+	batchCMD.Flags().Int("workers", 10, "Number of requests that are processed at the same time")
+}
+
+var batchCMD = &cobra.Command{
+	Use:   "batch [file]",
+	Short: "Process multiple requests of mixed types",
+	Long: "Process multiple requests of mixed types from a YAML or JSON file ('-' for stdin).\n\n" +
+		"The requests are processed concurrently, but only one request at a time is sent to the same IP address.\n" +
+		"The responses are printed as newline-delimited JSON as soon as they are completed.\n" +
+		"Each request consists of an optional 'id', a 'type' equal to the path of the corresponding\n" +
+		"API endpoint (e.g. 'read/interfaces') and the 'request' in the same format as for the API.",
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		err := rootCMD.PersistentPreRunE(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		setDeviceDefaults()
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := log.With().Str("request_id", xid.New().String()).Logger()
+		ctx := logger.WithContext(context.Background())
+
+		var data []byte
+		var err error
+		if args[0] == "-" {
+			data, err = ioutil.ReadAll(os.Stdin)
+		} else {
+			data, err = ioutil.ReadFile(args[0])
+		}
+		if err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("failed to read batch file")
+		}
+
+		b, err := request.ParseBatchRequest(data)
+		if err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("invalid batch file")
+		}
+
+		workers, err := cmd.Flags().GetInt("workers")
+		if err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("workers needs to be an integer")
+		}
+		if !cmd.Flags().Changed("workers") && b.Workers > 0 {
+			workers = b.Workers
+		}
+
+		db, err := database.GetDB(ctx)
+		if err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("failed to get database")
+		}
+
+		enc := json.NewEncoder(os.Stdout)
+		for res := range api.ProcessBatch(ctx, b, workers) {
+			if err := enc.Encode(res); err != nil {
+				log.Ctx(ctx).Error().Err(err).Msg("failed to print batch response")
+			}
+		}
+
+		if err = db.CloseConnection(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to close connection to the database")
+			os.Exit(3)
+		}
+	},
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(checkBGPCMD)
+	checkCMD.AddCommand(checkBGPCMD)
+
+	checkBGPCMD.Flags().Float64("prefixes-received-warning-min", 0, "Minimum warning threshold for the received prefixes of each bgp peer")
+	checkBGPCMD.Flags().Float64("prefixes-received-warning-max", 0, "Maximum warning threshold for the received prefixes of each bgp peer")
+	checkBGPCMD.Flags().Float64("prefixes-received-critical-min", 0, "Minimum critical threshold for the received prefixes of each bgp peer")
+	checkBGPCMD.Flags().Float64("prefixes-received-critical-max", 0, "Maximum critical threshold for the received prefixes of each bgp peer")
+	checkBGPCMD.Flags().Float64("prefixes-accepted-warning-min", 0, "Minimum warning threshold for the accepted prefixes of each bgp peer")
+	checkBGPCMD.Flags().Float64("prefixes-accepted-warning-max", 0, "Maximum warning threshold for the accepted prefixes of each bgp peer")
+	checkBGPCMD.Flags().Float64("prefixes-accepted-critical-min", 0, "Minimum critical threshold for the accepted prefixes of each bgp peer")
+	checkBGPCMD.Flags().Float64("prefixes-accepted-critical-max", 0, "Maximum critical threshold for the accepted prefixes of each bgp peer")
+}
+
+var checkBGPCMD = &cobra.Command{
+	Use:   "bgp",
+	Short: "Check the bgp peers of a device",
+	Long: "Checks the bgp peers of a device.\n\n" +
+		"The check is critical if a peer which is not administratively stopped is not in established state.\n" +
+		"Thresholds can be applied to the received and accepted prefixes of each peer.",
+	Run: func(cmd *cobra.Command, args []string) {
+		r := request.CheckBGPRequest{
+			CheckDeviceRequest:         getCheckDeviceRequest(args[0]),
+			PrefixesReceivedThresholds: generateCheckThresholds(cmd, "prefixes-received-warning-min", "prefixes-received-warning-max", "prefixes-received-critical-min", "prefixes-received-critical-max", false),
+			PrefixesAcceptedThresholds: generateCheckThresholds(cmd, "prefixes-accepted-warning-min", "prefixes-accepted-warning-max", "prefixes-accepted-critical-min", "prefixes-accepted-critical-max", false),
+		}
+		handleRequest(&r)
+	},
+}
@@ -27,6 +27,8 @@ func init() {
 	checkIdentifyCMD.Flags().Bool("model-series-diff-warning", false, "Use warning level if model-series differs to the expected value")
 	checkIdentifyCMD.Flags().Bool("os-version-diff-warning", false, "Use warning level if os-version differs to the expected value")
 
+	checkIdentifyCMD.Flags().Bool("detect-changes", false, "Use warning level if os, vendor, model, model-series, serial-number or os-version changed since the last check")
+
 	err := viper.BindPFlag("checkIdentify.os-diff-warning", checkIdentifyCMD.Flags().Lookup("os-diff-warning"))
 	if err != nil {
 		log.Fatal(err)
@@ -56,13 +58,20 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	err = viper.BindPFlag("checkIdentify.detect-changes", checkIdentifyCMD.Flags().Lookup("detect-changes"))
+	if err != nil {
+		log.Fatal(err)
+	}
 }
 
 var checkIdentifyCMD = &cobra.Command{
 	Use:   "identify",
 	Short: "Check identify properties with given expectations",
 	Long: "Check identify properties with given expectations.\n\n" +
-		"You can set the expectations with the flags.",
+		"You can set the expectations with the flags.\n\n" +
+		"With --detect-changes, the identify properties are compared to the previous identifications\n" +
+		"of the device in the device registry and changes since the last check are reported as warning.",
 	Run: func(cmd *cobra.Command, args []string) {
 		var nilString *string
 		vendor := cmd.Flags().Lookup("vendor").Value.String()
@@ -89,6 +98,7 @@ var checkIdentifyCMD = &cobra.Command{
 			ModelSeriesDiffWarning:  viper.GetBool("checkIdentify.model-series-diff-warning"),
 			OsVersionDiffWarning:    viper.GetBool("checkIdentify.os-version-diff-warning"),
 			SerialNumberDiffWarning: viper.GetBool("checkIdentify.serial-number-diff-warning"),
+			DetectChanges:           viper.GetBool("checkIdentify.detect-changes"),
 		}
 		handleRequest(&r)
 	},
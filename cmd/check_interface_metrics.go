@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"github.com/inexio/thola/internal/request"
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -12,22 +13,48 @@ func init() {
 	checkCMD.AddCommand(checkInterfaceMetricsCMD)
 
 	checkInterfaceMetricsCMD.Flags().Bool("print-interfaces", false, "Print interfaces to plugin output")
+	checkInterfaceMetricsCMD.Flags().Bool("rates", false, "Store the interface counters in the cache and calculate rates based on the previous check")
+	checkInterfaceMetricsCMD.Flags().Float64("utilization-warning", 0, "Warning threshold for the interface utilization in percent (requires 'rates')")
+	checkInterfaceMetricsCMD.Flags().Float64("utilization-critical", 0, "Critical threshold for the interface utilization in percent (requires 'rates')")
+	checkInterfaceMetricsCMD.Flags().String("interface-rules", "", "Threshold rules for interfaces as JSON array (e.g. '[{\"ifType_filter\": [\"^ethernetCsmacd$\"], \"oper_status_mismatch\": \"critical\"}]')")
 }
 
 var checkInterfaceMetricsCMD = &cobra.Command{
 	Use:   "interface-metrics",
 	Short: "Reads all interface metrics and prints them as performance data",
-	Long:  "Reads all interface metrics and prints them as performance data.",
+	Long: "Reads all interface metrics and prints them as performance data.\n\n" +
+		"If rates are enabled, the interface counters are stored in the cache and bit, packet, error and discard rates\n" +
+		"as well as the utilization are calculated based on the counters of the previous check.\n" +
+		"Threshold rules can be used to check oper status, error counters, optical power and radio levels of\n" +
+		"all interfaces that match the filters of a rule.",
 	Run: func(cmd *cobra.Command, args []string) {
 		printInterfaces, err := cmd.Flags().GetBool("print-interfaces")
 		if err != nil {
 			log.Fatal().Err(err).Msg("print-interfaces needs to be a boolean")
 		}
+		calculateRates, err := cmd.Flags().GetBool("rates")
+		if err != nil {
+			log.Fatal().Err(err).Msg("rates needs to be a boolean")
+		}
+		var interfaceRules []request.InterfaceThresholdRule
+		if cmd.Flags().Changed("interface-rules") {
+			rules, err := cmd.Flags().GetString("interface-rules")
+			if err != nil {
+				log.Fatal().Err(err).Msg("interface-rules needs to be a string")
+			}
+			err = json.Unmarshal([]byte(rules), &interfaceRules)
+			if err != nil {
+				log.Fatal().Err(err).Msg("interface-rules needs to be a valid JSON array of rules")
+			}
+		}
 
 		r := request.CheckInterfaceMetricsRequest{
-			PrintInterfaces:    printInterfaces,
-			InterfaceOptions:   getInterfaceOptions(),
-			CheckDeviceRequest: getCheckDeviceRequest(args[0]),
+			PrintInterfaces:       printInterfaces,
+			CalculateRates:        calculateRates,
+			UtilizationThresholds: generateCheckThresholds(cmd, "", "utilization-warning", "", "utilization-critical", true),
+			InterfaceRules:        interfaceRules,
+			InterfaceOptions:      getInterfaceOptions(),
+			CheckDeviceRequest:    getCheckDeviceRequest(args[0]),
 		}
 
 		handleRequest(&r)
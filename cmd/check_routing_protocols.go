@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/inexio/thola/internal/utility"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(checkRoutingProtocolsCMD)
+	checkCMD.AddCommand(checkRoutingProtocolsCMD)
+
+	checkRoutingProtocolsCMD.Flags().Int("expected-ospf-neighbors", 0, "Expected number of OSPF neighbors in full state")
+	checkRoutingProtocolsCMD.Flags().Int("expected-isis-adjacencies", 0, "Expected number of IS-IS adjacencies in up state")
+}
+
+var checkRoutingProtocolsCMD = &cobra.Command{
+	Use:   "routing-protocols",
+	Short: "Check the routing protocol adjacencies of a device",
+	Long: "Checks the OSPF neighbors and IS-IS adjacencies of a device.\n\n" +
+		"The check warns if a neighbor is not in full (OSPF) or up (IS-IS) state and is critical\n" +
+		"if the number of full neighbors or up adjacencies differs from the expected count.",
+	Run: func(cmd *cobra.Command, args []string) {
+		var nilInt *int
+		expectedOSPFNeighbors, err := cmd.Flags().GetInt("expected-ospf-neighbors")
+		if err != nil {
+			log.Fatal().Err(err).Msg("expected-ospf-neighbors needs to be an integer")
+		}
+		expectedISISAdjacencies, err := cmd.Flags().GetInt("expected-isis-adjacencies")
+		if err != nil {
+			log.Fatal().Err(err).Msg("expected-isis-adjacencies needs to be an integer")
+		}
+		r := request.CheckRoutingProtocolsRequest{
+			CheckDeviceRequest:      getCheckDeviceRequest(args[0]),
+			ExpectedOSPFNeighbors:   utility.IfThenElse(cmd.Flags().Changed("expected-ospf-neighbors"), &expectedOSPFNeighbors, nilInt).(*int),
+			ExpectedISISAdjacencies: utility.IfThenElse(cmd.Flags().Changed("expected-isis-adjacencies"), &expectedISISAdjacencies, nilInt).(*int),
+		}
+		handleRequest(&r)
+	},
+}
@@ -0,0 +1,116 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/inexio/thola/internal/credential"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"net/http"
+	"os"
+)
+
+func init() {
+	rootCMD.AddCommand(credentialsCMD)
+	credentialsCMD.AddCommand(credentialsEncryptCMD)
+	credentialsCMD.AddCommand(credentialsVaultStandInCMD)
+
+	credentialsEncryptCMD.Flags().StringP("output", "o", "", "File to write the encrypted credential file to (default: stdout)")
+	credentialsVaultStandInCMD.Flags().String("listen", "127.0.0.1:8200", "Address to listen on for Vault requests")
+}
+
+var credentialsCMD = &cobra.Command{
+	Use:   "credentials",
+	Short: "Work with credential profiles",
+	Long: "Work with credential profiles.\n\n" +
+		"Credential profiles contain SNMP communities and SNMP v3 data, which are used if a request\n" +
+		"references the profile with 'credential_profile' (--snmp-credential-profile) and does not set them itself.\n" +
+		"The profiles are read from the configured credential provider (--credential-provider).\n\n" +
+		"You need to specify what you want to do with a subcommand.",
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(cmd.UsageString())
+	},
+}
+
+var credentialsEncryptCMD = &cobra.Command{
+	Use:   "encrypt [file]",
+	Short: "Create an encrypted credential file",
+	Long: "Create an encrypted credential file for the file credential provider.\n\n" +
+		"The file contains the credential profiles in YAML format, e.g.\n\n" +
+		"  core:\n" +
+		"    communities: [\"public\"]\n" +
+		"    v3_data:\n" +
+		"      level: authPriv\n" +
+		"      user: monitoring\n\n" +
+		"The passphrase is read from the config key 'credentials.key' (env: THOLA_CREDENTIALS_KEY).",
+	Example: "  THOLA_CREDENTIALS_KEY=secret thola credentials encrypt profiles.yaml -o credentials.enc",
+	Args:    cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCMD.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		b, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read credential profiles")
+		}
+		encrypted, err := credential.EncryptProfiles(b, viper.GetString("credentials.key"))
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to encrypt credential profiles")
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			fmt.Println(string(encrypted))
+			return
+		}
+		if err := os.WriteFile(output, encrypted, 0600); err != nil {
+			log.Fatal().Err(err).Msg("failed to write credential file")
+		}
+	},
+}
+
+var credentialsVaultStandInCMD = &cobra.Command{
+	Use:   "vault-stand-in [file]",
+	Short: "Serve credential profiles like a Vault server",
+	Long: "Serve credential profiles like the KV version 2 secrets engine of a Vault server,\n" +
+		"so that the vault credential provider can be used without a Vault server, e.g. for development and tests.\n\n" +
+		"The file contains the unencrypted credential profiles in YAML format (see 'thola credentials encrypt').\n" +
+		"The profiles are served with the configured mount and path (credentials.vault.mount and\n" +
+		"credentials.vault.path) and every request needs the configured token (credentials.vault.token or VAULT_TOKEN).",
+	Example: "  VAULT_TOKEN=secret thola credentials vault-stand-in profiles.yaml\n" +
+		"  VAULT_TOKEN=secret thola identify 10.0.0.1 --credential-provider vault --credential-vault-addr http://127.0.0.1:8200 --snmp-credential-profile core",
+	Args: cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCMD.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		b, err := os.ReadFile(args[0])
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read credential profiles")
+		}
+		profiles, err := credential.ReadProfiles(b)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to read credential profiles")
+		}
+
+		token := viper.GetString("credentials.vault.token")
+		if token == "" {
+			token = os.Getenv("VAULT_TOKEN")
+		}
+		if token == "" {
+			log.Fatal().Msg("no vault token set (credentials.vault.token or VAULT_TOKEN)")
+		}
+
+		addr, _ := cmd.Flags().GetString("listen")
+		handler := credential.NewVaultStandIn(profiles, token, viper.GetString("credentials.vault.mount"), viper.GetString("credentials.vault.path"))
+		log.Info().Str("listen", addr).Int("profiles", len(profiles)).Msg("starting vault stand-in")
+		if err := http.ListenAndServe(addr, handler); err != nil {
+			log.Error().Err(err).Msg("vault stand-in failed")
+			os.Exit(3)
+		}
+	},
+}
@@ -47,10 +47,28 @@ func buildDeviceFlagSet() *flag.FlagSet {
 	fs.String("snmp-v3-auth-proto", "", "The authentication protocol of the SNMP v3 connection (e.g. 'MD5' or 'SHA')")
 	fs.String("snmp-v3-priv-key", "", "The privacy passphrase of the SNMP v3 connection")
 	fs.String("snmp-v3-priv-proto", "", "The privacy protocol of the SNMP v3 connection (e.g. 'DES' or 'AES')")
+	fs.String("snmp-credential-profile", "", "Credential profile of the credential provider that contains the communities and SNMP v3 data")
 	fs.IntSlice("http-port", nil, "Ports for HTTP to use")
 	fs.IntSlice("https-port", nil, "Ports for HTTPS to use")
 	fs.String("http-username", "", "Username for HTTP/HTTPS authorization")
 	fs.String("http-password", "", "Password for HTTP/HTTPS authorization")
+	fs.IntSlice("ssh-port", nil, "Ports for SSH to use")
+	fs.String("ssh-username", "", "Username for the SSH login")
+	fs.String("ssh-password", "", "Password for the SSH login")
+	fs.String("ssh-private-key", "", "Path to a private key file for the SSH login")
+	fs.String("ssh-known-hosts", "", "Path to a known_hosts file to verify SSH host keys")
+	fs.Bool("ssh-insecure-ignore-host-key", false, "Connect via SSH without verifying the host key if no known_hosts file is given")
+	fs.IntSlice("netconf-port", nil, "Ports for NETCONF to use")
+	fs.String("netconf-username", "", "Username for the NETCONF login")
+	fs.String("netconf-password", "", "Password for the NETCONF login")
+	fs.String("netconf-private-key", "", "Path to a private key file for the NETCONF login")
+	fs.String("netconf-known-hosts", "", "Path to a known_hosts file to verify NETCONF host keys")
+	fs.Bool("netconf-insecure-ignore-host-key", false, "Connect via NETCONF without verifying the host key if no known_hosts file is given")
+	fs.IntSlice("gnmi-port", nil, "Ports for gNMI to use (gNMI is preferred over NETCONF)")
+	fs.String("gnmi-username", "", "Username for gNMI requests")
+	fs.String("gnmi-password", "", "Password for gNMI requests")
+	fs.Bool("gnmi-plaintext", false, "Use an unencrypted connection for gNMI instead of TLS")
+	fs.Bool("gnmi-skip-verify", false, "Skip the verification of the TLS certificate of the gNMI connection")
 
 	return fs
 }
@@ -197,6 +215,15 @@ func bindDeviceFlags(cmd *cobra.Command) error {
 			return err
 		}
 	}
+	if x := cmd.Flags().Lookup("snmp-credential-profile"); x != nil {
+		err := viper.BindPFlag("device.snmp-credential-profile", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag snmp-credential-profile")
+			return err
+		}
+	}
 	if x := cmd.Flags().Lookup("http-port"); x != nil {
 		err := viper.BindPFlag("device.http-ports", x)
 		if err != nil {
@@ -233,5 +260,158 @@ func bindDeviceFlags(cmd *cobra.Command) error {
 			return err
 		}
 	}
+	if x := cmd.Flags().Lookup("ssh-port"); x != nil {
+		err := viper.BindPFlag("device.ssh-ports", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-port")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-username"); x != nil {
+		err := viper.BindPFlag("device.ssh-username", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-username")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-password"); x != nil {
+		err := viper.BindPFlag("device.ssh-password", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-password")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-private-key"); x != nil {
+		err := viper.BindPFlag("device.ssh-private-key", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-private-key")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-known-hosts"); x != nil {
+		err := viper.BindPFlag("device.ssh-known-hosts", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-known-hosts")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("ssh-insecure-ignore-host-key"); x != nil {
+		err := viper.BindPFlag("device.ssh-insecure-ignore-host-key", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag ssh-insecure-ignore-host-key")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("netconf-port"); x != nil {
+		err := viper.BindPFlag("device.netconf-ports", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag netconf-port")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("netconf-username"); x != nil {
+		err := viper.BindPFlag("device.netconf-username", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag netconf-username")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("netconf-password"); x != nil {
+		err := viper.BindPFlag("device.netconf-password", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag netconf-password")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("netconf-private-key"); x != nil {
+		err := viper.BindPFlag("device.netconf-private-key", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag netconf-private-key")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("netconf-known-hosts"); x != nil {
+		err := viper.BindPFlag("device.netconf-known-hosts", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag netconf-known-hosts")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("netconf-insecure-ignore-host-key"); x != nil {
+		err := viper.BindPFlag("device.netconf-insecure-ignore-host-key", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag netconf-insecure-ignore-host-key")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("gnmi-port"); x != nil {
+		err := viper.BindPFlag("device.gnmi-ports", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag gnmi-port")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("gnmi-username"); x != nil {
+		err := viper.BindPFlag("device.gnmi-username", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag gnmi-username")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("gnmi-password"); x != nil {
+		err := viper.BindPFlag("device.gnmi-password", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag gnmi-password")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("gnmi-plaintext"); x != nil {
+		err := viper.BindPFlag("device.gnmi-plaintext", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag gnmi-plaintext")
+			return err
+		}
+	}
+	if x := cmd.Flags().Lookup("gnmi-skip-verify"); x != nil {
+		err := viper.BindPFlag("device.gnmi-skip-verify", x)
+		if err != nil {
+			log.Error().
+				AnErr("Error", err).
+				Msg("Can't bind flag gnmi-skip-verify")
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,80 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"fmt"
+	"github.com/inexio/thola/config"
+	"github.com/inexio/thola/internal/deviceclass"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+func init() {
+	rootCMD.AddCommand(deviceClassCMD)
+	deviceClassCMD.AddCommand(deviceClassLintCMD)
+	deviceClassCMD.AddCommand(deviceClassSchemaCMD)
+}
+
+var deviceClassCMD = &cobra.Command{
+	Use:   "deviceclass",
+	Short: "Work with device class files",
+	Long: "Work with device class files.\n\n" +
+		"You need to specify what you want to do with a subcommand.",
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(cmd.UsageString())
+	},
+}
+
+var deviceClassLintCMD = &cobra.Command{
+	Use:   "lint [directory]",
+	Short: "Check device class files for problems",
+	Long: "Check device class files for problems.\n\n" +
+		"All device classes are validated against the device class schema (see 'thola deviceclass schema')\n" +
+		"and converted like it is done at runtime. All problems of all files are reported with their position.\n" +
+		"The directory needs to contain 'generic.yaml', by default the built-in device classes are checked\n" +
+		"together with the device classes of the overlay directory.",
+	Example: "  thola deviceclass lint config/deviceclass",
+	Args:    cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCMD.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		fsys := config.Files()
+		dir := "deviceclass"
+		if len(args) != 0 {
+			fsys, dir = os.DirFS(args[0]), "."
+		}
+
+		problems, err := deviceclass.Lint(fsys, dir)
+		if err != nil {
+			log.Fatal().Err(err).Msg("failed to lint device classes")
+		}
+		for _, p := range problems {
+			if len(args) != 0 {
+				p.File = filepath.Join(args[0], p.File)
+			}
+			fmt.Println(p)
+		}
+		if len(problems) != 0 {
+			fmt.Printf("%d problem(s) found\n", len(problems))
+			os.Exit(1)
+		}
+	},
+}
+
+var deviceClassSchemaCMD = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of device class files",
+	Long: "Print the JSON Schema of device class files.\n\n" +
+		"The schema can be used by editors to validate device classes while writing them,\n" +
+		"e.g. with the comment '# yaml-language-server: $schema=<path to schema>' in the yaml-language-server.",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Print(string(config.DeviceClassSchema))
+	},
+}
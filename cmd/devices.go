@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCMD.AddCommand(devicesCMD)
+	devicesCMD.AddCommand(devicesListCMD)
+
+	devicesListCMD.Flags().String("vendor", "", "Only list devices of this vendor (case insensitive)")
+	devicesListCMD.Flags().String("model", "", "Only list devices of this model (case insensitive)")
+	devicesListCMD.Flags().String("class", "", "Only list devices of this device class")
+	devicesListCMD.Flags().Int("timeout", 0, "Timeout for the request in seconds (0 => no timeout)")
+
+	devicesCMD.AddCommand(devicesHistoryCMD)
+	devicesHistoryCMD.Flags().Int("timeout", 0, "Timeout for the request in seconds (0 => no timeout)")
+}
+
+var devicesCMD = &cobra.Command{
+	Use:   "devices",
+	Short: "Work with the device registry",
+	Long: "Work with the device registry.\n\n" +
+		"The device registry contains every device that was identified with its class, properties,\n" +
+		"the time it was identified first and last and the ports and versions of the last identification.\n" +
+		"Unlike the cache, devices in the registry do not expire.\n\n" +
+		"You need to specify what you want to do with a subcommand.",
+	DisableFlagsInUseLine: true,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(cmd.UsageString())
+	},
+}
+
+var devicesListCMD = &cobra.Command{
+	Use:   "list",
+	Short: "List the devices of the device registry",
+	Long: "List the devices of the device registry sorted by their IP address.\n\n" +
+		"The devices can be filtered by vendor, model and device class.",
+	Example: "  thola devices list\n" +
+		"  thola devices list --vendor cisco --format json",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		vendor, _ := cmd.Flags().GetString("vendor")
+		model, _ := cmd.Flags().GetString("model")
+		class, _ := cmd.Flags().GetString("class")
+		timeout, _ := cmd.Flags().GetInt("timeout")
+
+		r := request.ListDevicesRequest{
+			Vendor:  vendor,
+			Model:   model,
+			Class:   class,
+			Timeout: &timeout,
+		}
+		handleRequest(&r)
+	},
+}
+
+var devicesHistoryCMD = &cobra.Command{
+	Use:   "history [host]",
+	Short: "Show the changes of the identify properties of a device",
+	Long: "Show when the os, vendor, model, model series, serial number or os version\n" +
+		"of a device in the device registry changed between two identifications, oldest first.",
+	Example: "  thola devices history 10.0.0.1",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		timeout, _ := cmd.Flags().GetInt("timeout")
+
+		r := request.DeviceHistoryRequest{
+			IPAddress: args[0],
+			Timeout:   &timeout,
+		}
+		handleRequest(&r)
+	},
+}
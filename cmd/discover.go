@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	rootCMD.AddCommand(discoverCMD)
+
+	discoverCMD.Flags().AddFlagSet(deviceFlagSet)
+	discoverCMD.Flags().Int("workers", 32, "Number of addresses that are discovered at the same time")
+}
+
+var discoverCMD = &cobra.Command{
+	Use:   "discover [cidr]",
+	Short: "Discover and identify all devices of a network",
+	Long: "Discover and identify all devices of a network.\n\n" +
+		"Every address of the network is tried with the given connection data, the same way as with identify.\n" +
+		"The devices that answer are identified and stored in the cache and the device registry.\n" +
+		"The result is an inventory of the devices with their class, properties and the connection data\n" +
+		"that worked, which can also be printed as CSV.",
+	Example: "  thola discover 192.168.178.0/24 --snmp-community public --snmp-community private\n" +
+		"  thola discover 10.0.0.0/22 --workers 64 --format csv > inventory.csv",
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{csvFormatAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		workers, _ := cmd.Flags().GetInt("workers")
+		base := getBaseRequest("")
+
+		r := request.DiscoverRequest{
+			CIDR:           args[0],
+			ConnectionData: base.DeviceData.ConnectionData,
+			Workers:        workers,
+			Timeout:        base.Timeout,
+		}
+		handleRequest(&r)
+	},
+}
@@ -2,22 +2,38 @@ package cmd
 
 import (
 	"github.com/inexio/thola/internal/request"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	addDeviceFlags(identifyCMD)
 	rootCMD.AddCommand(identifyCMD)
+
+	identifyCMD.Flags().Bool("explain", false, "Explain how the device class was identified")
+	identifyCMD.Flags().Bool("trace", false, "Add a trace of every property reader and operator that was used to the response")
 }
 
 var identifyCMD = &cobra.Command{
 	Use:   "identify",
 	Short: "Automatically identify devices",
 	Long: "Automatically identify devices.\n\n" +
-		"It returns properties like vendor, model, serial number,...",
+		"It returns properties like vendor, model, serial number,...\n" +
+		"With --explain every device class and condition that was checked is shown,\n" +
+		"including the values read from the device, the match modes and the results.",
 	Run: func(cmd *cobra.Command, args []string) {
+		explain, err := cmd.Flags().GetBool("explain")
+		if err != nil {
+			log.Fatal().Err(err).Msg("explain needs to be a boolean")
+		}
+		trace, err := cmd.Flags().GetBool("trace")
+		if err != nil {
+			log.Fatal().Err(err).Msg("trace needs to be a boolean")
+		}
 		r := request.IdentifyRequest{
 			BaseRequest: getBaseRequest(args[0]),
+			Explain:     explain,
+			Trace:       trace,
 		}
 		handleRequest(&r)
 	},
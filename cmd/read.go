@@ -3,11 +3,14 @@ package cmd
 import (
 	"fmt"
 	"github.com/inexio/thola/internal/request"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
 func init() {
 	rootCMD.AddCommand(readCMD)
+
+	readCMD.PersistentFlags().Bool("trace", false, "Add a trace of every property reader and operator that was used to the response")
 }
 
 var readCMD = &cobra.Command{
@@ -22,7 +25,12 @@ var readCMD = &cobra.Command{
 }
 
 func getReadRequest(host string) request.ReadRequest {
+	trace, err := readCMD.PersistentFlags().GetBool("trace")
+	if err != nil {
+		log.Fatal().Err(err).Msg("trace needs to be a boolean")
+	}
 	return request.ReadRequest{
 		BaseRequest: getBaseRequest(host),
+		Trace:       trace,
 	}
 }
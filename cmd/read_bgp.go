@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readBGPCMD)
+	readCMD.AddCommand(readBGPCMD)
+}
+
+var readBGPCMD = &cobra.Command{
+	Use:   "bgp",
+	Short: "Read out the bgp peers of a device",
+	Long:  "Read out the bgp peers of a device with their state, AS numbers and received and accepted prefixes.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadBGPRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readInventoryCMD)
+	readCMD.AddCommand(readInventoryCMD)
+}
+
+var readInventoryCMD = &cobra.Command{
+	Use:   "inventory",
+	Short: "Read out the physical inventory of a device",
+	Long:  "Read out the physical inventory of a device like chassis, modules, power supplies and transceivers with their serial numbers and models.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadInventoryRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"github.com/inexio/thola/internal/request"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addDeviceFlags(readRoutingProtocolsCMD)
+	readCMD.AddCommand(readRoutingProtocolsCMD)
+}
+
+var readRoutingProtocolsCMD = &cobra.Command{
+	Use:   "routing-protocols",
+	Short: "Read out the routing protocol adjacencies of a device",
+	Long:  "Read out the OSPF neighbors and IS-IS adjacencies of a device with their states.",
+	Run: func(cmd *cobra.Command, args []string) {
+		request := request.ReadRoutingProtocolsRequest{
+			ReadRequest: getReadRequest(args[0]),
+		}
+		handleRequest(&request)
+	},
+}
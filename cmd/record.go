@@ -0,0 +1,96 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/request"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/rs/xid"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+func init() {
+	addDeviceFlags(recordCMD)
+	rootCMD.AddCommand(recordCMD)
+
+	recordCMD.Flags().StringSlice("oid", nil, "Subtrees that are walked (default: the whole device)")
+	recordCMD.Flags().Bool("requests", false, "Record exactly the data that is requested by identify and the read requests of all available components")
+	recordCMD.Flags().Bool("no-anonymize", false, "Don't replace communities, IP addresses and serial numbers")
+	recordCMD.Flags().StringP("output", "o", "", "The snmprec file that is written, '-' for stdout (default: '<host>.snmprec')")
+}
+
+var recordCMD = &cobra.Command{
+	Use:   "record",
+	Short: "Record the SNMP data of a device",
+	Long: "Record the SNMP data of a device and write it to a snmprec file.\n\n" +
+		"The SNMP connection is discovered like in every other request. By default the whole device is walked,\n" +
+		"with --oid only the given subtrees are walked and with --requests exactly the data that is needed by\n" +
+		"identify and the read requests is recorded. Communities, IP addresses and serial numbers are anonymized.\n" +
+		"The snmprec file can be used with 'thola simulate', 'thola test-deviceclass' and the test suite.",
+	Example: "  thola record 10.0.0.1 --snmp-community secret -o test/testdata/devices/ios/my_device/public.snmprec\n" +
+		"  thola record 10.0.0.1 --requests\n" +
+		"  thola record 10.0.0.1 --oid 1.3.6.1.2.1.1 --oid 1.3.6.1.2.1.2",
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := log.With().Str("request_id", xid.New().String()).Logger()
+		ctx := logger.WithContext(context.Background())
+
+		oids, _ := cmd.Flags().GetStringSlice("oid")
+		requests, _ := cmd.Flags().GetBool("requests")
+		noAnonymize, _ := cmd.Flags().GetBool("no-anonymize")
+		output, _ := cmd.Flags().GetString("output")
+		if output == "" {
+			output = args[0] + ".snmprec"
+		}
+
+		r := request.RecordRequest{
+			OIDs:        oids,
+			Requests:    requests,
+			Anonymize:   !noAnonymize,
+			BaseRequest: getBaseRequest(args[0]),
+		}
+
+		db, err := database.GetDB(ctx)
+		if err != nil {
+			handleError(ctx, err, &r)
+			os.Exit(3)
+		}
+		resp, err := request.ProcessRequest(ctx, &r)
+		_ = db.CloseConnection(ctx)
+		if err != nil {
+			handleError(ctx, err, &r)
+			os.Exit(3)
+		}
+		rec := resp.(*request.RecordResponse).Recording
+
+		if output == "-" {
+			err = snmpsim.WriteRecording(os.Stdout, rec)
+		} else {
+			err = writeRecordingFile(output, rec)
+		}
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to write recording")
+			os.Exit(3)
+		}
+		if output != "-" {
+			fmt.Printf("recorded %d oids to %s\n", rec.Len(), output)
+		}
+	},
+}
+
+func writeRecordingFile(file string, rec *snmpsim.Recording) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := snmpsim.WriteRecording(f, rec); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
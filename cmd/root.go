@@ -6,6 +6,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"github.com/inexio/thola/config"
 	"github.com/inexio/thola/doc"
 	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/parser"
@@ -29,7 +30,7 @@ func init() {
 
 	rootCMD.PersistentFlags().StringVarP(&cfgFile, "config", "c", "", "The location of the config file")
 	rootCMD.PersistentFlags().StringP("loglevel", "l", "error", "The loglevel")
-	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml' or 'pretty')")
+	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml' or 'pretty', 'csv' for discover)")
 	rootCMD.PersistentFlags().String("db-drivername", "built-in", "Database type for caching ('built-in', 'mysql' or 'redis' supported)")
 	rootCMD.PersistentFlags().String("db-duration", "60m", "Duration in which the cache stays valid")
 	rootCMD.PersistentFlags().String("sql-datasourcename", "", "Data sourcename if using a sql driver")
@@ -41,6 +42,10 @@ func init() {
 	rootCMD.PersistentFlags().Bool("db-rebuild", false, "Rebuild the cache DB")
 	rootCMD.PersistentFlags().Bool("no-cache", false, "Don't use a database cache")
 	rootCMD.PersistentFlags().Bool("ignore-db-failure", false, "Ignore the cache if the database fails")
+	rootCMD.PersistentFlags().String("overlay-dir", "", "Directory with device classes and mappings that override or extend the built-in ones")
+	rootCMD.PersistentFlags().String("credential-provider", "", "Provider of credential profiles ('file', 'env' or 'vault')")
+	rootCMD.PersistentFlags().String("credential-file", "", "Encrypted credential file if using the file credential provider")
+	rootCMD.PersistentFlags().String("credential-vault-addr", "", "Vault address if using the vault credential provider")
 	rootCMD.Flags().BoolP("version", "v", false, "Prints the version of Thola")
 
 	err := viper.BindPFlag("config", rootCMD.PersistentFlags().Lookup("config"))
@@ -138,6 +143,38 @@ func init() {
 			Msg("Can't bind flag ignore-db-failure")
 		return
 	}
+
+	err = viper.BindPFlag("overlay.dir", rootCMD.PersistentFlags().Lookup("overlay-dir"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag overlay-dir")
+		return
+	}
+
+	err = viper.BindPFlag("credentials.provider", rootCMD.PersistentFlags().Lookup("credential-provider"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag credential-provider")
+		return
+	}
+
+	err = viper.BindPFlag("credentials.file", rootCMD.PersistentFlags().Lookup("credential-file"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag credential-file")
+		return
+	}
+
+	err = viper.BindPFlag("credentials.vault.addr", rootCMD.PersistentFlags().Lookup("credential-vault-addr"))
+	if err != nil {
+		log.Error().
+			AnErr("Error", err).
+			Msg("Can't bind flag credential-vault-addr")
+		return
+	}
 }
 
 func initConfig() {
@@ -173,7 +210,7 @@ var rootCMD = &cobra.Command{
 		if err != nil {
 			return errors.Wrap(err, "failed to bind device flags")
 		}
-		if !(viper.GetString("format") == "json" || viper.GetString("format") == "xml" || viper.GetString("format") == "pretty") {
+		if !isValidFormat(cmd, viper.GetString("format")) {
 			return errors.New("invalid output format set")
 		}
 		loglevel, err := zerolog.ParseLevel(viper.GetString("loglevel"))
@@ -181,6 +218,10 @@ var rootCMD = &cobra.Command{
 			return errors.New("invalid loglevel set")
 		}
 		zerolog.SetGlobalLevel(loglevel)
+		err = config.SetOverlayDirectory(viper.GetString("overlay.dir"))
+		if err != nil {
+			return errors.Wrap(err, "invalid overlay directory set")
+		}
 		return nil
 	},
 	Run: func(cmd *cobra.Command, args []string) {
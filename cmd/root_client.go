@@ -22,7 +22,7 @@ func init() {
 	log.Logger = zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
 
 	rootCMD.PersistentFlags().StringP("loglevel", "l", "error", "The loglevel")
-	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml' or 'pretty')")
+	rootCMD.PersistentFlags().StringP("format", "f", "pretty", "Output format ('json', 'xml' or 'pretty', 'csv' for discover)")
 	rootCMD.PersistentFlags().StringP("target-api", "t", "", "The URL of the target API")
 	rootCMD.PersistentFlags().String("target-api-username", "", "The username for authorization on the target API")
 	rootCMD.PersistentFlags().String("target-api-password", "", "The password for authorization on the target API")
@@ -111,7 +111,7 @@ var rootCMD = &cobra.Command{
 		if err != nil {
 			return errors.Wrap(err, "failed to bind device flags")
 		}
-		if !(viper.GetString("format") == "json" || viper.GetString("format") == "xml" || viper.GetString("format") == "pretty") {
+		if !isValidFormat(cmd, viper.GetString("format")) {
 			return errors.New("invalid output format set")
 		}
 		if !(viper.GetString("target-api-format") == "json" || viper.GetString("target-api-format") == "xml") {
@@ -8,13 +8,31 @@ import (
 	"github.com/inexio/thola/internal/request"
 	"github.com/inexio/thola/internal/utility"
 	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+// csvFormatAnnotation marks commands whose responses can be printed as CSV.
+const csvFormatAnnotation = "csv-format"
+
+// isValidFormat returns if the output format can be used for the command.
+func isValidFormat(cmd *cobra.Command, format string) bool {
+	switch format {
+	case "json", "xml", "pretty":
+		return true
+	case "csv":
+		_, ok := cmd.Annotations[csvFormatAnnotation]
+		return ok
+	default:
+		return false
+	}
+}
+
 func getBaseRequest(host string) request.BaseRequest {
 	var nullInt *int
 	var nullUInt32 *uint32
 	var nullString *string
+	var nullBool *bool
 	timeout := viper.GetInt("request.timeout")
 	maxRepetitions := viper.GetUint32("device.snmp-max-repetitions")
 	parallelRequests := viper.GetInt("device.snmp-discover-par-requests")
@@ -22,6 +40,20 @@ func getBaseRequest(host string) request.BaseRequest {
 	retries := viper.GetInt("device.snmp-discover-retries")
 	authUsername := viper.GetString("device.http-username")
 	authPassword := viper.GetString("device.http-password")
+	sshUsername := viper.GetString("device.ssh-username")
+	sshPassword := viper.GetString("device.ssh-password")
+	sshPrivateKeyFile := viper.GetString("device.ssh-private-key")
+	sshKnownHostsFile := viper.GetString("device.ssh-known-hosts")
+	sshInsecureIgnoreHostKey := viper.GetBool("device.ssh-insecure-ignore-host-key")
+	netconfUsername := viper.GetString("device.netconf-username")
+	netconfPassword := viper.GetString("device.netconf-password")
+	netconfPrivateKeyFile := viper.GetString("device.netconf-private-key")
+	netconfKnownHostsFile := viper.GetString("device.netconf-known-hosts")
+	netconfInsecureIgnoreHostKey := viper.GetBool("device.netconf-insecure-ignore-host-key")
+	gnmiUsername := viper.GetString("device.gnmi-username")
+	gnmiPassword := viper.GetString("device.gnmi-password")
+	gnmiPlaintext := viper.GetBool("device.gnmi-plaintext")
+	gnmiSkipVerify := viper.GetBool("device.gnmi-skip-verify")
 	v3Level := viper.GetString("device.snmp-v3-level")
 	v3ContextName := viper.GetString("device.snmp-v3-context")
 	v3User := viper.GetString("device.snmp-v3-user")
@@ -29,6 +61,7 @@ func getBaseRequest(host string) request.BaseRequest {
 	v3AuthProto := viper.GetString("device.snmp-v3-auth-proto")
 	v3PrivKey := viper.GetString("device.snmp-v3-priv-key")
 	v3PrivProto := viper.GetString("device.snmp-v3-priv-proto")
+	credentialProfile := viper.GetString("device.snmp-credential-profile")
 	return request.BaseRequest{
 		Timeout: utility.IfThenElse(deviceFlagSet.Changed("timeout"), &timeout, nullInt).(*int),
 		DeviceData: request.DeviceData{
@@ -51,6 +84,7 @@ func getBaseRequest(host string) request.BaseRequest {
 						PrivKey:      utility.IfThenElse(deviceFlagSet.Changed("snmp-v3-priv-key"), &v3PrivKey, nullString).(*string),
 						PrivProtocol: utility.IfThenElse(deviceFlagSet.Changed("snmp-v3-priv-proto"), &v3PrivProto, nullString).(*string),
 					},
+					CredentialProfile: utility.IfThenElse(deviceFlagSet.Changed("snmp-credential-profile"), &credentialProfile, nullString).(*string),
 				},
 				HTTP: &network.HTTPConnectionData{
 					HTTPPorts:    utility.IfThenElse(deviceFlagSet.Changed("http-port"), viper.GetIntSlice("device.http-ports"), []int{}).([]int),
@@ -58,6 +92,29 @@ func getBaseRequest(host string) request.BaseRequest {
 					AuthUsername: utility.IfThenElse(deviceFlagSet.Changed("http-username"), &authUsername, nullString).(*string),
 					AuthPassword: utility.IfThenElse(deviceFlagSet.Changed("http-password"), &authPassword, nullString).(*string),
 				},
+				SSH: &network.SSHConnectionData{
+					Ports:                 utility.IfThenElse(deviceFlagSet.Changed("ssh-port"), viper.GetIntSlice("device.ssh-ports"), []int{}).([]int),
+					Username:              utility.IfThenElse(deviceFlagSet.Changed("ssh-username"), &sshUsername, nullString).(*string),
+					Password:              utility.IfThenElse(deviceFlagSet.Changed("ssh-password"), &sshPassword, nullString).(*string),
+					PrivateKeyFile:        utility.IfThenElse(deviceFlagSet.Changed("ssh-private-key"), &sshPrivateKeyFile, nullString).(*string),
+					KnownHostsFile:        utility.IfThenElse(deviceFlagSet.Changed("ssh-known-hosts"), &sshKnownHostsFile, nullString).(*string),
+					InsecureIgnoreHostKey: utility.IfThenElse(deviceFlagSet.Changed("ssh-insecure-ignore-host-key"), &sshInsecureIgnoreHostKey, nullBool).(*bool),
+				},
+				NETCONF: &network.NETCONFConnectionData{
+					Ports:                 utility.IfThenElse(deviceFlagSet.Changed("netconf-port"), viper.GetIntSlice("device.netconf-ports"), []int{}).([]int),
+					Username:              utility.IfThenElse(deviceFlagSet.Changed("netconf-username"), &netconfUsername, nullString).(*string),
+					Password:              utility.IfThenElse(deviceFlagSet.Changed("netconf-password"), &netconfPassword, nullString).(*string),
+					PrivateKeyFile:        utility.IfThenElse(deviceFlagSet.Changed("netconf-private-key"), &netconfPrivateKeyFile, nullString).(*string),
+					KnownHostsFile:        utility.IfThenElse(deviceFlagSet.Changed("netconf-known-hosts"), &netconfKnownHostsFile, nullString).(*string),
+					InsecureIgnoreHostKey: utility.IfThenElse(deviceFlagSet.Changed("netconf-insecure-ignore-host-key"), &netconfInsecureIgnoreHostKey, nullBool).(*bool),
+				},
+				GNMI: &network.GNMIConnectionData{
+					Ports:      utility.IfThenElse(deviceFlagSet.Changed("gnmi-port"), viper.GetIntSlice("device.gnmi-ports"), []int{}).([]int),
+					Username:   utility.IfThenElse(deviceFlagSet.Changed("gnmi-username"), &gnmiUsername, nullString).(*string),
+					Password:   utility.IfThenElse(deviceFlagSet.Changed("gnmi-password"), &gnmiPassword, nullString).(*string),
+					Plaintext:  utility.IfThenElse(deviceFlagSet.Changed("gnmi-plaintext"), &gnmiPlaintext, nullBool).(*bool),
+					SkipVerify: utility.IfThenElse(deviceFlagSet.Changed("gnmi-skip-verify"), &gnmiSkipVerify, nullBool).(*bool),
+				},
 			},
 		},
 	}
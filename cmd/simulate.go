@@ -0,0 +1,71 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+)
+
+func init() {
+	rootCMD.AddCommand(simulateCMD)
+
+	simulateCMD.Flags().String("dir", "", "Directory which contains the snmprec files")
+	simulateCMD.Flags().String("listen", "127.0.0.1:161", "Address to listen on for SNMP requests")
+}
+
+var simulateCMD = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate SNMP devices with snmprec files",
+	Long: "Simulate SNMP devices by serving the data of snmprec files.\n\n" +
+		"All snmprec files in the directory and its subdirectories are served. Like in snmpsim,\n" +
+		"a file is selected by the community (SNMP v1/v2c) or the context name (SNMP v3 noAuthNoPriv),\n" +
+		"which is the path of the file relative to the directory without the file extension,\n" +
+		"e.g. 'ios/7206VXR/public' for 'ios/7206VXR/public.snmprec'.",
+	Example: "  thola simulate --dir test/testdata/devices --listen 127.0.0.1:1161\n" +
+		"  thola identify 127.0.0.1 --snmp-port 1161 --snmp-community ios/7206VXR/public",
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCMD.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(log.Logger.WithContext(context.Background()))
+		defer cancel()
+
+		dir, _ := cmd.Flags().GetString("dir")
+		if dir == "" {
+			log.Ctx(ctx).Fatal().Msg("no snmprec directory given (--dir)")
+		}
+		addr, _ := cmd.Flags().GetString("listen")
+
+		agent := snmpsim.NewAgent()
+		if err := agent.LoadDir(dir); err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("failed to load snmprec files")
+		}
+		communities := agent.Communities()
+		if len(communities) == 0 {
+			log.Ctx(ctx).Fatal().Str("dir", dir).Msg("no snmprec files found")
+		}
+		for _, community := range communities {
+			log.Ctx(ctx).Info().Str("community", community).Msg("serving recording")
+		}
+
+		go func() {
+			quit := make(chan os.Signal, 1)
+			signal.Notify(quit, os.Interrupt)
+			<-quit
+			cancel()
+		}()
+
+		log.Ctx(ctx).Info().Str("listen", addr).Msg("starting snmp simulator")
+		if err := agent.ListenAndServe(ctx, addr); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("snmp simulator failed")
+			os.Exit(3)
+		}
+	},
+}
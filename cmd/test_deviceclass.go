@@ -0,0 +1,104 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/deviceclasstest"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"os"
+)
+
+func init() {
+	rootCMD.AddCommand(testDeviceClassCMD)
+
+	testDeviceClassCMD.Flags().String("golden", "", "Golden file which contains the expected results (default: the recording with the extension '"+deviceclasstest.GoldenFileExtension+"')")
+	testDeviceClassCMD.Flags().Bool("update", false, "Write the results to the golden file instead of comparing them")
+}
+
+var testDeviceClassCMD = &cobra.Command{
+	Use:   "test-deviceclass [recording...]",
+	Short: "Test device classes against SNMP recordings",
+	Long: "Test device classes against SNMP recordings.\n\n" +
+		"Identify and all read requests of the available components are run against the recording without\n" +
+		"any network communication and the results are compared to the golden file of the recording.\n" +
+		"Recordings can be snmprec files or the output of 'snmpwalk -On' (every other file extension).\n" +
+		"Use --update to create or update the golden files after verifying the results.",
+	Example: "  thola test-deviceclass test/testdata/devices/ios/7206VXR/public.snmprec\n" +
+		"  thola test-deviceclass --update device.walk",
+	Args: cobra.MinimumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCMD.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := log.Logger.WithContext(context.Background())
+
+		golden, _ := cmd.Flags().GetString("golden")
+		if golden != "" && len(args) > 1 {
+			log.Ctx(ctx).Fatal().Msg("--golden can only be used with a single recording")
+		}
+		update, _ := cmd.Flags().GetBool("update")
+
+		// results of other devices must not be used
+		viper.Set("db.no-cache", true)
+
+		failed := false
+		for _, recording := range args {
+			goldenFile := golden
+			if goldenFile == "" {
+				goldenFile = deviceclasstest.GoldenFile(recording)
+			}
+			if !testDeviceClass(ctx, recording, goldenFile, update) {
+				failed = true
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func testDeviceClass(ctx context.Context, recording, goldenFile string, update bool) bool {
+	rec, err := snmpsim.ReadRecordingFile(recording)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to read recording")
+		return false
+	}
+
+	res, err := deviceclasstest.Run(ctx, rec)
+	if err != nil {
+		fmt.Printf("FAIL %s: %s\n", recording, err)
+		return false
+	}
+
+	if update {
+		if err := deviceclasstest.WriteGoldenFile(goldenFile, res); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to write golden file")
+			return false
+		}
+		fmt.Printf("updated %s: %s (%d requests)\n", recording, goldenFile, len(res))
+		return true
+	}
+
+	expected, err := deviceclasstest.ReadGoldenFile(goldenFile)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to read golden file, use --update to create it")
+		return false
+	}
+	diff, err := deviceclasstest.Diff(expected, res)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to compare results")
+		return false
+	}
+	if diff != "" {
+		fmt.Printf("FAIL %s: results differ from %s (-expected +actual):\n%s\n", recording, goldenFile, diff)
+		return false
+	}
+	fmt.Printf("ok   %s\n", recording)
+	return true
+}
@@ -0,0 +1,110 @@
+//go:build !client
+// +build !client
+
+package cmd
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/trap"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"time"
+)
+
+func init() {
+	rootCMD.AddCommand(trapReceiverCMD)
+
+	trapReceiverCMD.Flags().String("listen", "0.0.0.0:162", "Address to listen on for SNMP traps and informs")
+	trapReceiverCMD.Flags().StringSlice("community", []string{}, "Accepted communities of SNMP v1/v2c events (default: all)")
+	trapReceiverCMD.Flags().String("webhook", "", "URL the events are sent to as JSON (default: stdout)")
+	trapReceiverCMD.Flags().Duration("webhook-timeout", 5*time.Second, "Timeout of a webhook request")
+	trapReceiverCMD.Flags().String("snmp-v3-level", "", "The level of accepted SNMP v3 events ('noAuthNoPriv', 'authNoPriv' or 'authPriv')")
+	trapReceiverCMD.Flags().String("snmp-v3-user", "", "The username of accepted SNMP v3 events")
+	trapReceiverCMD.Flags().String("snmp-v3-auth-key", "", "The authentication passphrase of accepted SNMP v3 events")
+	trapReceiverCMD.Flags().String("snmp-v3-auth-proto", "", "The authentication protocol of accepted SNMP v3 events (e.g. 'MD5' or 'SHA')")
+	trapReceiverCMD.Flags().String("snmp-v3-priv-key", "", "The privacy passphrase of accepted SNMP v3 events")
+	trapReceiverCMD.Flags().String("snmp-v3-priv-proto", "", "The privacy protocol of accepted SNMP v3 events (e.g. 'DES' or 'AES')")
+}
+
+var trapReceiverCMD = &cobra.Command{
+	Use:   "trap-receiver",
+	Short: "Receive SNMP traps and informs",
+	Long: "Receive SNMP v1/v2c/v3 traps and informs and forward them as JSON.\n\n" +
+		"The sender of each event is matched against the cached device class and the varbinds are decoded\n" +
+		"with the same mappings that are used for read requests, e.g. the state of a power supply is\n" +
+		"reported in the same format as by 'read hardware-health'.\n" +
+		"The events are printed as newline-delimited JSON or sent to a webhook.",
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return rootCMD.PersistentPreRunE(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithCancel(log.Logger.WithContext(context.Background()))
+		defer cancel()
+
+		var v3Data *network.SNMPv3ConnectionData
+		if cmd.Flags().Changed("snmp-v3-user") || cmd.Flags().Changed("snmp-v3-level") {
+			v3Data = &network.SNMPv3ConnectionData{
+				Level:        getChangedStringFlag(cmd, "snmp-v3-level"),
+				User:         getChangedStringFlag(cmd, "snmp-v3-user"),
+				AuthKey:      getChangedStringFlag(cmd, "snmp-v3-auth-key"),
+				AuthProtocol: getChangedStringFlag(cmd, "snmp-v3-auth-proto"),
+				PrivKey:      getChangedStringFlag(cmd, "snmp-v3-priv-key"),
+				PrivProtocol: getChangedStringFlag(cmd, "snmp-v3-priv-proto"),
+			}
+		}
+
+		listener, err := network.NewSNMPTrapListener(v3Data)
+		if err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("failed to create trap listener")
+		}
+
+		var forwarder trap.Forwarder
+		if webhook, _ := cmd.Flags().GetString("webhook"); webhook != "" {
+			timeout, _ := cmd.Flags().GetDuration("webhook-timeout")
+			forwarder = trap.NewWebhookForwarder(webhook, timeout)
+		} else {
+			forwarder = trap.NewWriterForwarder(os.Stdout)
+		}
+
+		db, err := database.GetDB(ctx)
+		if err != nil {
+			log.Ctx(ctx).Fatal().Err(err).Msg("failed to get database")
+		}
+
+		communities, _ := cmd.Flags().GetStringSlice("community")
+		addr, _ := cmd.Flags().GetString("listen")
+
+		go func() {
+			quit := make(chan os.Signal, 1)
+			signal.Notify(quit, os.Interrupt)
+			<-quit
+			cancel()
+		}()
+
+		err = trap.NewReceiver(listener, db, forwarder, communities).Listen(ctx, addr)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("trap receiver failed")
+		}
+
+		if dbErr := db.CloseConnection(ctx); dbErr != nil {
+			log.Ctx(ctx).Error().Err(dbErr).Msg("failed to close connection to the database")
+			os.Exit(3)
+		}
+		if err != nil {
+			os.Exit(3)
+		}
+	},
+}
+
+func getChangedStringFlag(cmd *cobra.Command, name string) *string {
+	if !cmd.Flags().Changed(name) {
+		return nil
+	}
+	v, _ := cmd.Flags().GetString(name)
+	return &v
+}
@@ -2,12 +2,21 @@ package codecommunicator
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
 	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/textfsm"
 	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
 )
 
 type codeCommunicator struct {
@@ -15,51 +24,93 @@ type codeCommunicator struct {
 	parent      communicator.Communicator
 }
 
-// GetCodeCommunicator returns the code communicator for the given device class
+// Constructor creates a code communicator for a device class. The parent is the network device communicator
+// of the parent device class.
+type Constructor func(deviceClass, parent communicator.Communicator) communicator.Functions
+
+var registry = struct {
+	sync.RWMutex
+	constructors map[string]Constructor
+}{
+	constructors: make(map[string]Constructor),
+}
+
+// builtIn are the code communicators of the built-in device classes.
+var builtIn = map[string]func(base codeCommunicator) communicator.Functions{
+	"ceraos/ip10":  func(base codeCommunicator) communicator.Functions { return &ceraosIP10Communicator{base} },
+	"ceraos/ip20":  func(base codeCommunicator) communicator.Functions { return &ceraosIP20Communicator{base} },
+	"powerone/acc": func(base codeCommunicator) communicator.Functions { return &poweroneACCCommunicator{base} },
+	"powerone/pcc": func(base codeCommunicator) communicator.Functions { return &poweronePCCCommunicator{base} },
+	"ironware":     func(base codeCommunicator) communicator.Functions { return &ironwareCommunicator{base} },
+	"ios":          func(base codeCommunicator) communicator.Functions { return &iosCommunicator{base} },
+	"ekinops":      func(base codeCommunicator) communicator.Functions { return &ekinopsCommunicator{base} },
+	"adva_fsp3kr7": func(base codeCommunicator) communicator.Functions { return &advaCommunicator{base} },
+	"timos/sas":    func(base codeCommunicator) communicator.Functions { return &timosSASCommunicator{base} },
+	"timos":        func(base codeCommunicator) communicator.Functions { return &timosCommunicator{base} },
+	"junos":        func(base codeCommunicator) communicator.Functions { return &junosCommunicator{base} },
+	"aviat":        func(base codeCommunicator) communicator.Functions { return &aviatCommunicator{base} },
+	"fortigate":    func(base codeCommunicator) communicator.Functions { return &fortigateCommunicator{base} },
+	"linux":        func(base codeCommunicator) communicator.Functions { return &linuxCommunicator{base} },
+	"vmware-esxi":  func(base codeCommunicator) communicator.Functions { return &vmwareESXiCommunicator{base} },
+	"aruba":        func(base codeCommunicator) communicator.Functions { return &arubaCommunicator{base} },
+}
+
+func init() {
+	for classIdentifier, newCommunicator := range builtIn {
+		newCommunicator := newCommunicator
+		Register(classIdentifier, func(deviceClass, parent communicator.Communicator) communicator.Functions {
+			return newCommunicator(codeCommunicator{
+				deviceClass: deviceClass,
+				parent:      parent,
+			})
+		})
+	}
+}
+
+// Register makes a code communicator available for the device class with the given identifier.
+// It is meant to be called from init functions and panics if the constructor is nil
+// or a code communicator is already registered for the device class.
+func Register(classIdentifier string, constructor Constructor) {
+	registry.Lock()
+	defer registry.Unlock()
+	if constructor == nil {
+		panic("codecommunicator: constructor is nil")
+	}
+	if _, ok := registry.constructors[classIdentifier]; ok {
+		panic(fmt.Sprintf("codecommunicator: code communicator for device class identifier '%s' is already registered", classIdentifier))
+	}
+	registry.constructors[classIdentifier] = constructor
+}
+
+// GetCodeCommunicator returns the code communicator for the given device class.
+// A script in the overlay directory takes precedence over a registered code communicator,
+// functions which are not implemented by the script are passed on to the registered one.
 func GetCodeCommunicator(deviceClass communicator.Communicator, parentNetworkDeviceCommunicator communicator.Communicator) (communicator.Functions, error) {
 	if deviceClass == nil {
 		return nil, errors.New("device class is empty")
 	}
-	var base = codeCommunicator{
-		deviceClass: deviceClass,
-		parent:      parentNetworkDeviceCommunicator,
-	}
 	classIdentifier := deviceClass.GetIdentifier()
-	switch classIdentifier {
-	case "ceraos/ip10":
-		return &ceraosIP10Communicator{base}, nil
-	case "ceraos/ip20":
-		return &ceraosIP20Communicator{base}, nil
-	case "powerone/acc":
-		return &poweroneACCCommunicator{base}, nil
-	case "powerone/pcc":
-		return &poweronePCCCommunicator{base}, nil
-	case "ironware":
-		return &ironwareCommunicator{base}, nil
-	case "ios":
-		return &iosCommunicator{base}, nil
-	case "ekinops":
-		return &ekinopsCommunicator{base}, nil
-	case "adva_fsp3kr7":
-		return &advaCommunicator{base}, nil
-	case "timos/sas":
-		return &timosSASCommunicator{base}, nil
-	case "timos":
-		return &timosCommunicator{base}, nil
-	case "junos":
-		return &junosCommunicator{base}, nil
-	case "aviat":
-		return &aviatCommunicator{base}, nil
-	case "fortigate":
-		return &fortigateCommunicator{base}, nil
-	case "linux":
-		return &linuxCommunicator{base}, nil
-	case "vmware-esxi":
-		return &vmwareESXiCommunicator{base}, nil
-	case "aruba":
-		return &arubaCommunicator{base}, nil
-	}
-	return nil, tholaerr.NewNotFoundError(fmt.Sprintf("no code communicator found for device class identifier '%s'", classIdentifier))
+
+	var registered communicator.Functions
+	registry.RLock()
+	constructor, ok := registry.constructors[classIdentifier]
+	registry.RUnlock()
+	if ok {
+		registered = constructor(deviceClass, parentNetworkDeviceCommunicator)
+	}
+
+	script, err := getScriptCommunicator(classIdentifier, deviceClass, parentNetworkDeviceCommunicator, registered)
+	if err == nil {
+		return script, nil
+	}
+	if !tholaerr.IsNotFoundError(err) {
+		return nil, errors.Wrapf(err, "failed to get code communicator script for device class identifier '%s'", classIdentifier)
+	}
+
+	if registered == nil {
+		return nil, tholaerr.NewNotFoundError(fmt.Sprintf("no code communicator found for device class identifier '%s'", classIdentifier))
+	}
+	return registered, nil
 }
 
 func (c *codeCommunicator) GetVendor(_ context.Context) (string, error) {
@@ -222,6 +273,26 @@ func (c *codeCommunicator) GetHighAvailabilityComponentNodes(_ context.Context)
 	return 0, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
 }
 
+func (c *codeCommunicator) GetInventoryComponentEntities(_ context.Context) ([]device.InventoryComponentEntity, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetNeighborsComponentNeighbors(_ context.Context) ([]device.NeighborsComponentNeighbor, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetBGPComponentPeers(_ context.Context) ([]device.BGPComponentPeer, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetRoutingProtocolsComponentOSPFNeighbors(_ context.Context) ([]device.RoutingProtocolsComponentOSPFNeighbor, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
+func (c *codeCommunicator) GetRoutingProtocolsComponentISISAdjacencies(_ context.Context) ([]device.RoutingProtocolsComponentISISAdjacency, error) {
+	return nil, tholaerr.NewNotImplementedError("function is not implemented for this communicator")
+}
+
 func filterInterfaces(ctx context.Context, interfaces []device.Interface, filter []groupproperty.Filter) ([]device.Interface, error) {
 	if len(filter) == 0 {
 		return interfaces, nil
@@ -248,3 +319,136 @@ func filterInterfaces(ctx context.Context, interfaces []device.Interface, filter
 
 	return res, nil
 }
+
+// getValuesByIndex walks the given oid and returns the values mapped by their index.
+func getValuesByIndex(ctx context.Context, oid network.OID, raw bool) (map[string]value.Value, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	responses, err := con.SNMP.SnmpClient.SNMPWalk(ctx, oid)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to walk oid '%s'", oid)
+	}
+
+	res := make(map[string]value.Value)
+	for _, response := range responses {
+		idx, err := response.GetOID().GetIndexAfterOID(oid)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get index after oid")
+		}
+		var val value.Value
+		if raw {
+			val, err = response.GetValueRaw()
+		} else {
+			val, err = response.GetValue()
+		}
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msgf("failed to get value of oid '%s'", response.GetOID())
+			continue
+		}
+		res[idx] = val
+	}
+
+	return res, nil
+}
+
+// getBGPPrefixCounts walks a prefix counter whose indices end with <afi>.<safi> and returns the sum over all address families per peer index.
+func getBGPPrefixCounts(ctx context.Context, oid network.OID) (map[string]uint64, error) {
+	values, err := getValuesByIndex(ctx, oid, false)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]uint64)
+	for idx, val := range values {
+		parts := strings.Split(idx, ".")
+		if len(parts) < 3 {
+			return nil, fmt.Errorf("invalid prefix counter index '%s'", idx)
+		}
+		count, err := val.UInt64()
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse prefix count '%s'", val)
+		}
+		res[strings.Join(parts[:len(parts)-2], ".")] += count
+	}
+
+	return res, nil
+}
+
+// getBGPPrefixCountsByInetAddress works like getBGPPrefixCounts for prefix counters whose indices start with
+// <address type>.<address length>.<address> (InetAddressType and InetAddress) and returns the counts per peer address.
+func getBGPPrefixCountsByInetAddress(ctx context.Context, oid network.OID) (map[string]uint64, error) {
+	counts, err := getBGPPrefixCounts(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]uint64)
+	for idx, count := range counts {
+		address, rest, err := parseInetAddressIndex(strings.Split(idx, "."))
+		if err != nil || len(rest) != 0 {
+			return nil, fmt.Errorf("invalid prefix counter index '%s'", idx)
+		}
+		res[address] += count
+	}
+
+	return res, nil
+}
+
+// parseInetAddressIndex parses an index that starts with <address type>.<address length>.<address>
+// and returns the ip address and the remaining index parts.
+func parseInetAddressIndex(parts []string) (string, []string, error) {
+	if len(parts) < 2 {
+		return "", nil, errors.New("index is too short")
+	}
+	length, err := strconv.Atoi(parts[1])
+	if err != nil || len(parts) < 2+length {
+		return "", nil, errors.New("invalid address length")
+	}
+	b := make([]byte, length)
+	for i := range b {
+		octet, err := strconv.ParseUint(parts[2+i], 10, 8)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "invalid address octet")
+		}
+		b[i] = byte(octet)
+	}
+	address, err := hexToIPAddress(hex.EncodeToString(b))
+	if err != nil {
+		return "", nil, err
+	}
+	return address, parts[2+length:], nil
+}
+
+// hexToIPAddress converts a raw hex encoded ip address to its string representation.
+func hexToIPAddress(s string) (string, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode hex string")
+	}
+	if len(b) != net.IPv4len && len(b) != net.IPv6len {
+		return "", fmt.Errorf("invalid ip address length '%d'", len(b))
+	}
+	return net.IP(b).String(), nil
+}
+
+// runSSHCommand runs a command on the device via SSH and parses its output with the given TextFSM template.
+func runSSHCommand(ctx context.Context, command string, template *textfsm.Template) ([]textfsm.Record, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SSH == nil {
+		return nil, tholaerr.NewNotFoundError("no ssh connection available")
+	}
+
+	output, err := con.SSH.SSHClient.Run(ctx, command)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run command '%s'", command)
+	}
+
+	records, err := template.Parse(output)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse output of command '%s'", command)
+	}
+	return records, nil
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/textfsm"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
 	"github.com/shopspring/decimal"
 	"strconv"
 	"strings"
@@ -15,6 +17,12 @@ type iosCommunicator struct {
 	codeCommunicator
 }
 
+var iosCPUUtilizationTemplate = textfsm.MustNewTemplate(`Value Required FiveMinutes (\d+)
+
+Start
+  ^CPU utilization for five seconds: \S+; one minute: \d+%; five minutes: ${FiveMinutes}% -> Record
+`)
+
 // GetCPUComponentCPULoad returns the cpu load of ios devices.
 func (c *iosCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
@@ -26,7 +34,12 @@ func (c *iosCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.
 	cpuLoad5minDeprecated, err1 := con.SNMP.SnmpClient.SNMPWalk(ctx, "1.3.6.1.4.1.9.9.109.1.1.1.1.5")
 	cpuLoad5min, err2 := con.SNMP.SnmpClient.SNMPWalk(ctx, "1.3.6.1.4.1.9.9.109.1.1.1.1.8")
 	if err1 != nil && err2 != nil {
-		return nil, errors.New("snmpwalks failed")
+		cpus, err := c.getCPULoadByCLI(ctx)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to get cpu load via cli")
+			return nil, errors.New("snmpwalks failed")
+		}
+		return cpus, nil
 	}
 
 	indices := make(map[string]int)
@@ -99,6 +112,23 @@ func (c *iosCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.
 	return cpus, nil
 }
 
+// getCPULoadByCLI reads the cpu load from the output of "show processes cpu" via SSH.
+func (c *iosCommunicator) getCPULoadByCLI(ctx context.Context) ([]device.CPU, error) {
+	records, err := runSSHCommand(ctx, "show processes cpu | include CPU utilization", iosCPUUtilizationTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no cpu utilization found in command output")
+	}
+
+	load, err := strconv.ParseFloat(records[0]["FiveMinutes"].(string), 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse cpu load")
+	}
+	return []device.CPU{{Load: &load}}, nil
+}
+
 func (c *iosCommunicator) getCPUBySNMPResponse(res network.SNMPResponse) (device.CPU, error) {
 	val, err := res.GetValue()
 	if err != nil {
@@ -301,3 +331,192 @@ func (c *iosCommunicator) getChassisPowerSupply(ctx context.Context, id int) (de
 	}
 	return device.HardwareHealthComponentPowerSupply{}, errors.New("power supply not found")
 }
+
+// GetNeighborsComponentNeighbors returns the lldp and cdp neighbors of ios devices.
+func (c *iosCommunicator) GetNeighborsComponentNeighbors(ctx context.Context) ([]device.NeighborsComponentNeighbor, error) {
+	lldpNeighbors, lldpErr := c.deviceClass.GetNeighborsComponentNeighbors(ctx)
+	if lldpErr != nil {
+		log.Ctx(ctx).Debug().Err(lldpErr).Msg("failed to read out lldp neighbors")
+	}
+
+	cdpNeighbors, cdpErr := c.getCDPNeighbors(ctx)
+	if cdpErr != nil {
+		log.Ctx(ctx).Debug().Err(cdpErr).Msg("failed to read out cdp neighbors")
+		if lldpErr != nil {
+			return nil, errors.Wrap(cdpErr, "failed to read out lldp and cdp neighbors")
+		}
+	}
+
+	return mergeLLDPAndCDPNeighbors(lldpNeighbors, cdpNeighbors), nil
+}
+
+// mergeLLDPAndCDPNeighbors returns the lldp neighbors and all cdp neighbors that were not also found via lldp.
+// Neighbors are the same if they are connected to the same local port and have the same system name.
+// Missing values of lldp neighbors are filled in with the values of the matching cdp neighbor.
+func mergeLLDPAndCDPNeighbors(lldpNeighbors, cdpNeighbors []device.NeighborsComponentNeighbor) []device.NeighborsComponentNeighbor {
+	neighborKey := func(neighbor device.NeighborsComponentNeighbor) (string, bool) {
+		if neighbor.LocalPort == nil || neighbor.RemoteSystemName == nil {
+			return "", false
+		}
+		return *neighbor.LocalPort + "\x00" + strings.ToLower(*neighbor.RemoteSystemName), true
+	}
+
+	res := lldpNeighbors
+	lldpByKey := make(map[string]int)
+	for i, neighbor := range lldpNeighbors {
+		if key, ok := neighborKey(neighbor); ok {
+			lldpByKey[key] = i
+		}
+	}
+
+	for _, neighbor := range cdpNeighbors {
+		key, ok := neighborKey(neighbor)
+		if !ok {
+			res = append(res, neighbor)
+			continue
+		}
+		i, ok := lldpByKey[key]
+		if !ok {
+			res = append(res, neighbor)
+			continue
+		}
+		if res[i].RemotePortID == nil {
+			res[i].RemotePortID = neighbor.RemotePortID
+		}
+		if res[i].RemoteManagementAddress == nil {
+			res[i].RemoteManagementAddress = neighbor.RemoteManagementAddress
+		}
+	}
+
+	return res
+}
+
+func (c *iosCommunicator) getCDPNeighbors(ctx context.Context) ([]device.NeighborsComponentNeighbor, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, errors.New("no device connection available")
+	}
+
+	cdpCacheDeviceID := network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.6")
+	deviceIDs, err := con.SNMP.SnmpClient.SNMPWalk(ctx, cdpCacheDeviceID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read out cdp cache device ids")
+	}
+
+	devicePorts := c.getCDPCacheColumn(ctx, "1.3.6.1.4.1.9.9.23.1.2.1.1.7", false)
+	sysNames := c.getCDPCacheColumn(ctx, "1.3.6.1.4.1.9.9.23.1.2.1.1.17", false)
+	addressTypes := c.getCDPCacheColumn(ctx, "1.3.6.1.4.1.9.9.23.1.2.1.1.3", false)
+	addresses := c.getCDPCacheColumn(ctx, "1.3.6.1.4.1.9.9.23.1.2.1.1.4", true)
+
+	var neighbors []device.NeighborsComponentNeighbor
+	for _, deviceIDResponse := range deviceIDs {
+		// cdp cache indices consist of <ifIndex>.<device index>
+		idx, err := deviceIDResponse.GetOID().GetIndexAfterOID(cdpCacheDeviceID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get cdp cache index")
+		}
+		deviceID, err := deviceIDResponse.GetValue()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get cdp cache device id")
+		}
+		deviceIDString := deviceID.String()
+
+		neighbor := device.NeighborsComponentNeighbor{
+			RemoteChassisID:  &deviceIDString,
+			RemoteSystemName: &deviceIDString,
+		}
+
+		if sysName, ok := sysNames[idx]; ok && sysName != "" {
+			neighbor.RemoteSystemName = &sysName
+		}
+
+		if port, ok := devicePorts[idx]; ok {
+			neighbor.RemotePortID = &port
+		}
+
+		// address type 1 = ip
+		if addressType, ok := addressTypes[idx]; ok && addressType == "1" {
+			if address, err := hexToIPAddress(addresses[idx]); err == nil {
+				neighbor.RemoteManagementAddress = &address
+			}
+		}
+
+		if ifIndex := strings.Split(idx, "."); len(ifIndex) == 2 {
+			if localPort, err := c.getCDPLocalPort(ctx, con, ifIndex[0]); err == nil {
+				neighbor.LocalPort = &localPort
+			} else {
+				log.Ctx(ctx).Debug().Err(err).Msgf("failed to get local port for ifIndex '%s'", ifIndex[0])
+			}
+		}
+
+		neighbors = append(neighbors, neighbor)
+	}
+
+	return neighbors, nil
+}
+
+// getCDPCacheColumn walks a column of the cdp cache table and returns the values mapped by their index.
+func (c *iosCommunicator) getCDPCacheColumn(ctx context.Context, oid network.OID, raw bool) map[string]string {
+	res := make(map[string]string)
+
+	values, err := getValuesByIndex(ctx, oid, raw)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msgf("failed to read out cdp cache column '%s'", oid)
+		return res
+	}
+
+	for idx, val := range values {
+		res[idx] = val.String()
+	}
+
+	return res
+}
+
+func (c *iosCommunicator) getCDPLocalPort(ctx context.Context, con *network.RequestDeviceConnection, ifIndex string) (string, error) {
+	// try ifName first, if it fails try ifDescr
+	for _, oid := range []network.OID{"1.3.6.1.2.1.31.1.1.1.1", "1.3.6.1.2.1.2.2.1.2"} {
+		res, err := con.SNMP.SnmpClient.SNMPGet(ctx, oid.AddIndex(ifIndex))
+		if err != nil || len(res) == 0 {
+			continue
+		}
+		val, err := res[0].GetValue()
+		if err != nil || val.String() == "" {
+			continue
+		}
+		return val.String(), nil
+	}
+	return "", errors.New("failed to read out ifName and ifDescr")
+}
+
+// GetBGPComponentPeers returns the bgp peers of ios devices.
+func (c *iosCommunicator) GetBGPComponentPeers(ctx context.Context) ([]device.BGPComponentPeer, error) {
+	peers, err := c.deviceClass.GetBGPComponentPeers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// CISCO-BGP4-MIB cbgpPeer2AcceptedPrefixes, indices consist of <address type>.<address length>.<peer address>.<afi>.<safi>
+	accepted, err := getBGPPrefixCountsByInetAddress(ctx, "1.3.6.1.4.1.9.9.187.1.2.8.1.1")
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read out cbgpPeer2AcceptedPrefixes, falling back to cbgpPeerAcceptedPrefixes")
+
+		// cbgpPeerAcceptedPrefixes, indices consist of <peer address>.<afi>.<safi>
+		accepted, err = getBGPPrefixCounts(ctx, "1.3.6.1.4.1.9.9.187.1.2.4.1.1")
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to read out accepted prefixes of bgp peers")
+			return peers, nil
+		}
+	}
+
+	for i, peer := range peers {
+		if peer.PeerAddress == nil {
+			continue
+		}
+		// CISCO-BGP4-MIB has no counter of the received prefixes, so only the accepted prefixes are set
+		if count, ok := accepted[*peer.PeerAddress]; ok {
+			peers[i].PrefixesAccepted = &count
+		}
+	}
+
+	return peers, nil
+}
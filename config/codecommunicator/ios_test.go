@@ -330,3 +330,164 @@ func TestIosCommunicator_GetCPUComponentCPULoad_prioritiseRevOID(t *testing.T) {
 		assert.Equal(t, expected, res)
 	}
 }
+
+//TestIosCommunicator_getCDPNeighbors checks if cdp cache entries are converted to neighbors
+func TestIosCommunicator_getCDPNeighbors(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.6")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.2.1.1.6.10101.1", gosnmp.OctetString, "switch01.example.com"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.7")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.2.1.1.7.10101.1", gosnmp.OctetString, "GigabitEthernet1/0/24"),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.17")).
+		Return(nil, errors.New("no such oid")).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.3")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.2.1.1.3.10101.1", gosnmp.Integer, 1),
+		}, nil).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.23.1.2.1.1.4")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.23.1.2.1.1.4.10101.1", gosnmp.OctetString, []byte{10, 0, 0, 1}),
+		}, nil).
+		On("SNMPGet", ctx, network.OID("1.3.6.1.2.1.31.1.1.1.1.10101")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.31.1.1.1.1.10101", gosnmp.OctetString, "Gi0/1"),
+		}, nil)
+
+	sut := iosCommunicator{codeCommunicator{}}
+
+	localPort := "Gi0/1"
+	deviceID := "switch01.example.com"
+	remotePort := "GigabitEthernet1/0/24"
+	address := "10.0.0.1"
+	expected := []device.NeighborsComponentNeighbor{
+		{
+			LocalPort:               &localPort,
+			RemoteChassisID:         &deviceID,
+			RemotePortID:            &remotePort,
+			RemoteSystemName:        &deviceID,
+			RemoteManagementAddress: &address,
+		},
+	}
+
+	res, err := sut.getCDPNeighbors(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res)
+	}
+}
+
+//TestIosCommunicator_GetCPUComponentCPULoad_CLIFallback: both OIDs fail, cpu load is read via ssh
+func TestIosCommunicator_GetCPUComponentCPULoad_CLIFallback(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	var sshClient network.MockSSHClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+		SSH: &network.RequestDeviceConnectionSSH{
+			SSHClient: &sshClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.109.1.1.1.1.8")).
+		Return(nil, errors.New("no such oid")).
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.109.1.1.1.1.5")).
+		Return(nil, errors.New("no such oid"))
+	sshClient.
+		On("Run", ctx, "show processes cpu | include CPU utilization").
+		Return("CPU utilization for five seconds: 5%/0%; one minute: 7%; five minutes: 6%\n", nil)
+
+	sut := iosCommunicator{codeCommunicator{}}
+
+	load := 6.0
+	expected := []device.CPU{
+		{
+			Load: &load,
+		},
+	}
+
+	res, err := sut.GetCPUComponentCPULoad(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res)
+	}
+}
+
+//TestMergeLLDPAndCDPNeighbors: a neighbor that is found via lldp and cdp is only returned once
+func TestMergeLLDPAndCDPNeighbors(t *testing.T) {
+	localPort := "Gi0/1"
+	otherLocalPort := "Gi0/2"
+	lldpSysName := "switch01.example.com"
+	cdpSysName := "SWITCH01.example.com"
+	chassisID := "00:11:22:33:44:55"
+	remotePort := "GigabitEthernet1/0/24"
+	address := "10.0.0.1"
+
+	lldp := []device.NeighborsComponentNeighbor{
+		{
+			LocalPort:        &localPort,
+			RemoteChassisID:  &chassisID,
+			RemoteSystemName: &lldpSysName,
+		},
+	}
+	cdp := []device.NeighborsComponentNeighbor{
+		{
+			LocalPort:               &localPort,
+			RemoteChassisID:         &cdpSysName,
+			RemotePortID:            &remotePort,
+			RemoteSystemName:        &cdpSysName,
+			RemoteManagementAddress: &address,
+		},
+		{
+			LocalPort:        &otherLocalPort,
+			RemoteChassisID:  &cdpSysName,
+			RemoteSystemName: &cdpSysName,
+		},
+	}
+
+	expected := []device.NeighborsComponentNeighbor{
+		{
+			LocalPort:               &localPort,
+			RemoteChassisID:         &chassisID,
+			RemotePortID:            &remotePort,
+			RemoteSystemName:        &lldpSysName,
+			RemoteManagementAddress: &address,
+		},
+		cdp[1],
+	}
+
+	assert.Equal(t, expected, mergeLLDPAndCDPNeighbors(lldp, cdp))
+}
+
+//TestGetBGPPrefixCountsByInetAddress: prefix counts of cbgpPeer2AddrFamilyPrefixTable are summed up per peer address
+func TestGetBGPPrefixCountsByInetAddress(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID("1.3.6.1.4.1.9.9.187.1.2.8.1.1")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.187.1.2.8.1.1.1.4.10.0.0.1.1.1", gosnmp.Gauge32, uint(100)),
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.187.1.2.8.1.1.1.4.10.0.0.1.2.1", gosnmp.Gauge32, uint(20)),
+			network.NewSNMPResponse(".1.3.6.1.4.1.9.9.187.1.2.8.1.1.2.16.32.1.13.184.0.0.0.0.0.0.0.0.0.0.0.1.2.1", gosnmp.Gauge32, uint(5)),
+		}, nil)
+
+	res, err := getBGPPrefixCountsByInetAddress(ctx, "1.3.6.1.4.1.9.9.187.1.2.8.1.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]uint64{"10.0.0.1": 120, "2001:db8::1": 5}, res)
+	}
+}
@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/mapping"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/textfsm"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -16,6 +22,18 @@ type junosCommunicator struct {
 	codeCommunicator
 }
 
+// junosRoutingEngineTemplate parses the first idle value per routing engine of "show chassis routing-engine".
+var junosRoutingEngineTemplate = textfsm.MustNewTemplate(`Value Filldown Slot (\d+)
+Value Required Idle (\d+)
+
+Start
+  ^\s*Slot ${Slot}:
+  ^\s+Idle\s+${Idle} percent -> Record SkipSlot
+
+SkipSlot
+  ^\s*Slot ${Slot}: -> Start
+`)
+
 func (c *junosCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
 	interfaces, err := c.deviceClass.GetInterfaces(ctx, filter...)
 	if err != nil {
@@ -244,9 +262,19 @@ func (c *junosCommunicator) getPortIfIndexMapping(ctx context.Context) (map[stri
 }
 
 func (c *junosCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
+	if con, ok := network.DeviceConnectionFromContext(ctx); ok && con.HasTelemetryConnection() {
+		// the openconfig mappings of the device class are used
+		return nil, tholaerr.NewNotImplementedError("cpu load is read via openconfig")
+	}
+
 	indices, err := c.getRoutingEngineIndices(ctx)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get routing indices")
+		cpus, cliErr := c.getRoutingEngineCPULoadByCLI(ctx)
+		if cliErr != nil {
+			log.Ctx(ctx).Debug().Err(cliErr).Msg("failed to get routing engine cpu load via cli")
+			return nil, errors.Wrap(err, "failed to get routing indices")
+		}
+		return cpus, nil
 	}
 
 	con, ok := network.DeviceConnectionFromContext(ctx)
@@ -290,6 +318,36 @@ func (c *junosCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]devic
 	return cpus, nil
 }
 
+// getRoutingEngineCPULoadByCLI reads the routing engine cpu loads from the output of "show chassis routing-engine" via SSH.
+func (c *junosCommunicator) getRoutingEngineCPULoadByCLI(ctx context.Context) ([]device.CPU, error) {
+	records, err := runSSHCommand(ctx, "show chassis routing-engine", junosRoutingEngineTemplate)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("no routing engine found in command output")
+	}
+
+	var cpus []device.CPU
+	for _, record := range records {
+		idle, err := strconv.ParseFloat(record["Idle"].(string), 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse idle cpu percentage")
+		}
+		load := 100 - idle
+
+		label := "Routing Engine"
+		if slot := record["Slot"].(string); slot != "" {
+			label += " " + slot
+		}
+		cpus = append(cpus, device.CPU{
+			Label: &label,
+			Load:  &load,
+		})
+	}
+	return cpus, nil
+}
+
 type indexAndLabel struct {
 	index string
 	label string
@@ -389,6 +447,10 @@ func (c *junosCommunicator) getSPUIndices(ctx context.Context) (map[string]strin
 
 func (c *junosCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) ([]device.MemoryPool, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
+	if ok && con.HasTelemetryConnection() {
+		// the openconfig mappings of the device class are used
+		return nil, tholaerr.NewNotImplementedError("memory usage is read via openconfig")
+	}
 	if !ok || con.SNMP == nil {
 		return nil, errors.New("no device connection available")
 	}
@@ -476,3 +538,106 @@ func (c *junosCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) (
 
 	return pools, nil
 }
+
+// GetBGPComponentPeers returns the bgp peers of junos devices.
+func (c *junosCommunicator) GetBGPComponentPeers(ctx context.Context) ([]device.BGPComponentPeer, error) {
+	// BGP4-MIB only contains the ipv4 peers of the default routing instance, so BGP4-V2-MIB-JUNIPER (jnxBgpM2) is used instead
+	peerTable := network.OID("1.3.6.1.4.1.2636.5.1.1.2.1.1.1")
+
+	remoteAddresses, err := getValuesByIndex(ctx, peerTable.AddIndex("11"), true)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read out jnxBgpM2 peers, falling back to BGP4-MIB")
+		return c.deviceClass.GetBGPComponentPeers(ctx)
+	}
+
+	getOptionalValues := func(column string, raw bool) map[string]value.Value {
+		values, err := getValuesByIndex(ctx, peerTable.AddIndex(column), raw)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msgf("failed to read out jnxBgpM2 peer table column '%s'", column)
+		}
+		return values
+	}
+	identifiers := getOptionalValues("1", true)
+	states := getOptionalValues("2", false)
+	statuses := getOptionalValues("3", false)
+	localAddresses := getOptionalValues("7", true)
+	localAS := getOptionalValues("9", false)
+	remoteAS := getOptionalValues("13", false)
+	peerIndices := getOptionalValues("14", false)
+
+	// jnxBgpM2PrefixInPrefixes and jnxBgpM2PrefixInPrefixesAccepted, indices consist of <peer index>.<afi>.<safi>
+	received, err := getBGPPrefixCounts(ctx, "1.3.6.1.4.1.2636.5.1.1.2.6.2.1.7")
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read out received prefixes of bgp peers")
+	}
+	accepted, err := getBGPPrefixCounts(ctx, "1.3.6.1.4.1.2636.5.1.1.2.6.2.1.8")
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to read out accepted prefixes of bgp peers")
+	}
+
+	var indices []string
+	for idx := range remoteAddresses {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		cmp, _ := network.OID(indices[i]).Cmp(network.OID(indices[j]))
+		return cmp == -1
+	})
+
+	var peers []device.BGPComponentPeer
+	for _, idx := range indices {
+		var peer device.BGPComponentPeer
+
+		peerAddress, err := hexToIPAddress(remoteAddresses[idx].String())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse bgp peer address")
+		}
+		peer.PeerAddress = &peerAddress
+
+		if identifier, ok := identifiers[idx]; ok {
+			if identifierString, err := hexToIPAddress(identifier.String()); err == nil {
+				peer.PeerIdentifier = &identifierString
+			}
+		}
+		if localAddress, ok := localAddresses[idx]; ok {
+			if localAddressString, err := hexToIPAddress(localAddress.String()); err == nil {
+				peer.LocalAddress = &localAddressString
+			}
+		}
+		if state, ok := states[idx]; ok {
+			if stateString, err := mapping.GetMappedValue("bgpPeerState.yaml", state.String()); err == nil {
+				peer.State = &stateString
+			}
+		}
+		if status, ok := statuses[idx]; ok {
+			// jnxBgpM2PeerStatus: 1 = halted, 2 = running
+			adminStatus := device.BGPPeerAdminStatusStart
+			if status.String() == "1" {
+				adminStatus = device.BGPPeerAdminStatusStop
+			}
+			peer.AdminStatus = &adminStatus
+		}
+		if as, ok := localAS[idx]; ok {
+			if asNumber, err := as.UInt64(); err == nil {
+				peer.LocalAS = &asNumber
+			}
+		}
+		if as, ok := remoteAS[idx]; ok {
+			if asNumber, err := as.UInt64(); err == nil {
+				peer.PeerAS = &asNumber
+			}
+		}
+		if peerIndex, ok := peerIndices[idx]; ok {
+			if count, ok := received[peerIndex.String()]; ok {
+				peer.PrefixesReceived = &count
+			}
+			if count, ok := accepted[peerIndex.String()]; ok {
+				peer.PrefixesAccepted = &count
+			}
+		}
+
+		peers = append(peers, peer)
+	}
+
+	return peers, nil
+}
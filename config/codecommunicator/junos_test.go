@@ -99,3 +99,56 @@ func TestJunosCommunicator_GetCPUComponentCPULoad(t *testing.T) {
 		assert.Equal(t, expected, res)
 	}
 }
+
+func TestJunosCommunicator_GetCPUComponentCPULoad_CLIFallback(t *testing.T) {
+	var snmpClient network.MockSNMPClient
+	var sshClient network.MockSSHClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+		SSH: &network.RequestDeviceConnectionSSH{
+			SSHClient: &sshClient,
+		},
+	})
+
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.4.1.2636.3.1.13.1.5")).
+		Return(nil, errors.New("request timeout"))
+	sshClient.
+		On("Run", ctx, "show chassis routing-engine").
+		Return(`Routing Engine status:
+  Slot 0:
+    Current state                  Master
+    CPU utilization:
+      User                       2 percent
+      Idle                      95 percent
+    5 sec CPU utilization:
+      Idle                      90 percent
+  Slot 1:
+    Current state                  Backup
+    CPU utilization:
+      User                       0 percent
+      Idle                      99 percent
+`, nil)
+
+	sut := junosCommunicator{codeCommunicator{}}
+	res, err := sut.GetCPUComponentCPULoad(ctx)
+
+	label0, label1 := "Routing Engine 0", "Routing Engine 1"
+	load0, load1 := 5.0, 1.0
+	expected := []device.CPU{
+		{
+			Label: &label0,
+			Load:  &load0,
+		},
+		{
+			Label: &label1,
+			Load:  &load1,
+		},
+	}
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, res)
+	}
+}
@@ -0,0 +1,679 @@
+package codecommunicator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/inexio/thola/config"
+	"github.com/inexio/thola/internal/communicator"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	starlarkjson "go.starlark.net/lib/json"
+	"go.starlark.net/starlark"
+	"go.starlark.net/starlarkstruct"
+	"io/fs"
+	"path"
+	"reflect"
+)
+
+// scriptDirectory is the directory in the overlay directory that contains the code communicator scripts.
+// The script of a device class is located at the path of its identifier, e.g. "codecommunicator/ceraos/ip10.star".
+const scriptDirectory = "codecommunicator"
+
+// scriptContextKey is the key of the request context in the thread locals of a script.
+const scriptContextKey = "context"
+
+// scriptCommunicator is a code communicator which is implemented by a Starlark script of the overlay directory,
+// so device classes can ship custom logic without recompiling thola.
+//
+// The script defines functions with the names of the code communicator functions, e.g. "GetCPUComponentCPULoad",
+// which take no arguments and return the value in the same format as the API responses.
+// Functions that the script does not define or that return None are passed on to next, which is the registered
+// code communicator of the device class. If it does not implement them either, the device class files are used.
+type scriptCommunicator struct {
+	file    string
+	globals starlark.StringDict
+	next    communicator.Functions
+}
+
+// getScriptCommunicator loads the code communicator script for the device class identifier,
+// or returns a not found error if the overlay directory contains no script for it.
+// The functions of the device class and the parent are available to the script as "device_class" and "parent".
+func getScriptCommunicator(classIdentifier string, deviceClass, parent, next communicator.Functions) (communicator.Functions, error) {
+	file := path.Join(scriptDirectory, classIdentifier+".star")
+	src, err := fs.ReadFile(config.Files(), file)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, tholaerr.NewNotFoundError(fmt.Sprintf("no script found at '%s'", file))
+		}
+		return nil, errors.Wrapf(err, "failed to read script '%s'", file)
+	}
+
+	predeclared := starlark.StringDict{
+		"json":         starlarkjson.Module,
+		"snmpget":      starlark.NewBuiltin("snmpget", scriptSNMPGet),
+		"snmpwalk":     starlark.NewBuiltin("snmpwalk", scriptSNMPWalk),
+		"device_class": functionsValue("device_class", deviceClass),
+		"parent":       functionsValue("parent", parent),
+	}
+	thread := &starlark.Thread{Name: file, Print: scriptPrint}
+	globals, err := starlark.ExecFile(thread, file, src, predeclared)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load script '%s'", file)
+	}
+	// frozen globals can be used by multiple requests at the same time
+	globals.Freeze()
+
+	if next == nil {
+		next = &codeCommunicator{}
+	}
+	return &scriptCommunicator{
+		file:    file,
+		globals: globals,
+		next:    next,
+	}, nil
+}
+
+// call runs the function of the script and decodes its result into res.
+// It returns false if the script does not implement the function.
+func (s *scriptCommunicator) call(ctx context.Context, function string, res interface{}) (bool, error) {
+	fn, ok := s.globals[function]
+	if !ok {
+		return false, nil
+	}
+
+	thread := &starlark.Thread{Name: s.file + ":" + function, Print: scriptPrint}
+	thread.SetLocal(scriptContextKey, ctx)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			thread.Cancel(ctx.Err().Error())
+		case <-done:
+		}
+	}()
+
+	log.Ctx(ctx).Debug().Str("script", s.file).Str("function", function).Msg("running code communicator script")
+	val, err := starlark.Call(thread, fn, nil, nil)
+	if err != nil {
+		return true, errors.Wrapf(err, "script '%s' failed", s.file)
+	}
+	if val == starlark.None {
+		return false, nil
+	}
+
+	b, err := fromStarlark(thread, val)
+	if err != nil {
+		return true, errors.Wrapf(err, "failed to encode result of script '%s'", s.file)
+	}
+	if err := json.Unmarshal(b, res); err != nil {
+		return true, errors.Wrapf(err, "failed to decode result of script '%s'", s.file)
+	}
+	return true, nil
+}
+
+// scriptContext returns the context of the request that the thread of the script runs for.
+func scriptContext(thread *starlark.Thread) (context.Context, error) {
+	ctx, ok := thread.Local(scriptContextKey).(context.Context)
+	if !ok {
+		return nil, errors.New("only available inside of functions")
+	}
+	return ctx, nil
+}
+
+func scriptPrint(thread *starlark.Thread, msg string) {
+	ctx, err := scriptContext(thread)
+	if err != nil {
+		log.Debug().Str("script", thread.Name).Msg(msg)
+		return
+	}
+	log.Ctx(ctx).Debug().Str("script", thread.Name).Msg(msg)
+}
+
+// scriptSNMPGet implements snmpget(oid, ..., raw=False), which returns the values mapped by their OID.
+func scriptSNMPGet(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var raw bool
+	if err := starlark.UnpackArgs(b.Name(), nil, kwargs, "raw?", &raw); err != nil {
+		return nil, err
+	}
+	var oids []network.OID
+	for i, arg := range args {
+		oid, ok := starlark.AsString(arg)
+		if !ok {
+			return nil, fmt.Errorf("%s: argument %d is not a string", b.Name(), i+1)
+		}
+		oids = append(oids, network.OID(oid))
+	}
+	ctx, err := scriptContext(thread)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil {
+		return nil, fmt.Errorf("%s: no snmp connection available", b.Name())
+	}
+
+	responses, err := con.SNMP.SnmpClient.SNMPGet(ctx, oids...)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	values := starlark.NewDict(len(responses))
+	for _, response := range responses {
+		val, err := getResponseValue(response, raw)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msgf("failed to get value of oid '%s'", response.GetOID())
+			continue
+		}
+		if err := values.SetKey(starlark.String(response.GetOID().String()), starlark.String(val)); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// scriptSNMPWalk implements snmpwalk(oid, raw=False), which returns the values mapped by their index.
+func scriptSNMPWalk(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var oid string
+	var raw bool
+	if err := starlark.UnpackArgs(b.Name(), args, kwargs, "oid", &oid, "raw?", &raw); err != nil {
+		return nil, err
+	}
+	ctx, err := scriptContext(thread)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+
+	res, err := getValuesByIndex(ctx, network.OID(oid), raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", b.Name(), err)
+	}
+	values := starlark.NewDict(len(res))
+	for idx, val := range res {
+		if err := values.SetKey(starlark.String(idx), starlark.String(val.String())); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// functionsValue makes the functions of a communicator available to scripts, e.g. "parent.GetVendor()".
+// A function returns None if it is not implemented by the communicator.
+func functionsValue(name string, functions communicator.Functions) starlark.Value {
+	if functions == nil {
+		return starlark.None
+	}
+	v := reflect.ValueOf(functions)
+	t := reflect.TypeOf((*communicator.Functions)(nil)).Elem()
+	members := make(starlark.StringDict, t.NumMethod())
+	for i := 0; i < t.NumMethod(); i++ {
+		method := v.MethodByName(t.Method(i).Name)
+		members[t.Method(i).Name] = starlark.NewBuiltin(name+"."+t.Method(i).Name, func(thread *starlark.Thread, b *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+			if err := starlark.UnpackPositionalArgs(b.Name(), args, kwargs, 0); err != nil {
+				return nil, err
+			}
+			ctx, err := scriptContext(thread)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", b.Name(), err)
+			}
+			res := method.Call([]reflect.Value{reflect.ValueOf(ctx)})
+			if err, ok := res[1].Interface().(error); ok && err != nil {
+				if tholaerr.IsNotImplementedError(err) || tholaerr.IsNotFoundError(err) {
+					return starlark.None, nil
+				}
+				return nil, fmt.Errorf("%s: %s", b.Name(), err)
+			}
+			return toStarlark(thread, res[0].Interface())
+		})
+	}
+	return starlarkstruct.FromStringDict(starlark.String(name), members)
+}
+
+// toStarlark converts a value to Starlark using its JSON encoding.
+func toStarlark(thread *starlark.Thread, value interface{}) (starlark.Value, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return starlark.Call(thread, starlarkjson.Module.Members["decode"], starlark.Tuple{starlark.String(b)}, nil)
+}
+
+// fromStarlark returns the JSON encoding of a Starlark value.
+func fromStarlark(thread *starlark.Thread, value starlark.Value) ([]byte, error) {
+	res, err := starlark.Call(thread, starlarkjson.Module.Members["encode"], starlark.Tuple{value}, nil)
+	if err != nil {
+		return nil, err
+	}
+	s, ok := starlark.AsString(res)
+	if !ok {
+		return nil, errors.New("json encoding is not a string")
+	}
+	return []byte(s), nil
+}
+
+func getResponseValue(response network.SNMPResponse, raw bool) (string, error) {
+	if raw {
+		val, err := response.GetValueRaw()
+		if err != nil {
+			return "", err
+		}
+		return val.String(), nil
+	}
+	val, err := response.GetValue()
+	if err != nil {
+		return "", err
+	}
+	return val.String(), nil
+}
+
+func (s *scriptCommunicator) GetVendor(ctx context.Context) (string, error) {
+	var res string
+	implemented, err := s.call(ctx, "GetVendor", &res)
+	if !implemented {
+		return s.next.GetVendor(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetModel(ctx context.Context) (string, error) {
+	var res string
+	implemented, err := s.call(ctx, "GetModel", &res)
+	if !implemented {
+		return s.next.GetModel(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetModelSeries(ctx context.Context) (string, error) {
+	var res string
+	implemented, err := s.call(ctx, "GetModelSeries", &res)
+	if !implemented {
+		return s.next.GetModelSeries(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSerialNumber(ctx context.Context) (string, error) {
+	var res string
+	implemented, err := s.call(ctx, "GetSerialNumber", &res)
+	if !implemented {
+		return s.next.GetSerialNumber(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetOSVersion(ctx context.Context) (string, error) {
+	var res string
+	implemented, err := s.call(ctx, "GetOSVersion", &res)
+	if !implemented {
+		return s.next.GetOSVersion(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetInterfaces(ctx context.Context, filter ...groupproperty.Filter) ([]device.Interface, error) {
+	var res []device.Interface
+	implemented, err := s.call(ctx, "GetInterfaces", &res)
+	if !implemented {
+		return s.next.GetInterfaces(ctx, filter...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return filterInterfaces(ctx, res, filter)
+}
+
+func (s *scriptCommunicator) GetCountInterfaces(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetCountInterfaces", &res)
+	if !implemented {
+		return s.next.GetCountInterfaces(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetCPUComponentCPULoad(ctx context.Context) ([]device.CPU, error) {
+	var res []device.CPU
+	implemented, err := s.call(ctx, "GetCPUComponentCPULoad", &res)
+	if !implemented {
+		return s.next.GetCPUComponentCPULoad(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetMemoryComponentMemoryUsage(ctx context.Context) ([]device.MemoryPool, error) {
+	var res []device.MemoryPool
+	implemented, err := s.call(ctx, "GetMemoryComponentMemoryUsage", &res)
+	if !implemented {
+		return s.next.GetMemoryComponentMemoryUsage(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetServerComponentProcs(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetServerComponentProcs", &res)
+	if !implemented {
+		return s.next.GetServerComponentProcs(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetServerComponentUsers(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetServerComponentUsers", &res)
+	if !implemented {
+		return s.next.GetServerComponentUsers(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetDiskComponentStorages(ctx context.Context) ([]device.DiskComponentStorage, error) {
+	var res []device.DiskComponentStorage
+	implemented, err := s.call(ctx, "GetDiskComponentStorages", &res)
+	if !implemented {
+		return s.next.GetDiskComponentStorages(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentAlarmLowVoltageDisconnect(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetUPSComponentAlarmLowVoltageDisconnect", &res)
+	if !implemented {
+		return s.next.GetUPSComponentAlarmLowVoltageDisconnect(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentBatteryAmperage(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentBatteryAmperage", &res)
+	if !implemented {
+		return s.next.GetUPSComponentBatteryAmperage(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentBatteryCapacity(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentBatteryCapacity", &res)
+	if !implemented {
+		return s.next.GetUPSComponentBatteryCapacity(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentBatteryCurrent(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentBatteryCurrent", &res)
+	if !implemented {
+		return s.next.GetUPSComponentBatteryCurrent(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentBatteryRemainingTime(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentBatteryRemainingTime", &res)
+	if !implemented {
+		return s.next.GetUPSComponentBatteryRemainingTime(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentBatteryTemperature(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentBatteryTemperature", &res)
+	if !implemented {
+		return s.next.GetUPSComponentBatteryTemperature(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentBatteryVoltage(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentBatteryVoltage", &res)
+	if !implemented {
+		return s.next.GetUPSComponentBatteryVoltage(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentCurrentLoad(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentCurrentLoad", &res)
+	if !implemented {
+		return s.next.GetUPSComponentCurrentLoad(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentMainsVoltageApplied(ctx context.Context) (bool, error) {
+	var res bool
+	implemented, err := s.call(ctx, "GetUPSComponentMainsVoltageApplied", &res)
+	if !implemented {
+		return s.next.GetUPSComponentMainsVoltageApplied(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentRectifierCurrent(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentRectifierCurrent", &res)
+	if !implemented {
+		return s.next.GetUPSComponentRectifierCurrent(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentAgents(ctx context.Context) ([]device.SBCComponentAgent, error) {
+	var res []device.SBCComponentAgent
+	implemented, err := s.call(ctx, "GetSBCComponentAgents", &res)
+	if !implemented {
+		return s.next.GetSBCComponentAgents(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentRealms(ctx context.Context) ([]device.SBCComponentRealm, error) {
+	var res []device.SBCComponentRealm
+	implemented, err := s.call(ctx, "GetSBCComponentRealms", &res)
+	if !implemented {
+		return s.next.GetSBCComponentRealms(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetUPSComponentSystemVoltage(ctx context.Context) (float64, error) {
+	var res float64
+	implemented, err := s.call(ctx, "GetUPSComponentSystemVoltage", &res)
+	if !implemented {
+		return s.next.GetUPSComponentSystemVoltage(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentGlobalCallPerSecond(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetSBCComponentGlobalCallPerSecond", &res)
+	if !implemented {
+		return s.next.GetSBCComponentGlobalCallPerSecond(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentGlobalConcurrentSessions(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetSBCComponentGlobalConcurrentSessions", &res)
+	if !implemented {
+		return s.next.GetSBCComponentGlobalConcurrentSessions(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentActiveLocalContacts(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetSBCComponentActiveLocalContacts", &res)
+	if !implemented {
+		return s.next.GetSBCComponentActiveLocalContacts(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentTranscodingCapacity(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetSBCComponentTranscodingCapacity", &res)
+	if !implemented {
+		return s.next.GetSBCComponentTranscodingCapacity(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentLicenseCapacity(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetSBCComponentLicenseCapacity", &res)
+	if !implemented {
+		return s.next.GetSBCComponentLicenseCapacity(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentSystemRedundancy(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetSBCComponentSystemRedundancy", &res)
+	if !implemented {
+		return s.next.GetSBCComponentSystemRedundancy(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHardwareHealthComponentEnvironmentMonitorState(ctx context.Context) (device.HardwareHealthComponentState, error) {
+	var res device.HardwareHealthComponentState
+	implemented, err := s.call(ctx, "GetHardwareHealthComponentEnvironmentMonitorState", &res)
+	if !implemented {
+		return s.next.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHardwareHealthComponentFans(ctx context.Context) ([]device.HardwareHealthComponentFan, error) {
+	var res []device.HardwareHealthComponentFan
+	implemented, err := s.call(ctx, "GetHardwareHealthComponentFans", &res)
+	if !implemented {
+		return s.next.GetHardwareHealthComponentFans(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHardwareHealthComponentTemperature(ctx context.Context) ([]device.HardwareHealthComponentTemperature, error) {
+	var res []device.HardwareHealthComponentTemperature
+	implemented, err := s.call(ctx, "GetHardwareHealthComponentTemperature", &res)
+	if !implemented {
+		return s.next.GetHardwareHealthComponentTemperature(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHardwareHealthComponentVoltage(ctx context.Context) ([]device.HardwareHealthComponentVoltage, error) {
+	var res []device.HardwareHealthComponentVoltage
+	implemented, err := s.call(ctx, "GetHardwareHealthComponentVoltage", &res)
+	if !implemented {
+		return s.next.GetHardwareHealthComponentVoltage(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHardwareHealthComponentPowerSupply(ctx context.Context) ([]device.HardwareHealthComponentPowerSupply, error) {
+	var res []device.HardwareHealthComponentPowerSupply
+	implemented, err := s.call(ctx, "GetHardwareHealthComponentPowerSupply", &res)
+	if !implemented {
+		return s.next.GetHardwareHealthComponentPowerSupply(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetSBCComponentSystemHealthScore(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetSBCComponentSystemHealthScore", &res)
+	if !implemented {
+		return s.next.GetSBCComponentSystemHealthScore(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHighAvailabilityComponentState(ctx context.Context) (device.HighAvailabilityComponentState, error) {
+	var res device.HighAvailabilityComponentState
+	implemented, err := s.call(ctx, "GetHighAvailabilityComponentState", &res)
+	if !implemented {
+		return s.next.GetHighAvailabilityComponentState(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHighAvailabilityComponentRole(ctx context.Context) (string, error) {
+	var res string
+	implemented, err := s.call(ctx, "GetHighAvailabilityComponentRole", &res)
+	if !implemented {
+		return s.next.GetHighAvailabilityComponentRole(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetHighAvailabilityComponentNodes(ctx context.Context) (int, error) {
+	var res int
+	implemented, err := s.call(ctx, "GetHighAvailabilityComponentNodes", &res)
+	if !implemented {
+		return s.next.GetHighAvailabilityComponentNodes(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetInventoryComponentEntities(ctx context.Context) ([]device.InventoryComponentEntity, error) {
+	var res []device.InventoryComponentEntity
+	implemented, err := s.call(ctx, "GetInventoryComponentEntities", &res)
+	if !implemented {
+		return s.next.GetInventoryComponentEntities(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetNeighborsComponentNeighbors(ctx context.Context) ([]device.NeighborsComponentNeighbor, error) {
+	var res []device.NeighborsComponentNeighbor
+	implemented, err := s.call(ctx, "GetNeighborsComponentNeighbors", &res)
+	if !implemented {
+		return s.next.GetNeighborsComponentNeighbors(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetBGPComponentPeers(ctx context.Context) ([]device.BGPComponentPeer, error) {
+	var res []device.BGPComponentPeer
+	implemented, err := s.call(ctx, "GetBGPComponentPeers", &res)
+	if !implemented {
+		return s.next.GetBGPComponentPeers(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetRoutingProtocolsComponentOSPFNeighbors(ctx context.Context) ([]device.RoutingProtocolsComponentOSPFNeighbor, error) {
+	var res []device.RoutingProtocolsComponentOSPFNeighbor
+	implemented, err := s.call(ctx, "GetRoutingProtocolsComponentOSPFNeighbors", &res)
+	if !implemented {
+		return s.next.GetRoutingProtocolsComponentOSPFNeighbors(ctx)
+	}
+	return res, err
+}
+
+func (s *scriptCommunicator) GetRoutingProtocolsComponentISISAdjacencies(ctx context.Context) ([]device.RoutingProtocolsComponentISISAdjacency, error) {
+	var res []device.RoutingProtocolsComponentISISAdjacency
+	implemented, err := s.call(ctx, "GetRoutingProtocolsComponentISISAdjacencies", &res)
+	if !implemented {
+		return s.next.GetRoutingProtocolsComponentISISAdjacencies(ctx)
+	}
+	return res, err
+}
@@ -0,0 +1,121 @@
+package codecommunicator
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/config"
+	"github.com/inexio/thola/internal/communicator"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testScript = `
+def GetVendor():
+    return snmpget(".1.3.6.1.2.1.1.1.0")[".1.3.6.1.2.1.1.1.0"]
+
+def GetModel():
+    fail("model not found")
+
+def GetModelSeries():
+    return None
+
+def GetOSVersion():
+    return parent.GetSerialNumber() + "-" + str(parent.GetModel())
+
+def GetCPUComponentCPULoad():
+    return [{"label": idx, "load": float(value)} for idx, value in snmpwalk(".1.3.6.1.2.1.25.3.3.1.2").items()]
+`
+
+// testFunctions returns a fixed serial number and implements no other function.
+type testFunctions struct {
+	codeCommunicator
+	serialNumber string
+}
+
+func (t *testFunctions) GetSerialNumber(_ context.Context) (string, error) {
+	return t.serialNumber, nil
+}
+
+func TestScriptCommunicator(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "codecommunicator", "test"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "codecommunicator", "test", "script.star"), []byte(testScript), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "codecommunicator", "test", "invalid.star"), []byte("def GetVendor(:\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "codecommunicator", "test", "toplevel.star"), []byte("vendor = snmpget(\"1.3.6.1.2.1.1.1.0\")\n"), 0644))
+	assert.NoError(t, config.SetOverlayDirectory(dir))
+	defer func() {
+		assert.NoError(t, config.SetOverlayDirectory(""))
+	}()
+
+	var snmpClient network.MockSNMPClient
+	ctx := network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: &snmpClient,
+		},
+	})
+	snmpClient.
+		On("SNMPGet", ctx, network.OID(".1.3.6.1.2.1.1.1.0")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.1.1.0", gosnmp.OctetString, "thola"),
+		}, nil)
+	snmpClient.
+		On("SNMPWalk", ctx, network.OID(".1.3.6.1.2.1.25.3.3.1.2")).
+		Return([]network.SNMPResponse{
+			network.NewSNMPResponse(".1.3.6.1.2.1.25.3.3.1.2.1", gosnmp.Integer, 5),
+		}, nil)
+
+	sut, err := getScriptCommunicator("test/script", nil, &testFunctions{serialNumber: "parent"}, &testFunctions{serialNumber: "next"})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	vendor, err := sut.GetVendor(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "thola", vendor)
+	}
+
+	_, err = sut.GetModel(ctx)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "model not found")
+	}
+
+	osVersion, err := sut.GetOSVersion(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "parent-None", osVersion, "functions of the parent need to be available to the script")
+	}
+
+	cpus, err := sut.GetCPUComponentCPULoad(ctx)
+	if assert.NoError(t, err) && assert.Len(t, cpus, 1) {
+		assert.Equal(t, "1", *cpus[0].Label)
+		assert.Equal(t, 5.0, *cpus[0].Load)
+	}
+
+	serialNumber, err := sut.GetSerialNumber(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "next", serialNumber, "functions the script does not define need to be passed on")
+	}
+
+	_, err = sut.GetModelSeries(ctx)
+	assert.True(t, tholaerr.IsNotImplementedError(err), "functions returning None need to be passed on")
+
+	_, err = getScriptCommunicator("test/missing", nil, nil, nil)
+	assert.True(t, tholaerr.IsNotFoundError(err))
+
+	_, err = getScriptCommunicator("test/invalid", nil, nil, nil)
+	assert.False(t, err == nil || tholaerr.IsNotFoundError(err), "invalid scripts need to fail")
+
+	_, err = getScriptCommunicator("test/toplevel", nil, nil, nil)
+	assert.Error(t, err, "snmp requests are only available inside of functions")
+}
+
+func TestRegister(t *testing.T) {
+	assert.Panics(t, func() {
+		Register("ios", func(_, _ communicator.Communicator) communicator.Functions {
+			return nil
+		})
+	})
+}
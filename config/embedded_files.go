@@ -6,3 +6,8 @@ import (
 
 //go:embed deviceclass mapping
 var FileSystem embed.FS
+
+// DeviceClassSchema is the JSON Schema of the device class files.
+//
+//go:embed deviceclass.schema.json
+var DeviceClassSchema []byte
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"sync"
+)
+
+var overlay struct {
+	sync.RWMutex
+	dir string
+}
+
+// SetOverlayDirectory sets a directory whose files override or extend the built-in files of FileSystem.
+// The directory has the same structure, e.g. "deviceclass/generic/myvendor.yaml" adds a device class and
+// "mapping/ios_CiscoEnvMonState.yaml" replaces a built-in mapping. Starlark scripts in "codecommunicator" implement
+// code communicators of device classes. An empty directory removes the overlay.
+func SetOverlayDirectory(dir string) error {
+	if dir != "" {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return &fs.PathError{Op: "open", Path: dir, Err: fs.ErrInvalid}
+		}
+	}
+	overlay.Lock()
+	defer overlay.Unlock()
+	overlay.dir = dir
+	return nil
+}
+
+// OverlayDirectory returns the overlay directory, or an empty string if none is set.
+func OverlayDirectory() string {
+	overlay.RLock()
+	defer overlay.RUnlock()
+	return overlay.dir
+}
+
+// Files returns the device classes and mappings, which are the files of the overlay directory
+// on top of the built-in files of FileSystem.
+func Files() fs.FS {
+	dir := OverlayDirectory()
+	if dir == "" {
+		return FileSystem
+	}
+	return &overlayFS{
+		upper: os.DirFS(dir),
+		lower: FileSystem,
+	}
+}
+
+// overlayFS is a file system whose files in upper override the files in lower.
+// Directories of both are merged.
+type overlayFS struct {
+	upper fs.FS
+	lower fs.FS
+}
+
+func (o *overlayFS) Open(name string) (fs.File, error) {
+	f, err := o.upper.Open(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return o.lower.Open(name)
+	}
+	return f, err
+}
+
+func (o *overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	upperEntries, upperErr := fs.ReadDir(o.upper, name)
+	lowerEntries, lowerErr := fs.ReadDir(o.lower, name)
+	if upperErr != nil && lowerErr != nil {
+		return nil, lowerErr
+	}
+
+	entries := make(map[string]fs.DirEntry)
+	for _, entry := range lowerEntries {
+		entries[entry.Name()] = entry
+	}
+	for _, entry := range upperEntries {
+		entries[entry.Name()] = entry
+	}
+	res := make([]fs.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		res = append(res, entry)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name() < res[j].Name()
+	})
+	return res, nil
+}
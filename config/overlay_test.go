@@ -0,0 +1,59 @@
+package config
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFiles(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "mapping"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "mapping", "ifType.yaml"), []byte("1: overridden\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "mapping", "custom.yaml"), []byte("1: custom\n"), 0644))
+
+	if !assert.NoError(t, SetOverlayDirectory(dir)) {
+		return
+	}
+	defer func() {
+		assert.NoError(t, SetOverlayDirectory(""))
+	}()
+	assert.Equal(t, dir, OverlayDirectory())
+
+	fsys := Files()
+	b, err := fs.ReadFile(fsys, "mapping/ifType.yaml")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "1: overridden\n", string(b), "file of the overlay directory needs to override the built-in file")
+	}
+	_, err = fs.ReadFile(fsys, "mapping/ifStatus.yaml")
+	assert.NoError(t, err, "built-in files need to be available")
+	_, err = fs.ReadFile(fsys, "deviceclass/generic.yaml")
+	assert.NoError(t, err, "built-in directories that don't exist in the overlay directory need to be available")
+
+	entries, err := fs.ReadDir(fsys, "mapping")
+	if assert.NoError(t, err) {
+		builtin, err := fs.ReadDir(FileSystem, "mapping")
+		if assert.NoError(t, err) {
+			assert.Len(t, entries, len(builtin)+1)
+		}
+		for i := 1; i < len(entries); i++ {
+			assert.Less(t, entries[i-1].Name(), entries[i].Name(), "entries need to be sorted")
+		}
+	}
+
+	_, err = fs.ReadDir(fsys, "does-not-exist")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestSetOverlayDirectory(t *testing.T) {
+	assert.Error(t, SetOverlayDirectory(filepath.Join(t.TempDir(), "does-not-exist")))
+
+	file := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(file, nil, 0644))
+	assert.Error(t, SetOverlayDirectory(file))
+
+	assert.Equal(t, "", OverlayDirectory())
+	assert.Equal(t, FileSystem, Files())
+}
@@ -46,6 +46,18 @@ type Communicator interface {
 	// GetHighAvailabilityComponent returns the hardware health component of a device if available.
 	GetHighAvailabilityComponent(ctx context.Context) (device.HighAvailabilityComponent, error)
 
+	// GetInventoryComponent returns the inventory component of a device if available.
+	GetInventoryComponent(ctx context.Context) (device.InventoryComponent, error)
+
+	// GetNeighborsComponent returns the neighbors component of a device if available.
+	GetNeighborsComponent(ctx context.Context) (device.NeighborsComponent, error)
+
+	// GetBGPComponent returns the bgp component of a device if available.
+	GetBGPComponent(ctx context.Context) (device.BGPComponent, error)
+
+	// GetRoutingProtocolsComponent returns the routing protocols component of a device if available.
+	GetRoutingProtocolsComponent(ctx context.Context) (device.RoutingProtocolsComponent, error)
+
 	Functions
 }
 
@@ -81,6 +93,10 @@ type Functions interface {
 	availableDiskCommunicatorFunctions
 	availableHardwareHealthCommunicatorFunctions
 	availableHighAvailabilityCommunicatorFunctions
+	availableInventoryCommunicatorFunctions
+	availableNeighborsCommunicatorFunctions
+	availableBGPCommunicatorFunctions
+	availableRoutingProtocolsCommunicatorFunctions
 }
 
 type availableCPUCommunicatorFunctions interface {
@@ -205,3 +221,30 @@ type availableHighAvailabilityCommunicatorFunctions interface {
 	// GetHighAvailabilityComponentNodes returns number of nodes in a HA setup.
 	GetHighAvailabilityComponentNodes(ctx context.Context) (int, error)
 }
+
+type availableInventoryCommunicatorFunctions interface {
+
+	// GetInventoryComponentEntities returns the physical entities of the device.
+	GetInventoryComponentEntities(ctx context.Context) ([]device.InventoryComponentEntity, error)
+}
+
+type availableNeighborsCommunicatorFunctions interface {
+
+	// GetNeighborsComponentNeighbors returns the neighbors of the device.
+	GetNeighborsComponentNeighbors(ctx context.Context) ([]device.NeighborsComponentNeighbor, error)
+}
+
+type availableBGPCommunicatorFunctions interface {
+
+	// GetBGPComponentPeers returns the bgp peers of the device.
+	GetBGPComponentPeers(ctx context.Context) ([]device.BGPComponentPeer, error)
+}
+
+type availableRoutingProtocolsCommunicatorFunctions interface {
+
+	// GetRoutingProtocolsComponentOSPFNeighbors returns the ospf neighbors of the device.
+	GetRoutingProtocolsComponentOSPFNeighbors(ctx context.Context) ([]device.RoutingProtocolsComponentOSPFNeighbor, error)
+
+	// GetRoutingProtocolsComponentISISAdjacencies returns the is-is adjacencies of the device.
+	GetRoutingProtocolsComponentISISAdjacencies(ctx context.Context) ([]device.RoutingProtocolsComponentISISAdjacency, error)
+}
@@ -5,6 +5,8 @@ import (
 	"github.com/inexio/thola/internal/communicator"
 	"github.com/inexio/thola/internal/communicator/hierarchy"
 	"github.com/inexio/thola/internal/deviceclass"
+	"github.com/inexio/thola/internal/explain"
+	"github.com/inexio/thola/internal/mapping"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
@@ -15,27 +17,55 @@ import (
 
 var genericHierarchy struct {
 	hierarchy.Hierarchy
-	sync.Once
+	sync.RWMutex
 }
 
-func initHierarchy(ctx context.Context) error {
-	var err error
-	genericHierarchy.Do(func() {
+// getHierarchy returns the generic hierarchy and builds it if it wasn't built yet.
+func getHierarchy(ctx context.Context) (hierarchy.Hierarchy, error) {
+	genericHierarchy.RLock()
+	hier := genericHierarchy.Hierarchy
+	genericHierarchy.RUnlock()
+	if hier.NetworkDeviceCommunicator != nil {
+		return hier, nil
+	}
+
+	genericHierarchy.Lock()
+	defer genericHierarchy.Unlock()
+	if genericHierarchy.NetworkDeviceCommunicator == nil {
+		var err error
 		genericHierarchy.Hierarchy, err = deviceclass.GetHierarchy()
+		if err != nil {
+			return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to build initial hierarchy")
+		}
 		log.Ctx(ctx).Debug().Msg("device configurations initialized")
-	})
+	}
+	return genericHierarchy.Hierarchy, nil
+}
+
+// ReloadHierarchy reads all device classes and mappings again, e.g. after files in the overlay directory changed.
+// If the new device classes or mappings are invalid, the current hierarchy and mappings are kept and an error is returned.
+// Requests that are currently running continue with the hierarchy they started with.
+func ReloadHierarchy(ctx context.Context) error {
+	mappings, err := mapping.ReadMappings()
 	if err != nil {
-		return errors.Wrap(err, "failed to build initial hierarchy")
+		return errors.Wrap(err, "failed to read mappings")
 	}
-	if genericHierarchy.NetworkDeviceCommunicator == nil {
-		return errors.New("hierarchy isn't initialized")
+	hier, err := deviceclass.GetHierarchy()
+	if err != nil {
+		return errors.Wrap(err, "failed to build hierarchy")
 	}
+
+	genericHierarchy.Lock()
+	genericHierarchy.Hierarchy = hier
+	mapping.SetMappings(mappings)
+	genericHierarchy.Unlock()
+	log.Ctx(ctx).Debug().Msg("device configurations reloaded")
 	return nil
 }
 
 // GetNetworkDeviceCommunicator returns the network device communicator for the given identifier
 func GetNetworkDeviceCommunicator(ctx context.Context, identifier string) (communicator.Communicator, error) {
-	err := initHierarchy(ctx)
+	genericHier, err := getHierarchy(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -46,11 +76,11 @@ func GetNetworkDeviceCommunicator(ctx context.Context, identifier string) (commu
 	configIdentifiers := strings.Split(identifier, "/")
 
 	if configIdentifiers[0] == "generic" {
-		return genericHierarchy.NetworkDeviceCommunicator, nil
+		return genericHier.NetworkDeviceCommunicator, nil
 	}
 
 	currentIdentifier = configIdentifiers[0]
-	hier, ok = genericHierarchy.Children[currentIdentifier]
+	hier, ok = genericHier.Children[currentIdentifier]
 	if !ok {
 		return nil, errors.New("hierarchy does not exist")
 	}
@@ -71,17 +101,17 @@ func GetNetworkDeviceCommunicator(ctx context.Context, identifier string) (commu
 
 // IdentifyNetworkDeviceCommunicator identifies a devices and creates a network device communicator.
 func IdentifyNetworkDeviceCommunicator(ctx context.Context) (communicator.Communicator, error) {
-	err := initHierarchy(ctx)
+	genericHier, err := getHierarchy(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	setIdentifyConnectionSettings(ctx)
 
-	comm, err := identifyDeviceRecursive(ctx, genericHierarchy.Children, true)
+	comm, err := identifyDeviceRecursive(ctx, genericHier.Children, true)
 	if err != nil {
 		if tholaerr.IsNotFoundError(err) {
-			return genericHierarchy.NetworkDeviceCommunicator, nil
+			return genericHier.NetworkDeviceCommunicator, nil
 		}
 		return nil, errors.Wrap(err, "error occurred while identifying device class")
 	}
@@ -101,29 +131,33 @@ func identifyDeviceRecursive(ctx context.Context, children map[string]hierarchy.
 			continue
 		}
 
-		logger := log.Ctx(ctx).With().Str("device_class", hier.NetworkDeviceCommunicator.GetIdentifier()).Logger()
-		ctx = logger.WithContext(ctx)
-		log.Ctx(ctx).Debug().Msgf("starting class match (%s)", hier.NetworkDeviceCommunicator.GetIdentifier())
-		match, err := hier.NetworkDeviceCommunicator.Match(ctx)
+		classCtx, explanation := explain.StartDeviceClass(ctx, hier.NetworkDeviceCommunicator.GetIdentifier(), hier.TryToMatchLast)
+		logger := log.Ctx(classCtx).With().Str("device_class", hier.NetworkDeviceCommunicator.GetIdentifier()).Logger()
+		classCtx = logger.WithContext(classCtx)
+		log.Ctx(classCtx).Debug().Msgf("starting class match (%s)", hier.NetworkDeviceCommunicator.GetIdentifier())
+		match, err := hier.NetworkDeviceCommunicator.Match(classCtx)
+		explanation.Finish(match, err)
 		if err != nil {
 			return nil, errors.Wrap(err, "error while trying to match device class: "+hier.NetworkDeviceCommunicator.GetIdentifier())
 		}
 
 		if match {
-			log.Ctx(ctx).Debug().Msg("device class matched")
+			log.Ctx(classCtx).Debug().Msg("device class matched")
 			if hier.Children != nil {
-				subDeviceClass, err := identifyDeviceRecursive(ctx, hier.Children, true)
+				subDeviceClass, err := identifyDeviceRecursive(classCtx, hier.Children, true)
 				if err != nil {
 					if tholaerr.IsNotFoundError(err) {
+						explanation.SetNote("no sub device class matched, device class is used")
 						return hier.NetworkDeviceCommunicator, nil
 					}
 					return nil, errors.Wrapf(err, "error occurred while trying to identify sub device class for device class '%s'", hier.NetworkDeviceCommunicator.GetIdentifier())
 				}
 				return subDeviceClass, nil
 			}
+			explanation.SetNote("device class is used")
 			return hier.NetworkDeviceCommunicator, nil
 		}
-		log.Ctx(ctx).Debug().Msg("device class did not match")
+		log.Ctx(classCtx).Debug().Msg("device class did not match")
 	}
 	if tryToMatchLastDeviceClasses != nil {
 		deviceClass, err := identifyDeviceRecursive(ctx, tryToMatchLastDeviceClasses, false)
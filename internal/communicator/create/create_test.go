@@ -0,0 +1,68 @@
+package create
+
+import (
+	"context"
+	"github.com/inexio/thola/config"
+	"github.com/inexio/thola/internal/mapping"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReloadHierarchy(t *testing.T) {
+	ctx := context.Background()
+	_, err := GetNetworkDeviceCommunicator(ctx, "overlayvendor")
+	assert.Error(t, err, "device class of the overlay directory must not exist before reloading")
+
+	dir := t.TempDir()
+	deviceClassDir := filepath.Join(dir, "deviceclass", "generic")
+	if !assert.NoError(t, os.MkdirAll(deviceClassDir, 0755)) {
+		return
+	}
+	file := filepath.Join(deviceClassDir, "overlayvendor.yaml")
+	if !assert.NoError(t, os.WriteFile(file, []byte("name: overlayvendor\n\nmatch:\n  logical_operator: OR\n  conditions:\n    - type: SysDescription\n      match_mode: contains\n      values:\n        - overlay\n"), 0644)) {
+		return
+	}
+	if !assert.NoError(t, config.SetOverlayDirectory(dir)) {
+		return
+	}
+	defer func() {
+		assert.NoError(t, config.SetOverlayDirectory(""))
+		assert.NoError(t, ReloadHierarchy(ctx))
+	}()
+
+	if !assert.NoError(t, ReloadHierarchy(ctx)) {
+		return
+	}
+	_, err = GetNetworkDeviceCommunicator(ctx, "overlayvendor")
+	assert.NoError(t, err, "device class of the overlay directory needs to exist after reloading")
+	_, err = GetNetworkDeviceCommunicator(ctx, "ios")
+	assert.NoError(t, err, "built-in device classes need to exist after reloading")
+
+	mappingDir := filepath.Join(dir, "mapping")
+	if !assert.NoError(t, os.MkdirAll(mappingDir, 0755)) {
+		return
+	}
+	mappingFile := filepath.Join(mappingDir, "ifType.yaml")
+	if !assert.NoError(t, os.WriteFile(mappingFile, []byte("1: overridden\n"), 0644)) {
+		return
+	}
+	if !assert.NoError(t, os.WriteFile(file, []byte("name: overlayvendor\nmatch: invalid\n"), 0644)) {
+		return
+	}
+	assert.Error(t, ReloadHierarchy(ctx), "reloading invalid device classes needs to fail")
+	_, err = GetNetworkDeviceCommunicator(ctx, "overlayvendor")
+	assert.NoError(t, err, "the current hierarchy needs to be kept if reloading fails")
+	val, err := mapping.GetMappedValue("ifType.yaml", "1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "other", val, "the current mappings need to be kept if reloading fails")
+	}
+
+	if !assert.NoError(t, os.Remove(file)) || !assert.NoError(t, os.WriteFile(mappingFile, []byte("1: [\n"), 0644)) {
+		return
+	}
+	assert.Error(t, ReloadHierarchy(ctx), "reloading invalid mappings needs to fail")
+	_, err = GetNetworkDeviceCommunicator(ctx, "overlayvendor")
+	assert.NoError(t, err, "the current hierarchy needs to be kept if reading the mappings fails")
+}
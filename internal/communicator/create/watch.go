@@ -0,0 +1,69 @@
+package create
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/thola/config"
+	"github.com/rs/zerolog/log"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// WatchOverlayDirectory reloads the hierarchy every time files in the overlay directory change,
+// until the context is canceled. The directory is checked for changes in the given interval.
+// Nothing is done if no overlay directory is set.
+func WatchOverlayDirectory(ctx context.Context, interval time.Duration) {
+	dir := config.OverlayDirectory()
+	if dir == "" {
+		return
+	}
+	log.Ctx(ctx).Info().Str("directory", dir).Msg("watching overlay directory for changes")
+
+	last, err := fingerprintDirectory(dir)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to read overlay directory")
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		current, err := fingerprintDirectory(dir)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to read overlay directory")
+			continue
+		}
+		if current == last {
+			continue
+		}
+		last = current
+
+		if err := ReloadHierarchy(ctx); err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to reload device classes, keeping the current device classes")
+			continue
+		}
+		log.Ctx(ctx).Info().Msg("reloaded device classes after the overlay directory changed")
+	}
+}
+
+// fingerprintDirectory returns a string that changes when a file in the directory is added, removed or modified.
+func fingerprintDirectory(dir string) (string, error) {
+	var fingerprint string
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&fs.ModeType == 0 {
+			fingerprint += fmt.Sprintf("%s %d %d\n", path, info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	return fingerprint, err
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/inexio/thola/internal/component"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
+	"github.com/inexio/thola/internal/explain"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -512,14 +513,131 @@ func (c *networkDeviceCommunicator) GetHighAvailabilityComponent(ctx context.Con
 	return ha, nil
 }
 
+func (c *networkDeviceCommunicator) GetInventoryComponent(ctx context.Context) (device.InventoryComponent, error) {
+	if !c.HasComponent(component.Inventory) {
+		return device.InventoryComponent{}, tholaerr.NewComponentNotFoundError("no inventory component available for this device")
+	}
+
+	var inventory device.InventoryComponent
+
+	empty := true
+
+	entities, err := c.GetInventoryComponentEntities(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.InventoryComponent{}, errors.Wrap(err, "error occurred during get inventory component entities")
+		}
+	} else {
+		inventory.Entities = entities
+		empty = false
+	}
+
+	if empty {
+		return device.InventoryComponent{}, tholaerr.NewNotFoundError("no inventory data available")
+	}
+
+	return inventory, nil
+}
+
+func (c *networkDeviceCommunicator) GetNeighborsComponent(ctx context.Context) (device.NeighborsComponent, error) {
+	if !c.HasComponent(component.Neighbors) {
+		return device.NeighborsComponent{}, tholaerr.NewComponentNotFoundError("no neighbors component available for this device")
+	}
+
+	var neighbors device.NeighborsComponent
+
+	empty := true
+
+	res, err := c.GetNeighborsComponentNeighbors(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.NeighborsComponent{}, errors.Wrap(err, "error occurred during get neighbors component neighbors")
+		}
+	} else {
+		neighbors.Neighbors = res
+		empty = false
+	}
+
+	if empty {
+		return device.NeighborsComponent{}, tholaerr.NewNotFoundError("no neighbors data available")
+	}
+
+	return neighbors, nil
+}
+
+func (c *networkDeviceCommunicator) GetBGPComponent(ctx context.Context) (device.BGPComponent, error) {
+	if !c.HasComponent(component.BGP) {
+		return device.BGPComponent{}, tholaerr.NewComponentNotFoundError("no bgp component available for this device")
+	}
+
+	var bgp device.BGPComponent
+
+	empty := true
+
+	res, err := c.GetBGPComponentPeers(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.BGPComponent{}, errors.Wrap(err, "error occurred during get bgp component peers")
+		}
+	} else {
+		bgp.Peers = res
+		empty = false
+	}
+
+	if empty {
+		return device.BGPComponent{}, tholaerr.NewNotFoundError("no bgp data available")
+	}
+
+	return bgp, nil
+}
+
+func (c *networkDeviceCommunicator) GetRoutingProtocolsComponent(ctx context.Context) (device.RoutingProtocolsComponent, error) {
+	if !c.HasComponent(component.RoutingProtocols) {
+		return device.RoutingProtocolsComponent{}, tholaerr.NewComponentNotFoundError("no routing protocols component available for this device")
+	}
+
+	var routingProtocols device.RoutingProtocolsComponent
+
+	empty := true
+
+	ospfNeighbors, err := c.GetRoutingProtocolsComponentOSPFNeighbors(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.RoutingProtocolsComponent{}, errors.Wrap(err, "error occurred during get ospf neighbors")
+		}
+	} else {
+		routingProtocols.OSPFNeighbors = ospfNeighbors
+		empty = false
+	}
+
+	isisAdjacencies, err := c.GetRoutingProtocolsComponentISISAdjacencies(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.RoutingProtocolsComponent{}, errors.Wrap(err, "error occurred during get is-is adjacencies")
+		}
+	} else {
+		routingProtocols.ISISAdjacencies = isisAdjacencies
+		empty = false
+	}
+
+	if empty {
+		return device.RoutingProtocolsComponent{}, tholaerr.NewNotFoundError("no routing protocols data available")
+	}
+
+	return routingProtocols, nil
+}
+
 func (c *networkDeviceCommunicator) GetVendor(ctx context.Context) (string, error) {
+	ctx, trace := explain.StartProperty(ctx, "Vendor")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetVendor(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -528,13 +646,16 @@ func (c *networkDeviceCommunicator) GetVendor(ctx context.Context) (string, erro
 }
 
 func (c *networkDeviceCommunicator) GetModel(ctx context.Context) (string, error) {
+	ctx, trace := explain.StartProperty(ctx, "Model")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetModel(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -543,13 +664,16 @@ func (c *networkDeviceCommunicator) GetModel(ctx context.Context) (string, error
 }
 
 func (c *networkDeviceCommunicator) GetModelSeries(ctx context.Context) (string, error) {
+	ctx, trace := explain.StartProperty(ctx, "ModelSeries")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetModelSeries(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -558,13 +682,16 @@ func (c *networkDeviceCommunicator) GetModelSeries(ctx context.Context) (string,
 }
 
 func (c *networkDeviceCommunicator) GetSerialNumber(ctx context.Context) (string, error) {
+	ctx, trace := explain.StartProperty(ctx, "SerialNumber")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSerialNumber(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -573,13 +700,16 @@ func (c *networkDeviceCommunicator) GetSerialNumber(ctx context.Context) (string
 }
 
 func (c *networkDeviceCommunicator) GetOSVersion(ctx context.Context) (string, error) {
+	ctx, trace := explain.StartProperty(ctx, "OSVersion")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetOSVersion(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -592,13 +722,16 @@ func (c *networkDeviceCommunicator) GetInterfaces(ctx context.Context, filter ..
 		return nil, tholaerr.NewComponentNotFoundError("no interface component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "Interfaces")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetInterfaces(ctx, filter...)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -611,13 +744,16 @@ func (c *networkDeviceCommunicator) GetCountInterfaces(ctx context.Context) (int
 		return 0, tholaerr.NewComponentNotFoundError("no interface component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "CountInterfaces")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetCountInterfaces(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -641,13 +777,16 @@ func (c *networkDeviceCommunicator) GetCPUComponentCPULoad(ctx context.Context)
 		return nil, tholaerr.NewComponentNotFoundError("no cpu component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "CPUComponentCPULoad")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetCPUComponentCPULoad(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -660,13 +799,16 @@ func (c *networkDeviceCommunicator) GetMemoryComponentMemoryUsage(ctx context.Co
 		return nil, tholaerr.NewComponentNotFoundError("no memory component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "MemoryComponentMemoryUsage")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetMemoryComponentMemoryUsage(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -679,13 +821,16 @@ func (c *networkDeviceCommunicator) GetDiskComponentStorages(ctx context.Context
 		return nil, tholaerr.NewComponentNotFoundError("no disk component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "DiskComponentStorages")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetDiskComponentStorages(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -698,13 +843,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentAlarmLowVoltageDisconnect(ctx
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentAlarmLowVoltageDisconnect")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentAlarmLowVoltageDisconnect(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -717,13 +865,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryAmperage(ctx context.C
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentBatteryAmperage")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentBatteryAmperage(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -736,13 +887,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryCapacity(ctx context.C
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentBatteryCapacity")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentBatteryCapacity(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -755,13 +909,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryCurrent(ctx context.Co
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentBatteryCurrent")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentBatteryCurrent(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -774,13 +931,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryRemainingTime(ctx cont
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentBatteryRemainingTime")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentBatteryRemainingTime(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -793,13 +953,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryTemperature(ctx contex
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentBatteryTemperature")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentBatteryTemperature(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -812,13 +975,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentBatteryVoltage(ctx context.Co
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentBatteryVoltage")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentBatteryVoltage(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -831,13 +997,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentCurrentLoad(ctx context.Conte
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentCurrentLoad")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentCurrentLoad(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -850,13 +1019,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentMainsVoltageApplied(ctx conte
 		return false, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentMainsVoltageApplied")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentMainsVoltageApplied(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return false, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -869,13 +1041,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentRectifierCurrent(ctx context.
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentRectifierCurrent")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentRectifierCurrent(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -888,13 +1063,16 @@ func (c *networkDeviceCommunicator) GetUPSComponentSystemVoltage(ctx context.Con
 		return 0, tholaerr.NewComponentNotFoundError("no ups component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "UPSComponentSystemVoltage")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetUPSComponentSystemVoltage(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -907,13 +1085,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentAgents(ctx context.Context) (
 		return nil, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentAgents")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentAgents(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -926,13 +1107,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentRealms(ctx context.Context) (
 		return nil, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentRealms")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentRealms(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -945,13 +1129,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentGlobalCallPerSecond(ctx conte
 		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentGlobalCallPerSecond")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentGlobalCallPerSecond(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -964,13 +1151,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentGlobalConcurrentSessions(ctx
 		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentGlobalConcurrentSessions")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentGlobalConcurrentSessions(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -983,13 +1173,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentActiveLocalContacts(ctx conte
 		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentActiveLocalContacts")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentActiveLocalContacts(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1002,13 +1195,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentTranscodingCapacity(ctx conte
 		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentTranscodingCapacity")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentTranscodingCapacity(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1021,13 +1217,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentLicenseCapacity(ctx context.C
 		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentLicenseCapacity")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentLicenseCapacity(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1040,13 +1239,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentSystemRedundancy(ctx context.
 		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentSystemRedundancy")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentSystemRedundancy(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1059,13 +1261,16 @@ func (c *networkDeviceCommunicator) GetSBCComponentSystemHealthScore(ctx context
 		return 0, tholaerr.NewComponentNotFoundError("no sbc component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "SBCComponentSystemHealthScore")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetSBCComponentSystemHealthScore(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1078,13 +1283,16 @@ func (c *networkDeviceCommunicator) GetServerComponentProcs(ctx context.Context)
 		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "ServerComponentProcs")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetServerComponentProcs(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1097,13 +1305,16 @@ func (c *networkDeviceCommunicator) GetServerComponentUsers(ctx context.Context)
 		return 0, tholaerr.NewComponentNotFoundError("no server component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "ServerComponentUsers")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetServerComponentUsers(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1116,13 +1327,16 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponentEnvironmentMonitor
 		return "", tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HardwareHealthComponentEnvironmentMonitorState")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHardwareHealthComponentEnvironmentMonitorState(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1135,13 +1349,16 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponentFans(ctx context.C
 		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HardwareHealthComponentFans")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHardwareHealthComponentFans(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1154,13 +1371,16 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponentPowerSupply(ctx co
 		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HardwareHealthComponentPowerSupply")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHardwareHealthComponentPowerSupply(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1173,13 +1393,16 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponentTemperature(ctx co
 		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HardwareHealthComponentTemperature")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHardwareHealthComponentTemperature(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1192,13 +1415,16 @@ func (c *networkDeviceCommunicator) GetHardwareHealthComponentVoltage(ctx contex
 		return nil, tholaerr.NewComponentNotFoundError("no hardware health component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HardwareHealthComponentVoltage")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHardwareHealthComponentVoltage(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return nil, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1211,13 +1437,16 @@ func (c *networkDeviceCommunicator) GetHighAvailabilityComponentState(ctx contex
 		return "", tholaerr.NewComponentNotFoundError("no ha component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HighAvailabilityComponentState")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHighAvailabilityComponentState(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1230,13 +1459,16 @@ func (c *networkDeviceCommunicator) GetHighAvailabilityComponentRole(ctx context
 		return "", tholaerr.NewComponentNotFoundError("no ha component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HighAvailabilityComponentRole")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHighAvailabilityComponentRole(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return "", errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
@@ -1249,16 +1481,129 @@ func (c *networkDeviceCommunicator) GetHighAvailabilityComponentNodes(ctx contex
 		return 0, tholaerr.NewComponentNotFoundError("no ha component available for this device")
 	}
 
+	ctx, trace := explain.StartProperty(ctx, "HighAvailabilityComponentNodes")
 	if c.codeCommunicator != nil {
 		res, err := c.codeCommunicator.GetHighAvailabilityComponentNodes(ctx)
 		if err != nil {
 			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
 				return 0, errors.Wrap(err, "error in code communicator")
 			}
 		} else {
+			trace.UseCodeCommunicator(nil)
 			return res, nil
 		}
 	}
 
 	return c.deviceClassCommunicator.GetHighAvailabilityComponentNodes(ctx)
 }
+
+func (c *networkDeviceCommunicator) GetInventoryComponentEntities(ctx context.Context) ([]device.InventoryComponentEntity, error) {
+	if !c.HasComponent(component.Inventory) {
+		return nil, tholaerr.NewComponentNotFoundError("no inventory component available for this device")
+	}
+
+	ctx, trace := explain.StartProperty(ctx, "InventoryComponentEntities")
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetInventoryComponentEntities(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			trace.UseCodeCommunicator(nil)
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetInventoryComponentEntities(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetNeighborsComponentNeighbors(ctx context.Context) ([]device.NeighborsComponentNeighbor, error) {
+	if !c.HasComponent(component.Neighbors) {
+		return nil, tholaerr.NewComponentNotFoundError("no neighbors component available for this device")
+	}
+
+	ctx, trace := explain.StartProperty(ctx, "NeighborsComponentNeighbors")
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetNeighborsComponentNeighbors(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			trace.UseCodeCommunicator(nil)
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetNeighborsComponentNeighbors(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetBGPComponentPeers(ctx context.Context) ([]device.BGPComponentPeer, error) {
+	if !c.HasComponent(component.BGP) {
+		return nil, tholaerr.NewComponentNotFoundError("no bgp component available for this device")
+	}
+
+	ctx, trace := explain.StartProperty(ctx, "BGPComponentPeers")
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetBGPComponentPeers(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			trace.UseCodeCommunicator(nil)
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetBGPComponentPeers(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetRoutingProtocolsComponentOSPFNeighbors(ctx context.Context) ([]device.RoutingProtocolsComponentOSPFNeighbor, error) {
+	if !c.HasComponent(component.RoutingProtocols) {
+		return nil, tholaerr.NewComponentNotFoundError("no routing protocols component available for this device")
+	}
+
+	ctx, trace := explain.StartProperty(ctx, "RoutingProtocolsComponentOSPFNeighbors")
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetRoutingProtocolsComponentOSPFNeighbors(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			trace.UseCodeCommunicator(nil)
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetRoutingProtocolsComponentOSPFNeighbors(ctx)
+}
+
+func (c *networkDeviceCommunicator) GetRoutingProtocolsComponentISISAdjacencies(ctx context.Context) ([]device.RoutingProtocolsComponentISISAdjacency, error) {
+	if !c.HasComponent(component.RoutingProtocols) {
+		return nil, tholaerr.NewComponentNotFoundError("no routing protocols component available for this device")
+	}
+
+	ctx, trace := explain.StartProperty(ctx, "RoutingProtocolsComponentISISAdjacencies")
+	if c.codeCommunicator != nil {
+		res, err := c.codeCommunicator.GetRoutingProtocolsComponentISISAdjacencies(ctx)
+		if err != nil {
+			if !tholaerr.IsNotImplementedError(err) {
+				trace.UseCodeCommunicator(err)
+				return nil, errors.Wrap(err, "error in code communicator")
+			}
+		} else {
+			trace.UseCodeCommunicator(nil)
+			return res, nil
+		}
+	}
+
+	return c.deviceClassCommunicator.GetRoutingProtocolsComponentISISAdjacencies(ctx)
+}
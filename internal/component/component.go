@@ -19,6 +19,10 @@ const (
 	Disk
 	HardwareHealth
 	HighAvailability
+	Inventory
+	Neighbors
+	BGP
+	RoutingProtocols
 )
 
 // CreateComponent creates a component.
@@ -42,6 +46,14 @@ func CreateComponent(component string) (Component, error) {
 		return HardwareHealth, nil
 	case "high_availability":
 		return HighAvailability, nil
+	case "inventory":
+		return Inventory, nil
+	case "neighbors":
+		return Neighbors, nil
+	case "bgp":
+		return BGP, nil
+	case "routing_protocols":
+		return RoutingProtocols, nil
 	default:
 		return 0, fmt.Errorf("invalid component type: %s", component)
 	}
@@ -71,6 +83,14 @@ func (d *Component) ToString() (string, error) {
 		return "hardware_health", nil
 	case HighAvailability:
 		return "high_availability", nil
+	case Inventory:
+		return "inventory", nil
+	case Neighbors:
+		return "neighbors", nil
+	case BGP:
+		return "bgp", nil
+	case RoutingProtocols:
+		return "routing_protocols", nil
 	default:
 		return "", errors.New("unknown component")
 	}
@@ -0,0 +1,79 @@
+// Package credential provides the SNMP credentials of credential profiles, so that communities and
+// SNMP v3 keys do not need to be passed in the config, flags or every request.
+//
+// The credentials are read from the configured provider: an encrypted file, environment variables
+// or a HashiCorp Vault compatible HTTP API.
+package credential
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/viper"
+	"os"
+	"sync"
+)
+
+// SNMP contains the SNMP credentials of a credential profile.
+type SNMP struct {
+	// The SNMP community strings
+	Communities []string `yaml:"communities" json:"communities"`
+	// The data required for an SNMP v3 connection
+	V3Data network.SNMPv3ConnectionData `yaml:"v3_data" json:"v3_data"`
+}
+
+// Provider provides the credentials of credential profiles.
+type Provider interface {
+	// GetSNMPCredentials returns the SNMP credentials of the profile.
+	// It returns a tholaerr.NotFoundError if the profile does not exist.
+	GetSNMPCredentials(ctx context.Context, profile string) (SNMP, error)
+}
+
+var provider struct {
+	sync.Once
+	Provider
+
+	err error
+}
+
+// GetSNMPCredentials returns the SNMP credentials of the profile from the configured provider.
+func GetSNMPCredentials(ctx context.Context, profile string) (SNMP, error) {
+	provider.Do(func() {
+		provider.Provider, provider.err = newConfiguredProvider(ctx)
+	})
+	if provider.err != nil {
+		return SNMP{}, errors.Wrap(provider.err, "failed to initialize credential provider")
+	}
+	return provider.GetSNMPCredentials(ctx, profile)
+}
+
+func newConfiguredProvider(ctx context.Context) (Provider, error) {
+	name := viper.GetString("credentials.provider")
+	log.Ctx(ctx).Debug().Str("provider", name).Msg("initializing credential provider")
+	switch name {
+	case "file":
+		return NewFileProvider(viper.GetString("credentials.file"), viper.GetString("credentials.key"))
+	case "env":
+		return NewEnvProvider(), nil
+	case "vault":
+		return NewVaultProvider(VaultConfig{
+			Address: stringOrEnv(viper.GetString("credentials.vault.addr"), "VAULT_ADDR"),
+			Token:   stringOrEnv(viper.GetString("credentials.vault.token"), "VAULT_TOKEN"),
+			Mount:   viper.GetString("credentials.vault.mount"),
+			Path:    viper.GetString("credentials.vault.path"),
+		})
+	case "":
+		return nil, errors.New("no credential provider configured")
+	default:
+		return nil, errors.New("invalid credential provider, only 'file', 'env' and 'vault' supported")
+	}
+}
+
+// stringOrEnv returns s, or the environment variable if s is empty.
+func stringOrEnv(s, env string) string {
+	if s != "" {
+		return s
+	}
+	return os.Getenv(env)
+}
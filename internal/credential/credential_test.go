@@ -0,0 +1,109 @@
+package credential
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testProfiles = `
+core:
+  communities:
+    - secret
+access:
+  v3_data:
+    level: authPriv
+    user: monitoring
+    auth_key: authpass
+    auth_protocol: SHA
+    priv_key: privpass
+    priv_protocol: AES
+`
+
+func TestFileProvider(t *testing.T) {
+	b, err := EncryptProfiles([]byte(testProfiles), "passphrase")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotContains(t, string(b), "secret")
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	if !assert.NoError(t, os.WriteFile(path, b, 0600)) {
+		return
+	}
+
+	_, err = NewFileProvider(path, "wrong")
+	assert.Error(t, err)
+
+	p, err := NewFileProvider(path, "passphrase")
+	if !assert.NoError(t, err) {
+		return
+	}
+	res, err := p.GetSNMPCredentials(context.Background(), "core")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"secret"}, res.Communities)
+	}
+	res, err = p.GetSNMPCredentials(context.Background(), "access")
+	if assert.NoError(t, err) && assert.NotNil(t, res.V3Data.PrivKey) {
+		assert.Equal(t, "privpass", *res.V3Data.PrivKey)
+	}
+	_, err = p.GetSNMPCredentials(context.Background(), "missing")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+
+	_, err = EncryptProfiles([]byte("core:\n  unknown: 1\n"), "passphrase")
+	assert.Error(t, err)
+}
+
+func TestEnvProvider(t *testing.T) {
+	env := map[string]string{
+		"THOLA_CREDENTIAL_SITE_A_COMMUNITIES": "public, private",
+		"THOLA_CREDENTIAL_SITE_A_V3_USER":     "monitoring",
+	}
+	p := &envProvider{lookupEnv: func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}}
+
+	res, err := p.GetSNMPCredentials(context.Background(), "site-a")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"public", "private"}, res.Communities)
+		assert.Equal(t, "monitoring", *res.V3Data.User)
+		assert.Nil(t, res.V3Data.AuthKey)
+	}
+
+	_, err = p.GetSNMPCredentials(context.Background(), "site-b")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
+
+func TestVaultProvider(t *testing.T) {
+	profiles, err := ReadProfiles([]byte(testProfiles))
+	if !assert.NoError(t, err) {
+		return
+	}
+	server := httptest.NewServer(NewVaultStandIn(profiles, "token", "kv", "network/snmp"))
+	defer server.Close()
+
+	p, err := NewVaultProvider(VaultConfig{Address: server.URL, Token: "token", Mount: "kv", Path: "network/snmp"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	res, err := p.GetSNMPCredentials(context.Background(), "access")
+	if assert.NoError(t, err) && assert.NotNil(t, res.V3Data.User) {
+		assert.Equal(t, "monitoring", *res.V3Data.User)
+		assert.Equal(t, "AES", *res.V3Data.PrivProtocol)
+	}
+	_, err = p.GetSNMPCredentials(context.Background(), "missing")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+
+	p, err = NewVaultProvider(VaultConfig{Address: server.URL, Token: "wrong", Mount: "kv", Path: "network/snmp"})
+	if assert.NoError(t, err) {
+		_, err = p.GetSNMPCredentials(context.Background(), "core")
+		if assert.Error(t, err) {
+			assert.False(t, tholaerr.IsNotFoundError(err))
+			assert.Contains(t, err.Error(), "permission denied")
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package credential
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/tholaerr"
+	"os"
+	"strings"
+)
+
+// envProvider provides the credentials of environment variables.
+type envProvider struct {
+	lookupEnv func(string) (string, bool)
+}
+
+// NewEnvProvider returns a provider that reads the credentials of a profile from the environment variables
+// THOLA_CREDENTIAL_<PROFILE>_COMMUNITIES (comma separated), THOLA_CREDENTIAL_<PROFILE>_V3_LEVEL,
+// _V3_CONTEXT, _V3_USER, _V3_AUTH_KEY, _V3_AUTH_PROTO, _V3_PRIV_KEY and _V3_PRIV_PROTO.
+// The profile name is upper-cased and every character that is not a letter or digit is replaced by '_'.
+func NewEnvProvider() Provider {
+	return &envProvider{lookupEnv: os.LookupEnv}
+}
+
+func (p *envProvider) GetSNMPCredentials(_ context.Context, profile string) (SNMP, error) {
+	prefix := envPrefix(profile)
+	found := false
+	lookup := func(name string) *string {
+		value, ok := p.lookupEnv(prefix + name)
+		if !ok {
+			return nil
+		}
+		found = true
+		return &value
+	}
+
+	var res SNMP
+	if communities := lookup("COMMUNITIES"); communities != nil {
+		for _, community := range strings.Split(*communities, ",") {
+			if community = strings.TrimSpace(community); community != "" {
+				res.Communities = append(res.Communities, community)
+			}
+		}
+	}
+	res.V3Data.Level = lookup("V3_LEVEL")
+	res.V3Data.ContextName = lookup("V3_CONTEXT")
+	res.V3Data.User = lookup("V3_USER")
+	res.V3Data.AuthKey = lookup("V3_AUTH_KEY")
+	res.V3Data.AuthProtocol = lookup("V3_AUTH_PROTO")
+	res.V3Data.PrivKey = lookup("V3_PRIV_KEY")
+	res.V3Data.PrivProtocol = lookup("V3_PRIV_PROTO")
+
+	if !found {
+		return SNMP{}, tholaerr.NewNotFoundError("no environment variables with prefix '" + prefix + "' set")
+	}
+	return res, nil
+}
+
+func envPrefix(profile string) string {
+	name := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' {
+			return r - 'a' + 'A'
+		}
+		if r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, profile)
+	return "THOLA_CREDENTIAL_" + name + "_"
+}
@@ -0,0 +1,94 @@
+package credential
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/inexio/thola/internal/encryption"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"os"
+)
+
+// encryptedFile is the format of an encrypted credential file.
+// Data contains the encrypted profiles in the same format as ReadProfiles expects.
+type encryptedFile struct {
+	Salt []byte `json:"salt"`
+	Data []byte `json:"data"`
+}
+
+// fileProvider provides the credentials of an encrypted credential file.
+type fileProvider struct {
+	profiles map[string]SNMP
+}
+
+// NewFileProvider returns a provider for the encrypted credential file, which was created with EncryptProfiles.
+// The file is read and decrypted once.
+func NewFileProvider(path, passphrase string) (Provider, error) {
+	if path == "" {
+		return nil, errors.New("no credential file set")
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read credential file")
+	}
+	profiles, err := DecryptProfiles(b, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return &fileProvider{profiles: profiles}, nil
+}
+
+func (p *fileProvider) GetSNMPCredentials(_ context.Context, profile string) (SNMP, error) {
+	res, ok := p.profiles[profile]
+	if !ok {
+		return SNMP{}, tholaerr.NewNotFoundError("credential profile '" + profile + "' does not exist")
+	}
+	return res, nil
+}
+
+// ReadProfiles parses unencrypted profiles in YAML or JSON format, which map the profile names to credentials.
+func ReadProfiles(b []byte) (map[string]SNMP, error) {
+	var profiles map[string]SNMP
+	if err := yaml.UnmarshalStrict(b, &profiles); err != nil {
+		return nil, errors.Wrap(err, "failed to parse credential profiles")
+	}
+	return profiles, nil
+}
+
+// EncryptProfiles validates and encrypts unencrypted profiles, the result can be used as credential file.
+func EncryptProfiles(b []byte, passphrase string) ([]byte, error) {
+	if _, err := ReadProfiles(b); err != nil {
+		return nil, err
+	}
+	salt, err := encryption.NewSalt()
+	if err != nil {
+		return nil, err
+	}
+	c, err := encryption.NewCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.Encrypt(b)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(encryptedFile{Salt: salt, Data: data})
+}
+
+// DecryptProfiles decrypts and parses a credential file.
+func DecryptProfiles(b []byte, passphrase string) (map[string]SNMP, error) {
+	var file encryptedFile
+	if err := json.Unmarshal(b, &file); err != nil || file.Salt == nil || file.Data == nil {
+		return nil, errors.New("credential file is not encrypted")
+	}
+	c, err := encryption.NewCipher(passphrase, file.Salt)
+	if err != nil {
+		return nil, err
+	}
+	data, err := c.Decrypt(file.Data)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decrypt credential file")
+	}
+	return ReadProfiles(data)
+}
@@ -0,0 +1,151 @@
+package credential
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultVaultMount = "secret"
+	defaultVaultPath  = "thola"
+)
+
+// VaultConfig is the configuration of the vault provider.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200
+	Address string
+	// Token that is sent in the X-Vault-Token header
+	Token string
+	// Mount path of the KV version 2 secrets engine (default: secret)
+	Mount string
+	// Path below the mount that contains one secret per profile (default: thola)
+	Path string
+}
+
+// vaultProvider provides the credentials of the KV version 2 secrets engine of a HashiCorp Vault compatible HTTP API.
+type vaultProvider struct {
+	config VaultConfig
+	client *http.Client
+}
+
+// vaultSecret is the response of the KV version 2 secrets engine when reading a secret.
+type vaultSecret struct {
+	Data struct {
+		Data SNMP `json:"data"`
+	} `json:"data"`
+}
+
+// vaultErrors is the response of Vault if a request failed.
+type vaultErrors struct {
+	Errors []string `json:"errors"`
+}
+
+// NewVaultProvider returns a provider that reads the credentials of a profile from the secret
+// <mount>/data/<path>/<profile> of a Vault server. The secret has the same fields as a profile
+// of a credential file, e.g. {"communities": ["public"], "v3_data": {"user": "monitoring"}}.
+func NewVaultProvider(config VaultConfig) (Provider, error) {
+	if config.Address == "" {
+		return nil, errors.New("no vault address set")
+	}
+	if _, err := url.Parse(config.Address); err != nil {
+		return nil, errors.Wrap(err, "invalid vault address")
+	}
+	if config.Token == "" {
+		return nil, errors.New("no vault token set")
+	}
+	if config.Mount == "" {
+		config.Mount = defaultVaultMount
+	}
+	if config.Path == "" {
+		config.Path = defaultVaultPath
+	}
+	config.Address = strings.TrimSuffix(config.Address, "/")
+	config.Mount = strings.Trim(config.Mount, "/")
+	config.Path = strings.Trim(config.Path, "/")
+	return &vaultProvider{
+		config: config,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+func (p *vaultProvider) GetSNMPCredentials(ctx context.Context, profile string) (SNMP, error) {
+	u := p.config.Address + "/v1/" + p.config.Mount + "/data/" + p.config.Path + "/" + url.PathEscape(profile)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return SNMP{}, errors.Wrap(err, "failed to create vault request")
+	}
+	req.Header.Set("X-Vault-Token", p.config.Token)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return SNMP{}, errors.Wrap(err, "failed to send request to vault")
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return SNMP{}, tholaerr.NewNotFoundError("credential profile '" + profile + "' does not exist in vault")
+	default:
+		var e vaultErrors
+		_ = json.NewDecoder(resp.Body).Decode(&e)
+		return SNMP{}, errors.Errorf("vault returned status code %d: %s", resp.StatusCode, strings.Join(e.Errors, ", "))
+	}
+
+	var secret vaultSecret
+	if err := json.NewDecoder(resp.Body).Decode(&secret); err != nil {
+		return SNMP{}, errors.Wrap(err, "failed to parse vault response")
+	}
+	return secret.Data.Data, nil
+}
+
+// NewVaultStandIn returns a handler that serves the profiles like the KV version 2 secrets engine of Vault
+// with the given mount and path, so that the vault provider can be used without a Vault server,
+// e.g. for development and tests. Every request needs the token in the X-Vault-Token header.
+func NewVaultStandIn(profiles map[string]SNMP, token, mount, path string) http.Handler {
+	if mount == "" {
+		mount = defaultVaultMount
+	}
+	if path == "" {
+		path = defaultVaultPath
+	}
+	prefix := "/v1/" + strings.Trim(mount, "/") + "/data/" + strings.Trim(path, "/") + "/"
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON := func(statusCode int, v interface{}) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(statusCode)
+			_ = json.NewEncoder(w).Encode(v)
+		}
+
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("X-Vault-Token")), []byte(token)) != 1 {
+			writeJSON(http.StatusForbidden, vaultErrors{Errors: []string{"permission denied"}})
+			return
+		}
+		if r.Method != http.MethodGet {
+			writeJSON(http.StatusMethodNotAllowed, vaultErrors{Errors: []string{"unsupported operation"}})
+			return
+		}
+		profile, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), prefix))
+		if !strings.HasPrefix(r.URL.EscapedPath(), prefix) || err != nil {
+			writeJSON(http.StatusNotFound, vaultErrors{Errors: []string{}})
+			return
+		}
+		credentials, ok := profiles[profile]
+		if !ok {
+			writeJSON(http.StatusNotFound, vaultErrors{Errors: []string{}})
+			return
+		}
+
+		var secret vaultSecret
+		secret.Data.Data = credentials
+		writeJSON(http.StatusOK, secret)
+	})
+}
@@ -113,6 +113,122 @@ func (d *badgerDatabase) GetConnectionData(_ context.Context, ip string) (networ
 	return data, nil
 }
 
+func (d *badgerDatabase) SetInterfaceCounters(_ context.Context, ip string, data InterfaceCounterSnapshot) error {
+	txn := d.db.NewTransaction(true)
+	defer txn.Discard()
+
+	JSONData, err := parser.ToJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshall interface counters")
+	}
+	entry := badger.Entry{
+		Key:       []byte("InterfaceCounters-" + ip),
+		Value:     JSONData,
+		ExpiresAt: uint64(time.Now().Add(cacheExpiration).Unix()),
+	}
+
+	err = txn.SetEntry(&entry)
+	if err != nil {
+		return errors.Wrap(err, "failed to store interface counters")
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to store interface counters")
+	}
+	return nil
+}
+
+func (d *badgerDatabase) GetInterfaceCounters(_ context.Context, ip string) (InterfaceCounterSnapshot, error) {
+	txn := d.db.NewTransaction(false)
+	defer txn.Discard()
+
+	item, err := txn.Get([]byte("InterfaceCounters-" + ip))
+	if err != nil {
+		return InterfaceCounterSnapshot{}, tholaerr.NewNotFoundError("cannot find cache entry")
+	}
+
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return InterfaceCounterSnapshot{}, errors.Wrap(err, "failed to get value from db item")
+	}
+
+	data := InterfaceCounterSnapshot{}
+	err = json.Unmarshal(value, &data)
+	if err != nil {
+		return InterfaceCounterSnapshot{}, errors.Wrap(err, "failed to unmarshall interface counters")
+	}
+	return data, nil
+}
+
+func (d *badgerDatabase) SetDeviceRecord(_ context.Context, ip string, data DeviceRecord) error {
+	txn := d.db.NewTransaction(true)
+	defer txn.Discard()
+
+	JSONData, err := parser.ToJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshall device record")
+	}
+
+	err = txn.Set([]byte("DeviceRecord-"+ip), JSONData)
+	if err != nil {
+		return errors.Wrap(err, "failed to store device record")
+	}
+
+	err = txn.Commit()
+	if err != nil {
+		return errors.Wrap(err, "failed to store device record")
+	}
+	return nil
+}
+
+func (d *badgerDatabase) GetDeviceRecord(_ context.Context, ip string) (DeviceRecord, error) {
+	txn := d.db.NewTransaction(false)
+	defer txn.Discard()
+
+	item, err := txn.Get([]byte("DeviceRecord-" + ip))
+	if err != nil {
+		return DeviceRecord{}, tholaerr.NewNotFoundError("cannot find device record")
+	}
+
+	value, err := item.ValueCopy(nil)
+	if err != nil {
+		return DeviceRecord{}, errors.Wrap(err, "failed to get value from db item")
+	}
+
+	data := DeviceRecord{}
+	err = json.Unmarshal(value, &data)
+	if err != nil {
+		return DeviceRecord{}, errors.Wrap(err, "failed to unmarshall device record")
+	}
+	return data, nil
+}
+
+func (d *badgerDatabase) GetDeviceRecords(_ context.Context) ([]DeviceRecord, error) {
+	txn := d.db.NewTransaction(false)
+	defer txn.Discard()
+
+	it := txn.NewIterator(badger.DefaultIteratorOptions)
+	defer it.Close()
+
+	var records []DeviceRecord
+	prefix := []byte("DeviceRecord-")
+	for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+		value, err := it.Item().ValueCopy(nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get value from db item")
+		}
+
+		data := DeviceRecord{}
+		err = json.Unmarshal(value, &data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshall device record")
+		}
+		records = append(records, data)
+	}
+	return records, nil
+}
+
 func (d *badgerDatabase) CheckConnection(_ context.Context) error {
 	if d.db.IsClosed() {
 		return errors.New("badger db is closed")
@@ -7,6 +7,7 @@ import (
 	"github.com/gomodule/redigo/redis"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/jmoiron/sqlx"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -33,10 +34,248 @@ type Database interface {
 	GetDeviceProperties(ctx context.Context, ip string) (device.Device, error)
 	SetConnectionData(ctx context.Context, ip string, data network.ConnectionData) error
 	GetConnectionData(ctx context.Context, ip string) (network.ConnectionData, error)
+	SetInterfaceCounters(ctx context.Context, ip string, data InterfaceCounterSnapshot) error
+	GetInterfaceCounters(ctx context.Context, ip string) (InterfaceCounterSnapshot, error)
+	SetDeviceRecord(ctx context.Context, ip string, data DeviceRecord) error
+	GetDeviceRecord(ctx context.Context, ip string) (DeviceRecord, error)
+	GetDeviceRecords(ctx context.Context) ([]DeviceRecord, error)
 	CheckConnection(ctx context.Context) error
 	CloseConnection(ctx context.Context) error
 }
 
+// InterfaceCounterSnapshot represents the interface counters of a device.
+type InterfaceCounterSnapshot struct {
+	SysUpTime *uint64 `json:"sys_up_time"`
+
+	// Interfaces maps the ifIndex of an interface to its counters.
+	Interfaces map[uint64]InterfaceCounters `json:"interfaces"`
+}
+
+// InterfaceCounters represents the counters of an interface at a specific point in time.
+type InterfaceCounters struct {
+	Time     time.Time                   `json:"time"`
+	Counters map[string]InterfaceCounter `json:"counters"`
+}
+
+// InterfaceCounter represents a single interface counter.
+type InterfaceCounter struct {
+	Value uint64 `json:"value"`
+
+	// HighCapacity is true if the value was read from a 64-bit counter.
+	HighCapacity bool `json:"high_capacity"`
+}
+
+// DeviceRecord
+//
+// DeviceRecord is an entry of the device registry, which contains every device that was identified.
+// Unlike the cached device properties, device records do not expire.
+//
+// swagger:model
+type DeviceRecord struct {
+	// The IP address of the device.
+	//
+	// example: 192.168.178.1
+	IPAddress string `yaml:"ip_address" json:"ip_address" xml:"ip_address"`
+	// The class of the last identification and the last seen properties.
+	device.Device `yaml:",inline"`
+	// When the device was identified for the first time.
+	FirstSeen time.Time `yaml:"first_seen" json:"first_seen" xml:"first_seen"`
+	// When the device was identified for the last time.
+	LastSeen time.Time `yaml:"last_seen" json:"last_seen" xml:"last_seen"`
+	// The connection data of the last identification without credentials.
+	ConnectionData DeviceRecordConnectionData `yaml:"connection_data" json:"connection_data" xml:"connection_data"`
+	// The changes of the class and properties of the device, the oldest first.
+	Changes []DeviceChange `yaml:"changes,omitempty" json:"changes,omitempty" xml:"changes>change,omitempty"`
+	// When check identify looked for changes of the device for the last time.
+	ChangesCheckedAt *time.Time `yaml:"changes_checked_at,omitempty" json:"changes_checked_at,omitempty" xml:"changes_checked_at,omitempty"`
+}
+
+// DeviceRecordConnectionData
+//
+// DeviceRecordConnectionData is the connection data of the last identification of a device.
+// It contains no communities, keys, usernames or passwords, only the credential profile that was used.
+//
+// swagger:model
+type DeviceRecordConnectionData struct {
+	// Data of the snmp connection to the device
+	SNMP *DeviceRecordSNMPConnectionData `yaml:"snmp,omitempty" json:"snmp,omitempty" xml:"snmp,omitempty"`
+	// Data of the http connection to the device
+	HTTP *DeviceRecordHTTPConnectionData `yaml:"http,omitempty" json:"http,omitempty" xml:"http,omitempty"`
+	// Data of the ssh connection to the device
+	SSH *DeviceRecordPortConnectionData `yaml:"ssh,omitempty" json:"ssh,omitempty" xml:"ssh,omitempty"`
+	// Data of the netconf connection to the device
+	NETCONF *DeviceRecordPortConnectionData `yaml:"netconf,omitempty" json:"netconf,omitempty" xml:"netconf,omitempty"`
+	// Data of the gnmi connection to the device
+	GNMI *DeviceRecordPortConnectionData `yaml:"gnmi,omitempty" json:"gnmi,omitempty" xml:"gnmi,omitempty"`
+}
+
+// DeviceRecordSNMPConnectionData
+//
+// DeviceRecordSNMPConnectionData is the SNMP connection data of a device record.
+//
+// swagger:model
+type DeviceRecordSNMPConnectionData struct {
+	// The SNMP version(s) of the device.
+	//
+	// example: ["2c"]
+	Versions []string `yaml:"versions" json:"versions" xml:"versions"`
+	// The SNMP port(s) of the device.
+	//
+	// example: [161]
+	Ports []int `yaml:"ports" json:"ports" xml:"ports"`
+	// The security level of the SNMP v3 connection.
+	//
+	// example: authPriv
+	V3Level *string `yaml:"v3_level,omitempty" json:"v3_level,omitempty" xml:"v3_level,omitempty"`
+	// The name of the credential profile that was used.
+	//
+	// example: core
+	CredentialProfile *string `yaml:"credential_profile,omitempty" json:"credential_profile,omitempty" xml:"credential_profile,omitempty"`
+}
+
+// DeviceRecordHTTPConnectionData
+//
+// DeviceRecordHTTPConnectionData is the HTTP connection data of a device record.
+//
+// swagger:model
+type DeviceRecordHTTPConnectionData struct {
+	// The HTTP port(s) of the device.
+	//
+	// example: [80]
+	HTTPPorts []int `yaml:"http_ports" json:"http_ports" xml:"http_ports"`
+	// The HTTPS port(s) of the device.
+	//
+	// example: [443]
+	HTTPSPorts []int `yaml:"https_ports" json:"https_ports" xml:"https_ports"`
+}
+
+// DeviceRecordPortConnectionData
+//
+// DeviceRecordPortConnectionData is the SSH, NETCONF or gNMI connection data of a device record.
+//
+// swagger:model
+type DeviceRecordPortConnectionData struct {
+	// The port(s) of the device.
+	//
+	// example: [22]
+	Ports []int `yaml:"ports" json:"ports" xml:"ports"`
+}
+
+// newDeviceRecordConnectionData returns the connection data of a device record for the given connection data.
+func newDeviceRecordConnectionData(data network.ConnectionData) DeviceRecordConnectionData {
+	var res DeviceRecordConnectionData
+	if data.SNMP != nil {
+		res.SNMP = &DeviceRecordSNMPConnectionData{
+			Versions:          data.SNMP.Versions,
+			Ports:             data.SNMP.Ports,
+			V3Level:           data.SNMP.V3Data.Level,
+			CredentialProfile: data.SNMP.CredentialProfile,
+		}
+	}
+	if data.HTTP != nil {
+		res.HTTP = &DeviceRecordHTTPConnectionData{
+			HTTPPorts:  data.HTTP.HTTPPorts,
+			HTTPSPorts: data.HTTP.HTTPSPorts,
+		}
+	}
+	if data.SSH != nil {
+		res.SSH = &DeviceRecordPortConnectionData{Ports: data.SSH.Ports}
+	}
+	if data.NETCONF != nil {
+		res.NETCONF = &DeviceRecordPortConnectionData{Ports: data.NETCONF.Ports}
+	}
+	if data.GNMI != nil {
+		res.GNMI = &DeviceRecordPortConnectionData{Ports: data.GNMI.Ports}
+	}
+	return res
+}
+
+// DeviceChange
+//
+// DeviceChange is a change of the class or a property of a device between two identifications.
+//
+// swagger:model
+type DeviceChange struct {
+	// When the change was detected.
+	Time time.Time `yaml:"time" json:"time" xml:"time"`
+	// The property that changed.
+	//
+	// example: os_version
+	Property string `yaml:"property" json:"property" xml:"property"`
+	// The value before the change.
+	Old string `yaml:"old" json:"old" xml:"old"`
+	// The value after the change.
+	New string `yaml:"new" json:"new" xml:"new"`
+}
+
+// maxDeviceChanges is the number of changes that are kept per device.
+const maxDeviceChanges = 100
+
+// UpdateDeviceRecord records in the device registry that the device with the given IP address was identified.
+// The time the device was first seen and the last seen value of properties that could not be read
+// are kept if it is already in the registry. Credentials of the connection data are not recorded.
+func UpdateDeviceRecord(ctx context.Context, db Database, ip string, data device.Device, connectionData network.ConnectionData) error {
+	now := time.Now()
+	record, err := db.GetDeviceRecord(ctx, ip)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) {
+			return errors.Wrap(err, "failed to get device record")
+		}
+		record = DeviceRecord{
+			IPAddress: ip,
+			FirstSeen: now,
+		}
+	}
+	record.Changes = append(record.Changes, updateDevice(&record.Device, data, now)...)
+	if len(record.Changes) > maxDeviceChanges {
+		record.Changes = record.Changes[len(record.Changes)-maxDeviceChanges:]
+	}
+	record.LastSeen = now
+	record.ConnectionData = newDeviceRecordConnectionData(connectionData)
+	return db.SetDeviceRecord(ctx, ip, record)
+}
+
+// updateDevice updates the class and properties of a device record with a new identification
+// and returns the changes. Properties that could not be read in the new identification are not treated as changed,
+// the last seen value is kept instead unless the class changed.
+func updateDevice(record *device.Device, data device.Device, t time.Time) []DeviceChange {
+	var changes []DeviceChange
+	classChanged := record.Class != "" && record.Class != data.Class
+	if classChanged {
+		changes = append(changes, DeviceChange{
+			Time:     t,
+			Property: "class",
+			Old:      record.Class,
+			New:      data.Class,
+		})
+	}
+	record.Class = data.Class
+
+	update := func(property string, last **string, value *string) {
+		if value == nil || *value == "" {
+			if classChanged {
+				*last = nil
+			}
+			return
+		}
+		if *last != nil && **last != "" && **last != *value {
+			changes = append(changes, DeviceChange{
+				Time:     t,
+				Property: property,
+				Old:      **last,
+				New:      *value,
+			})
+		}
+		*last = value
+	}
+	update("vendor", &record.Properties.Vendor, data.Properties.Vendor)
+	update("model", &record.Properties.Model, data.Properties.Model)
+	update("model_series", &record.Properties.ModelSeries, data.Properties.ModelSeries)
+	update("serial_number", &record.Properties.SerialNumber, data.Properties.SerialNumber)
+	update("os_version", &record.Properties.OSVersion, data.Properties.OSVersion)
+	return changes
+}
+
 func initDB(ctx context.Context) error {
 	if viper.GetBool("db.no-cache") {
 		log.Ctx(ctx).Debug().Msg("initialized empty database")
@@ -53,6 +292,10 @@ func initDB(ctx context.Context) error {
 	db.ignoreFailure = viper.GetBool("db.ignore-db-failure")
 
 	drivername := viper.GetString("db.drivername")
+	encryptionKey := viper.GetString("db.encryption.key")
+	if encryptionKey == "" && (drivername == "mysql" || drivername == "redis") {
+		return errors.New("no database encryption key (db.encryption.key) set, it is required to cache connection data in a " + drivername + " database")
+	}
 
 	if drivername == "built-in" {
 		badgerDB := badgerDatabase{}
@@ -60,7 +303,8 @@ func initDB(ctx context.Context) error {
 		if err != nil {
 			return errors.Wrap(err, "failed to get username")
 		}
-		badgerDB.db, err = badger.Open(badger.DefaultOptions(filepath.Join(os.TempDir(), "thola-"+u.Username+"-cache")).WithLogger(nil))
+		dir := filepath.Join(os.TempDir(), "thola-"+u.Username+"-cache")
+		badgerDB.db, err = badger.Open(badger.DefaultOptions(dir).WithLogger(nil))
 		if err != nil {
 			return errors.Wrap(err, "error while setting up database")
 		}
@@ -70,6 +314,13 @@ func initDB(ctx context.Context) error {
 				return errors.Wrap(err, "failed to rebuild the db")
 			}
 		}
+		// the built-in database is only used by this host, so a generated key can be kept next to it
+		if encryptionKey == "" {
+			encryptionKey, err = readOrCreateEncryptionKey(dir + ".key")
+			if err != nil {
+				return err
+			}
+		}
 		db.Database = &badgerDB
 	} else if drivername == "mysql" {
 		checkIfTableExistsQuery := "SHOW TABLES LIKE 'cache';"
@@ -132,6 +383,11 @@ func initDB(ctx context.Context) error {
 	} else {
 		return errors.New("invalid drivername, only 'built-in', 'mysql' and 'redis' supported")
 	}
+	db.Database, err = newEncryptedDatabase(db.Database, encryptionKey)
+	if err != nil {
+		return err
+	}
+	db.Database = &metricsDatabase{db.Database}
 	log.Ctx(ctx).Debug().Msg("initialized " + drivername + " database")
 	return nil
 }
@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"github.com/dgraph-io/badger/v2"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUpdateDeviceRecord(t *testing.T) {
+	ctx := context.Background()
+	badgerDB, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if !assert.NoError(t, err) {
+		return
+	}
+	db := &badgerDatabase{db: badgerDB}
+	defer db.CloseConnection(ctx)
+
+	vendor := "Cisco"
+	level := "authPriv"
+	authKey := "authkey"
+	password := "password"
+	connectionData := network.ConnectionData{
+		SNMP: &network.SNMPConnectionData{
+			Communities: []string{"public"},
+			Versions:    []string{"3"},
+			Ports:       []int{161},
+			V3Data: network.SNMPv3ConnectionData{
+				Level:   &level,
+				AuthKey: &authKey,
+			},
+		},
+		SSH: &network.SSHConnectionData{
+			Ports:    []int{22},
+			Password: &password,
+		},
+	}
+	assert.NoError(t, UpdateDeviceRecord(ctx, db, "10.0.0.1", device.Device{Class: "generic"}, connectionData))
+	assert.NoError(t, UpdateDeviceRecord(ctx, db, "10.0.0.2", device.Device{Class: "generic"}, connectionData))
+	first, err := db.GetDeviceRecord(ctx, "10.0.0.1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NoError(t, UpdateDeviceRecord(ctx, db, "10.0.0.1", device.Device{Class: "ios", Properties: device.Properties{Vendor: &vendor}}, connectionData))
+	second, err := db.GetDeviceRecord(ctx, "10.0.0.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "10.0.0.1", second.IPAddress)
+		assert.Equal(t, "ios", second.Class)
+		assert.Equal(t, &vendor, second.Properties.Vendor)
+		assert.True(t, first.FirstSeen.Equal(second.FirstSeen))
+		assert.False(t, second.LastSeen.Before(first.LastSeen))
+		assert.Equal(t, DeviceRecordConnectionData{
+			SNMP: &DeviceRecordSNMPConnectionData{
+				Versions: []string{"3"},
+				Ports:    []int{161},
+				V3Level:  &level,
+			},
+			SSH: &DeviceRecordPortConnectionData{
+				Ports: []int{22},
+			},
+		}, second.ConnectionData, "credentials must not be recorded")
+	}
+
+	records, err := db.GetDeviceRecords(ctx)
+	if assert.NoError(t, err) && assert.Len(t, records, 2) {
+		assert.ElementsMatch(t, []string{"10.0.0.1", "10.0.0.2"}, []string{records[0].IPAddress, records[1].IPAddress})
+	}
+}
+
+func TestUpdateDeviceRecord_Changes(t *testing.T) {
+	ctx := context.Background()
+	badgerDB, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if !assert.NoError(t, err) {
+		return
+	}
+	db := &badgerDatabase{db: badgerDB}
+	defer db.CloseConnection(ctx)
+
+	oldVersion := "15.1"
+	newVersion := "15.2"
+	serialNumber := "FTX1234"
+	assert.NoError(t, UpdateDeviceRecord(ctx, db, "10.0.0.1", device.Device{Class: "ios", Properties: device.Properties{OSVersion: &oldVersion}}, network.ConnectionData{}))
+	// properties that could not be read are not treated as changed
+	assert.NoError(t, UpdateDeviceRecord(ctx, db, "10.0.0.1", device.Device{Class: "ios", Properties: device.Properties{SerialNumber: &serialNumber}}, network.ConnectionData{}))
+	assert.NoError(t, UpdateDeviceRecord(ctx, db, "10.0.0.1", device.Device{Class: "ios", Properties: device.Properties{OSVersion: &newVersion, SerialNumber: &serialNumber}}, network.ConnectionData{}))
+
+	record, err := db.GetDeviceRecord(ctx, "10.0.0.1")
+	if assert.NoError(t, err) && assert.Len(t, record.Changes, 1) {
+		assert.Equal(t, "os_version", record.Changes[0].Property)
+		assert.Equal(t, oldVersion, record.Changes[0].Old)
+		assert.Equal(t, newVersion, record.Changes[0].New)
+		assert.False(t, record.Changes[0].Time.Before(record.FirstSeen))
+	}
+}
+
+func TestEncryptedDatabase(t *testing.T) {
+	ctx := context.Background()
+	badgerDB, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if !assert.NoError(t, err) {
+		return
+	}
+	plainDB := &badgerDatabase{db: badgerDB}
+	defer plainDB.CloseConnection(ctx)
+	cacheExpiration = time.Hour
+
+	db, err := newEncryptedDatabase(plainDB, "secret")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	contextName := "context"
+	authKey := "authkey"
+	password := "password"
+	connectionData := network.ConnectionData{
+		SNMP: &network.SNMPConnectionData{
+			Communities: []string{"public"},
+			V3Data: network.SNMPv3ConnectionData{
+				ContextName: &contextName,
+				AuthKey:     &authKey,
+			},
+		},
+		SSH: &network.SSHConnectionData{
+			Password: &password,
+		},
+	}
+	assert.NoError(t, db.SetConnectionData(ctx, "10.0.0.1", connectionData))
+	assert.Equal(t, "public", connectionData.SNMP.Communities[0], "connection data of the caller must not be modified")
+
+	stored, err := plainDB.GetConnectionData(ctx, "10.0.0.1")
+	if assert.NoError(t, err) {
+		assert.NotEqual(t, "public", stored.SNMP.Communities[0])
+		assert.NotEqual(t, contextName, *stored.SNMP.V3Data.ContextName)
+		assert.NotEqual(t, authKey, *stored.SNMP.V3Data.AuthKey)
+		assert.NotEqual(t, password, *stored.SSH.Password)
+	}
+
+	res, err := db.GetConnectionData(ctx, "10.0.0.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, connectionData, res)
+	}
+
+	// connection data that was encrypted with another key cannot be used
+	otherKeyDB, err := newEncryptedDatabase(plainDB, "other")
+	if assert.NoError(t, err) {
+		_, err = otherKeyDB.GetConnectionData(ctx, "10.0.0.1")
+		assert.True(t, tholaerr.IsNotFoundError(err))
+	}
+
+	_, err = newEncryptedDatabase(plainDB, "")
+	assert.Error(t, err, "connection data must not be cached unencrypted")
+
+	// connection data that was cached before the encryption was enabled can still be read
+	assert.NoError(t, plainDB.SetConnectionData(ctx, "10.0.0.2", connectionData))
+	res, err = db.GetConnectionData(ctx, "10.0.0.2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, connectionData, res)
+	}
+}
+
+func TestReadOrCreateEncryptionKey(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "thola-cache.key")
+
+	key, err := readOrCreateEncryptionKey(file)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotEmpty(t, key)
+
+	info, err := os.Stat(file)
+	if assert.NoError(t, err) {
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	res, err := readOrCreateEncryptionKey(file)
+	if assert.NoError(t, err) {
+		assert.Equal(t, key, res, "the persisted key must be reused")
+	}
+}
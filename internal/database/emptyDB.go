@@ -26,6 +26,26 @@ func (d *emptyDatabase) GetConnectionData(_ context.Context, _ string) (network.
 	return network.ConnectionData{}, tholaerr.NewNotFoundError("no db available")
 }
 
+func (d *emptyDatabase) SetInterfaceCounters(_ context.Context, _ string, _ InterfaceCounterSnapshot) error {
+	return nil
+}
+
+func (d *emptyDatabase) GetInterfaceCounters(_ context.Context, _ string) (InterfaceCounterSnapshot, error) {
+	return InterfaceCounterSnapshot{}, tholaerr.NewNotFoundError("no db available")
+}
+
+func (d *emptyDatabase) SetDeviceRecord(_ context.Context, _ string, _ DeviceRecord) error {
+	return nil
+}
+
+func (d *emptyDatabase) GetDeviceRecord(_ context.Context, _ string) (DeviceRecord, error) {
+	return DeviceRecord{}, tholaerr.NewNotFoundError("no db available")
+}
+
+func (d *emptyDatabase) GetDeviceRecords(_ context.Context) ([]DeviceRecord, error) {
+	return nil, nil
+}
+
 func (d *emptyDatabase) CheckConnection(_ context.Context) error {
 	return nil
 }
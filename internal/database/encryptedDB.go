@@ -0,0 +1,174 @@
+package database
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"github.com/inexio/thola/internal/encryption"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+// encryptedValuePrefix marks encrypted values in cached connection data.
+const encryptedValuePrefix = "enc:v1:"
+
+// encryptionSalt is the salt of the key that is derived from the configured database encryption key.
+// It is fixed so that every thola instance sharing a database derives the same key.
+var encryptionSalt = []byte("thola-database")
+
+// errWrongEncryptionKey is returned if cached connection data was encrypted with another key.
+var errWrongEncryptionKey = errors.New("cached connection data was encrypted with another key")
+
+// encryptedDatabase encrypts the communities, SNMP v3 credentials, usernames and passwords
+// of connection data before they are stored in the underlying database.
+type encryptedDatabase struct {
+	Database
+
+	cipher *encryption.Cipher
+}
+
+func newEncryptedDatabase(database Database, key string) (*encryptedDatabase, error) {
+	if key == "" {
+		return nil, errors.New("no database encryption key set")
+	}
+	cipher, err := encryption.NewCipher(key, encryptionSalt)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher for database encryption")
+	}
+	return &encryptedDatabase{Database: database, cipher: cipher}, nil
+}
+
+// readOrCreateEncryptionKey reads the database encryption key from the file.
+// If the file does not exist, a random key is generated and written to it.
+func readOrCreateEncryptionKey(file string) (string, error) {
+	b, err := ioutil.ReadFile(file)
+	if err == nil {
+		key := strings.TrimSpace(string(b))
+		if key == "" {
+			return "", errors.Errorf("database encryption key file '%s' is empty", file)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return "", errors.Wrap(err, "failed to read database encryption key file")
+	}
+
+	b = make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return "", errors.Wrap(err, "failed to generate database encryption key")
+	}
+	key := base64.StdEncoding.EncodeToString(b)
+	if err := ioutil.WriteFile(file, []byte(key), 0600); err != nil {
+		return "", errors.Wrap(err, "failed to write database encryption key file")
+	}
+	return key, nil
+}
+
+func (d *encryptedDatabase) SetConnectionData(ctx context.Context, ip string, data network.ConnectionData) error {
+	data, err := transformConnectionData(data, d.encrypt)
+	if err != nil {
+		return errors.Wrap(err, "failed to encrypt connection data")
+	}
+	return d.Database.SetConnectionData(ctx, ip, data)
+}
+
+func (d *encryptedDatabase) GetConnectionData(ctx context.Context, ip string) (network.ConnectionData, error) {
+	data, err := d.Database.GetConnectionData(ctx, ip)
+	if err != nil {
+		return network.ConnectionData{}, err
+	}
+	data, err = transformConnectionData(data, d.decrypt)
+	if err != nil {
+		if err == errWrongEncryptionKey {
+			return network.ConnectionData{}, tholaerr.NewNotFoundError(err.Error())
+		}
+		return network.ConnectionData{}, errors.Wrap(err, "failed to decrypt connection data")
+	}
+	return data, nil
+}
+
+func (d *encryptedDatabase) encrypt(value string) (string, error) {
+	b, err := d.cipher.Encrypt([]byte(value))
+	if err != nil {
+		return "", err
+	}
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// decrypt decrypts the value if it is encrypted, unencrypted values from before the encryption was enabled
+// are returned as they are.
+func (d *encryptedDatabase) decrypt(value string) (string, error) {
+	if !strings.HasPrefix(value, encryptedValuePrefix) {
+		return value, nil
+	}
+	b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedValuePrefix))
+	if err != nil {
+		return "", errors.Wrap(err, "invalid encrypted value")
+	}
+	b, err = d.cipher.Decrypt(b)
+	if err != nil {
+		return "", errWrongEncryptionKey
+	}
+	return string(b), nil
+}
+
+// transformConnectionData returns a copy of the connection data with all credentials transformed by f.
+func transformConnectionData(data network.ConnectionData, f func(string) (string, error)) (network.ConnectionData, error) {
+	var err error
+	transform := func(value *string) *string {
+		if value == nil || err != nil {
+			return value
+		}
+		var res string
+		res, err = f(*value)
+		return &res
+	}
+
+	if data.SNMP != nil {
+		snmp := *data.SNMP
+		if snmp.Communities != nil {
+			snmp.Communities = make([]string, len(data.SNMP.Communities))
+			for i := range data.SNMP.Communities {
+				snmp.Communities[i] = *transform(&data.SNMP.Communities[i])
+			}
+		}
+		snmp.V3Data.ContextName = transform(snmp.V3Data.ContextName)
+		snmp.V3Data.User = transform(snmp.V3Data.User)
+		snmp.V3Data.AuthKey = transform(snmp.V3Data.AuthKey)
+		snmp.V3Data.PrivKey = transform(snmp.V3Data.PrivKey)
+		data.SNMP = &snmp
+	}
+	if data.HTTP != nil {
+		http := *data.HTTP
+		http.AuthUsername = transform(http.AuthUsername)
+		http.AuthPassword = transform(http.AuthPassword)
+		data.HTTP = &http
+	}
+	if data.SSH != nil {
+		ssh := *data.SSH
+		ssh.Username = transform(ssh.Username)
+		ssh.Password = transform(ssh.Password)
+		data.SSH = &ssh
+	}
+	if data.NETCONF != nil {
+		netconf := *data.NETCONF
+		netconf.Username = transform(netconf.Username)
+		netconf.Password = transform(netconf.Password)
+		data.NETCONF = &netconf
+	}
+	if data.GNMI != nil {
+		gnmi := *data.GNMI
+		gnmi.Username = transform(gnmi.Username)
+		gnmi.Password = transform(gnmi.Password)
+		data.GNMI = &gnmi
+	}
+	if err != nil {
+		return network.ConnectionData{}, err
+	}
+	return data, nil
+}
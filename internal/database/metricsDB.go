@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/metrics"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+)
+
+// metricsDatabase counts the cache hits and misses of the underlying database.
+type metricsDatabase struct {
+	Database
+}
+
+func (d *metricsDatabase) GetDeviceProperties(ctx context.Context, ip string) (device.Device, error) {
+	res, err := d.Database.GetDeviceProperties(ctx, ip)
+	observeCacheLookup("device_properties", err)
+	return res, err
+}
+
+func (d *metricsDatabase) GetConnectionData(ctx context.Context, ip string) (network.ConnectionData, error) {
+	res, err := d.Database.GetConnectionData(ctx, ip)
+	observeCacheLookup("connection_data", err)
+	return res, err
+}
+
+func (d *metricsDatabase) GetInterfaceCounters(ctx context.Context, ip string) (InterfaceCounterSnapshot, error) {
+	res, err := d.Database.GetInterfaceCounters(ctx, ip)
+	observeCacheLookup("interface_counters", err)
+	return res, err
+}
+
+func observeCacheLookup(cache string, err error) {
+	switch {
+	case err == nil:
+		metrics.IncCacheRequests(cache, metrics.CacheHit)
+	case tholaerr.IsNotFoundError(err):
+		metrics.IncCacheRequests(cache, metrics.CacheMiss)
+	default:
+		metrics.IncCacheRequests(cache, metrics.CacheError)
+	}
+}
@@ -90,6 +90,121 @@ func (d *redisDatabase) GetConnectionData(ctx context.Context, ip string) (netwo
 	return data, nil
 }
 
+func (d *redisDatabase) SetInterfaceCounters(ctx context.Context, ip string, data InterfaceCounterSnapshot) error {
+	conn, err := d.pool.GetContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection to redis database")
+	}
+	defer conn.Close()
+
+	JSONData, err := parser.ToJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshall interface counters")
+	}
+	_, err = conn.Do("SETEX", "InterfaceCounters-"+ip, cacheExpiration.Seconds(), JSONData)
+	if err != nil && !db.ignoreFailure {
+		return errors.Wrap(err, "failed to store interface counters")
+	}
+	return nil
+}
+
+func (d *redisDatabase) GetInterfaceCounters(ctx context.Context, ip string) (InterfaceCounterSnapshot, error) {
+	conn, err := d.pool.GetContext(ctx)
+	if err != nil {
+		return InterfaceCounterSnapshot{}, errors.Wrap(err, "failed to get connection to redis database")
+	}
+	defer conn.Close()
+
+	value, err := redis.String(conn.Do("GET", "InterfaceCounters-"+ip))
+	if err != nil {
+		return InterfaceCounterSnapshot{}, tholaerr.NewNotFoundError("cannot find cache entry")
+	}
+	data := InterfaceCounterSnapshot{}
+	err = json.Unmarshal([]byte(value), &data)
+	if err != nil {
+		return InterfaceCounterSnapshot{}, errors.Wrap(err, "failed to unmarshall interface counters")
+	}
+	return data, nil
+}
+
+func (d *redisDatabase) SetDeviceRecord(ctx context.Context, ip string, data DeviceRecord) error {
+	conn, err := d.pool.GetContext(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get connection to redis database")
+	}
+	defer conn.Close()
+
+	JSONData, err := parser.ToJSON(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshall device record")
+	}
+	_, err = conn.Do("SET", "DeviceRecord-"+ip, JSONData)
+	if err != nil && !db.ignoreFailure {
+		return errors.Wrap(err, "failed to store device record")
+	}
+	return nil
+}
+
+func (d *redisDatabase) GetDeviceRecord(ctx context.Context, ip string) (DeviceRecord, error) {
+	conn, err := d.pool.GetContext(ctx)
+	if err != nil {
+		return DeviceRecord{}, errors.Wrap(err, "failed to get connection to redis database")
+	}
+	defer conn.Close()
+
+	value, err := redis.String(conn.Do("GET", "DeviceRecord-"+ip))
+	if err != nil {
+		return DeviceRecord{}, tholaerr.NewNotFoundError("cannot find device record")
+	}
+	data := DeviceRecord{}
+	err = json.Unmarshal([]byte(value), &data)
+	if err != nil {
+		return DeviceRecord{}, errors.Wrap(err, "failed to unmarshall device record")
+	}
+	return data, nil
+}
+
+func (d *redisDatabase) GetDeviceRecords(ctx context.Context) ([]DeviceRecord, error) {
+	conn, err := d.pool.GetContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get connection to redis database")
+	}
+	defer conn.Close()
+
+	var keys []string
+	cursor := "0"
+	for {
+		res, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", "DeviceRecord-*"))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to scan device records")
+		}
+		var page []string
+		if _, err := redis.Scan(res, &cursor, &page); err != nil {
+			return nil, errors.Wrap(err, "failed to parse scan result")
+		}
+		keys = append(keys, page...)
+		if cursor == "0" {
+			break
+		}
+	}
+
+	var records []DeviceRecord
+	for _, key := range keys {
+		value, err := redis.String(conn.Do("GET", key))
+		if err != nil {
+			// the record was deleted after the scan
+			continue
+		}
+		data := DeviceRecord{}
+		err = json.Unmarshal([]byte(value), &data)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshall device record")
+		}
+		records = append(records, data)
+	}
+	return records, nil
+}
+
 func (d *redisDatabase) CheckConnection(ctx context.Context) error {
 	conn, err := d.pool.GetContext(ctx)
 	if err != nil {
@@ -78,6 +78,60 @@ func (d *sqlDatabase) GetConnectionData(ctx context.Context, ip string) (network
 	return connectionData, nil
 }
 
+func (d *sqlDatabase) SetInterfaceCounters(ctx context.Context, ip string, data InterfaceCounterSnapshot) error {
+	return d.insertReplaceQuery(ctx, data, ip, "InterfaceCounters")
+}
+
+func (d *sqlDatabase) GetInterfaceCounters(ctx context.Context, ip string) (InterfaceCounterSnapshot, error) {
+	var interfaceCounters InterfaceCounterSnapshot
+	err := d.getEntry(ctx, &interfaceCounters, ip, "InterfaceCounters")
+	if err != nil {
+		return InterfaceCounterSnapshot{}, err
+	}
+	return interfaceCounters, nil
+}
+
+func (d *sqlDatabase) SetDeviceRecord(ctx context.Context, ip string, data DeviceRecord) error {
+	return d.insertReplaceQuery(ctx, data, ip, "DeviceRecord")
+}
+
+func (d *sqlDatabase) GetDeviceRecord(ctx context.Context, ip string) (DeviceRecord, error) {
+	var results []string
+	err := d.db.SelectContext(ctx, &results, d.db.Rebind("SELECT data FROM cache WHERE ip=? AND datatype=?;"), ip, "DeviceRecord")
+	if err != nil {
+		return DeviceRecord{}, errors.Wrap(err, "db select failed")
+	}
+	if len(results) == 0 {
+		return DeviceRecord{}, tholaerr.NewNotFoundError("device record not found")
+	}
+
+	var record DeviceRecord
+	err = json.Unmarshal([]byte(results[0]), &record)
+	if err != nil {
+		return DeviceRecord{}, errors.Wrap(err, "failed to unmarshall device record")
+	}
+	return record, nil
+}
+
+func (d *sqlDatabase) GetDeviceRecords(ctx context.Context) ([]DeviceRecord, error) {
+	var results []string
+	err := d.db.SelectContext(ctx, &results, d.db.Rebind("SELECT data FROM cache WHERE datatype=?;"), "DeviceRecord")
+	if err != nil {
+		return nil, errors.Wrap(err, "db select failed")
+	}
+
+	var records []DeviceRecord
+	for _, res := range results {
+		var record DeviceRecord
+		err = json.Unmarshal([]byte(res), &record)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshall device record")
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
 func (d *sqlDatabase) CheckConnection(ctx context.Context) error {
 	return d.db.PingContext(ctx)
 }
@@ -133,6 +133,15 @@ type Interface struct {
 	VLAN               *VLANInformation             `yaml:"vlan,omitempty" json:"vlan,omitempty" xml:"vlan,omitempty" mapstructure:"vlan,omitempty"`
 }
 
+// PreferHCCounter returns the high capacity (64-bit) counter of an interface if it is available, otherwise the 32-bit counter.
+// A high capacity counter that is 0 is only used if there is no 32-bit counter, as some devices return 0 for unsupported counters.
+func PreferHCCounter(hcCounter *uint64, counter *uint64) *uint64 {
+	if hcCounter != nil && (*hcCounter != 0 || counter == nil) {
+		return hcCounter
+	}
+	return counter
+}
+
 //
 // Special interface types are defined here.
 //
@@ -547,6 +556,156 @@ func (h HighAvailabilityComponentState) GetInt() (int, error) {
 	return 0, fmt.Errorf("invalid high availability state '%s'", h)
 }
 
+// InventoryComponent
+//
+// InventoryComponent represents the physical inventory of a device.
+//
+// swagger:model
+type InventoryComponent struct {
+	Entities []InventoryComponentEntity `yaml:"entities" json:"entities" xml:"entities" mapstructure:"entities"`
+}
+
+// InventoryComponentEntity
+//
+// InventoryComponentEntity contains information per physical entity like chassis, modules, power supplies or transceivers.
+//
+// swagger:model
+type InventoryComponentEntity struct {
+	Index        *string `yaml:"index" json:"index" xml:"index" mapstructure:"index"`
+	Description  *string `yaml:"description" json:"description" xml:"description" mapstructure:"description"`
+	VendorType   *string `yaml:"vendor_type" json:"vendor_type" xml:"vendor_type" mapstructure:"vendor_type"`
+	ContainedIn  *string `yaml:"contained_in" json:"contained_in" xml:"contained_in" mapstructure:"contained_in"`
+	Class        *string `yaml:"class" json:"class" xml:"class" mapstructure:"class"`
+	ParentRelPos *int    `yaml:"parent_rel_pos" json:"parent_rel_pos" xml:"parent_rel_pos" mapstructure:"parent_rel_pos"`
+	Name         *string `yaml:"name" json:"name" xml:"name" mapstructure:"name"`
+	HardwareRev  *string `yaml:"hardware_rev" json:"hardware_rev" xml:"hardware_rev" mapstructure:"hardware_rev"`
+	FirmwareRev  *string `yaml:"firmware_rev" json:"firmware_rev" xml:"firmware_rev" mapstructure:"firmware_rev"`
+	SoftwareRev  *string `yaml:"software_rev" json:"software_rev" xml:"software_rev" mapstructure:"software_rev"`
+	SerialNumber *string `yaml:"serial_number" json:"serial_number" xml:"serial_number" mapstructure:"serial_number"`
+	Manufacturer *string `yaml:"manufacturer" json:"manufacturer" xml:"manufacturer" mapstructure:"manufacturer"`
+	Model        *string `yaml:"model" json:"model" xml:"model" mapstructure:"model"`
+	Alias        *string `yaml:"alias" json:"alias" xml:"alias" mapstructure:"alias"`
+	AssetID      *string `yaml:"asset_id" json:"asset_id" xml:"asset_id" mapstructure:"asset_id"`
+	IsFRU        *bool   `yaml:"is_fru" json:"is_fru" xml:"is_fru" mapstructure:"is_fru"`
+}
+
+// NeighborsComponent
+//
+// NeighborsComponent represents the neighbors of a device which were discovered via LLDP or CDP.
+//
+// swagger:model
+type NeighborsComponent struct {
+	Neighbors []NeighborsComponentNeighbor `yaml:"neighbors" json:"neighbors" xml:"neighbors" mapstructure:"neighbors"`
+}
+
+// NeighborsComponentNeighbor
+//
+// NeighborsComponentNeighbor contains information per neighbor.
+//
+// swagger:model
+type NeighborsComponentNeighbor struct {
+	LocalPort               *string `yaml:"local_port" json:"local_port" xml:"local_port" mapstructure:"local_port"`
+	RemoteChassisID         *string `yaml:"remote_chassis_id" json:"remote_chassis_id" xml:"remote_chassis_id" mapstructure:"remote_chassis_id"`
+	RemotePortID            *string `yaml:"remote_port_id" json:"remote_port_id" xml:"remote_port_id" mapstructure:"remote_port_id"`
+	RemotePortDescription   *string `yaml:"remote_port_description" json:"remote_port_description" xml:"remote_port_description" mapstructure:"remote_port_description"`
+	RemoteSystemName        *string `yaml:"remote_system_name" json:"remote_system_name" xml:"remote_system_name" mapstructure:"remote_system_name"`
+	RemoteManagementAddress *string `yaml:"remote_management_address" json:"remote_management_address" xml:"remote_management_address" mapstructure:"remote_management_address"`
+}
+
+// BGPComponent
+//
+// BGPComponent represents the BGP sessions of a device.
+//
+// swagger:model
+type BGPComponent struct {
+	Peers []BGPComponentPeer `yaml:"peers" json:"peers" xml:"peers" mapstructure:"peers"`
+}
+
+// BGPComponentPeer
+//
+// BGPComponentPeer contains information per BGP peer.
+//
+// swagger:model
+type BGPComponentPeer struct {
+	LocalAddress     *string `yaml:"local_address" json:"local_address" xml:"local_address" mapstructure:"local_address"`
+	LocalAS          *uint64 `yaml:"local_as" json:"local_as" xml:"local_as" mapstructure:"local_as"`
+	PeerAddress      *string `yaml:"peer_address" json:"peer_address" xml:"peer_address" mapstructure:"peer_address"`
+	PeerAS           *uint64 `yaml:"peer_as" json:"peer_as" xml:"peer_as" mapstructure:"peer_as"`
+	PeerIdentifier   *string `yaml:"peer_identifier" json:"peer_identifier" xml:"peer_identifier" mapstructure:"peer_identifier"`
+	State            *string `yaml:"state" json:"state" xml:"state" mapstructure:"state"`
+	AdminStatus      *string `yaml:"admin_status" json:"admin_status" xml:"admin_status" mapstructure:"admin_status"`
+	EstablishedTime  *uint64 `yaml:"established_time" json:"established_time" xml:"established_time" mapstructure:"established_time"`
+	PrefixesReceived *uint64 `yaml:"prefixes_received" json:"prefixes_received" xml:"prefixes_received" mapstructure:"prefixes_received"`
+	PrefixesAccepted *uint64 `yaml:"prefixes_accepted" json:"prefixes_accepted" xml:"prefixes_accepted" mapstructure:"prefixes_accepted"`
+}
+
+// All BGP peer states and admin states with the corresponding label
+const (
+	BGPPeerStateIdle        = "idle"
+	BGPPeerStateConnect     = "connect"
+	BGPPeerStateActive      = "active"
+	BGPPeerStateOpenSent    = "opensent"
+	BGPPeerStateOpenConfirm = "openconfirm"
+	BGPPeerStateEstablished = "established"
+
+	BGPPeerAdminStatusStop  = "stop"
+	BGPPeerAdminStatusStart = "start"
+)
+
+// RoutingProtocolsComponent
+//
+// RoutingProtocolsComponent represents the OSPF neighbors and IS-IS adjacencies of a device.
+//
+// swagger:model
+type RoutingProtocolsComponent struct {
+	OSPFNeighbors   []RoutingProtocolsComponentOSPFNeighbor  `yaml:"ospf_neighbors" json:"ospf_neighbors" xml:"ospf_neighbors" mapstructure:"ospf_neighbors"`
+	ISISAdjacencies []RoutingProtocolsComponentISISAdjacency `yaml:"isis_adjacencies" json:"isis_adjacencies" xml:"isis_adjacencies" mapstructure:"isis_adjacencies"`
+}
+
+// RoutingProtocolsComponentOSPFNeighbor
+//
+// RoutingProtocolsComponentOSPFNeighbor contains information per OSPF neighbor.
+//
+// swagger:model
+type RoutingProtocolsComponentOSPFNeighbor struct {
+	NeighborAddress *string `yaml:"neighbor_address" json:"neighbor_address" xml:"neighbor_address" mapstructure:"neighbor_address"`
+	RouterID        *string `yaml:"router_id" json:"router_id" xml:"router_id" mapstructure:"router_id"`
+	Priority        *uint64 `yaml:"priority" json:"priority" xml:"priority" mapstructure:"priority"`
+	State           *string `yaml:"state" json:"state" xml:"state" mapstructure:"state"`
+	Events          *uint64 `yaml:"events" json:"events" xml:"events" mapstructure:"events"`
+}
+
+// RoutingProtocolsComponentISISAdjacency
+//
+// RoutingProtocolsComponentISISAdjacency contains information per IS-IS adjacency.
+//
+// swagger:model
+type RoutingProtocolsComponentISISAdjacency struct {
+	CircuitIndex     *uint64 `yaml:"circuit_index" json:"circuit_index" xml:"circuit_index" mapstructure:"circuit_index"`
+	NeighborSystemID *string `yaml:"neighbor_system_id" json:"neighbor_system_id" xml:"neighbor_system_id" mapstructure:"neighbor_system_id"`
+	State            *string `yaml:"state" json:"state" xml:"state" mapstructure:"state"`
+	Level            *string `yaml:"level" json:"level" xml:"level" mapstructure:"level"`
+	HoldTimer        *uint64 `yaml:"hold_timer" json:"hold_timer" xml:"hold_timer" mapstructure:"hold_timer"`
+	Priority         *uint64 `yaml:"priority" json:"priority" xml:"priority" mapstructure:"priority"`
+}
+
+// All OSPF neighbor states and IS-IS adjacency states with the corresponding label
+const (
+	OSPFNeighborStateDown          = "down"
+	OSPFNeighborStateAttempt       = "attempt"
+	OSPFNeighborStateInit          = "init"
+	OSPFNeighborStateTwoWay        = "twoWay"
+	OSPFNeighborStateExchangeStart = "exchangeStart"
+	OSPFNeighborStateExchange      = "exchange"
+	OSPFNeighborStateLoading       = "loading"
+	OSPFNeighborStateFull          = "full"
+
+	ISISAdjacencyStateDown         = "down"
+	ISISAdjacencyStateInitializing = "initializing"
+	ISISAdjacencyStateUp           = "up"
+	ISISAdjacencyStateFailed       = "failed"
+)
+
 // Rate
 //
 // Rate encapsulates values which refer to a time span.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/explain"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/utility"
@@ -75,6 +76,19 @@ func Interface2Condition(i interface{}, task RelatedTask) (Condition, error) {
 		}
 		return &condition, nil
 	}
+	//SSH
+	if stringType == "SSHCommandOutput" {
+		var condition sshCondition
+		err := mapstructure.Decode(i, &condition)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode condition")
+		}
+		err = condition.validate()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid ssh condition")
+		}
+		return &condition, nil
+	}
 
 	if stringType == "Vendor" {
 		if task <= PropertyVendor {
@@ -132,7 +146,10 @@ type multipleConditions struct {
 	Conditions      []Condition
 }
 
-func (c *multipleConditions) Check(ctx context.Context) (bool, error) {
+func (c *multipleConditions) Check(ctx context.Context) (matched bool, err error) {
+	ctx, explanation := explain.StartCondition(ctx, explain.Condition{LogicalOperator: string(c.LogicalOperator)})
+	defer func() { explanation.Finish(matched, err) }()
+
 	log.Ctx(ctx).Debug().Msg("starting with matching condition set (OR)")
 	for _, condition := range c.Conditions {
 		match, err := condition.Check(ctx)
@@ -172,7 +189,10 @@ type snmpCondition struct {
 	network.SNMPGetConfiguration `mapstructure:",squash"`
 }
 
-func (s *snmpCondition) Check(ctx context.Context) (bool, error) {
+func (s *snmpCondition) Check(ctx context.Context) (matched bool, err error) {
+	ctx, explanation := explain.StartCondition(ctx, explain.Condition{Type: s.Type, MatchMode: string(s.MatchMode), OID: string(s.OID), Values: s.Value})
+	defer func() { explanation.Finish(matched, err) }()
+
 	if s.Type == "snmpget" {
 		logger := log.Ctx(ctx).With().Str("condition", "snmp").Str("condition_type", s.Type).Str("match_mode", string(s.MatchMode)).Str("oid", string(s.OID)).Logger()
 		ctx = logger.WithContext(ctx)
@@ -184,16 +204,17 @@ func (s *snmpCondition) Check(ctx context.Context) (bool, error) {
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.SNMP == nil {
 		log.Ctx(ctx).Debug().Bool("condition_matched", false).Msg("no snmp connection data available")
+		explanation.SetMessage("no snmp connection data available")
 		return false, nil
 	}
 	var val string
-	var err error
 
 	if s.Type == "SysDescription" {
 		val, err = con.SNMP.GetSysDescription(ctx)
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
 				log.Ctx(ctx).Debug().Err(err).Msg("sysDescription is not available for snmp agent")
+				explanation.SetMessage("sysDescription is not available")
 				return false, nil
 			}
 			return false, errors.Wrap(err, "failed to get SysDescription")
@@ -203,6 +224,7 @@ func (s *snmpCondition) Check(ctx context.Context) (bool, error) {
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
 				log.Ctx(ctx).Debug().Err(err).Msg("sysObjectID is not available for snmp agent")
+				explanation.SetMessage("sysObjectID is not available")
 				return false, nil
 			}
 			return false, errors.Wrap(err, "failed to get SysObjectID")
@@ -212,6 +234,7 @@ func (s *snmpCondition) Check(ctx context.Context) (bool, error) {
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
 				log.Ctx(ctx).Debug().Err(err).Msg("snmpget returned no result")
+				explanation.SetMessage("snmpget returned no result")
 				return false, nil
 			}
 			log.Ctx(ctx).Error().Err(err).Msg("error during snmpget")
@@ -220,6 +243,7 @@ func (s *snmpCondition) Check(ctx context.Context) (bool, error) {
 		value, err := response[0].GetValueBySNMPGetConfiguration(s.SNMPGetConfiguration)
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) {
+				explanation.SetMessage("snmpget returned no value")
 				return false, nil
 			}
 			return false, err
@@ -272,30 +296,32 @@ type httpCondition struct {
 	URI             string
 }
 
-func (s *httpCondition) Check(ctx context.Context) (bool, error) {
+func (s *httpCondition) Check(ctx context.Context) (matched bool, err error) {
+	ctx, explanation := explain.StartCondition(ctx, explain.Condition{Type: s.Type, MatchMode: string(s.MatchMode), URI: s.URI, Values: s.Value})
+	defer func() { explanation.Finish(matched, err) }()
+
 	logger := log.Ctx(ctx).With().Str("condition", "http").Str("condition_type", s.Type).Str("match_mode", string(s.MatchMode)).Str("uri", s.URI).Logger()
 	ctx = logger.WithContext(ctx)
 
 	con, ok := network.DeviceConnectionFromContext(ctx)
 	if !ok || con.HTTP == nil {
 		log.Ctx(ctx).Debug().Bool("condition_matched", false).Msg("no http connection data available")
+		explanation.SetMessage("no http connection data available")
 		return false, nil //TODO: throw error and catch it or just return false?
 	}
 	var value string
 	if s.Type == "HttpGetBody" {
 		for _, useHTTPS := range []bool{true, false} {
-			con.HTTP.HTTPClient.UseHTTPS(useHTTPS)
 			for _, port := range utility.IfThenElse(useHTTPS, con.HTTP.ConnectionData.HTTPSPorts, con.HTTP.ConnectionData.HTTPPorts).([]int) {
-				con.HTTP.HTTPClient.SetPort(port)
-				r, err := con.HTTP.HTTPClient.Request(ctx, "GET", s.URI, "", nil, nil)
+				r, err := con.HTTP.HTTPClient.RequestTo(ctx, useHTTPS, port, "GET", s.URI, "", nil, nil)
 				if err != nil {
-					log.Ctx(ctx).Debug().Err(err).Str("protocol", con.HTTP.HTTPClient.GetProtocolString()).Int("port", port).Msg("http(s) request returned error")
+					log.Ctx(ctx).Debug().Err(err).Str("protocol", utility.IfThenElse(useHTTPS, "https", "http").(string)).Int("port", port).Msg("http(s) request returned error")
 					if tholaerr.IsNetworkError(err) {
 						continue
 					}
 					return false, errors.Wrap(err, "non-network error during http(s) request!")
 				}
-				log.Ctx(ctx).Debug().Str("protocol", con.HTTP.HTTPClient.GetProtocolString()).Int("port", port).Msg("http(s) request was successful")
+				log.Ctx(ctx).Debug().Str("protocol", utility.IfThenElse(useHTTPS, "https", "http").(string)).Int("port", port).Msg("http(s) request was successful")
 				value = string(r.Body())
 
 				matched, err := MatchStrings(ctx, value, s.MatchMode, s.Value...)
@@ -334,12 +360,64 @@ func (s *httpCondition) validate() error {
 	return nil
 }
 
+// sshCondition is a condition based on the output of a command that is run via ssh.
+type sshCondition struct {
+	singleCondition `mapstructure:",squash"`
+	Command         string
+}
+
+func (s *sshCondition) Check(ctx context.Context) (matched bool, err error) {
+	ctx, explanation := explain.StartCondition(ctx, explain.Condition{Type: s.Type, MatchMode: string(s.MatchMode), Command: s.Command, Values: s.Value})
+	defer func() { explanation.Finish(matched, err) }()
+
+	logger := log.Ctx(ctx).With().Str("condition", "ssh").Str("condition_type", s.Type).Str("match_mode", string(s.MatchMode)).Str("command", s.Command).Logger()
+	ctx = logger.WithContext(ctx)
+
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SSH == nil || con.SSH.SSHClient == nil {
+		log.Ctx(ctx).Debug().Bool("condition_matched", false).Msg("no ssh connection data available")
+		explanation.SetMessage("no ssh connection data available")
+		return false, nil
+	}
+
+	output, err := con.SSH.SSHClient.Run(ctx, s.Command)
+	if err != nil {
+		// devices that do not know the command are not matched
+		log.Ctx(ctx).Debug().Err(err).Bool("condition_matched", false).Msg("ssh command failed")
+		explanation.SetMessage("ssh command failed: " + err.Error())
+		return false, nil
+	}
+
+	return MatchStrings(ctx, output, s.MatchMode, s.Value...)
+}
+
+func (s *sshCondition) ContainsUniqueRequest() bool {
+	return true
+}
+
+func (s *sshCondition) validate() error {
+	err := s.MatchMode.Validate()
+	if err != nil {
+		return errors.Wrap(err, "invalid matchmode")
+	}
+	if s.Command == "" {
+		return errors.New("no command defined")
+	}
+	if len(s.Value) == 0 {
+		return errors.New("no values defined")
+	}
+	return nil
+}
+
 // vendorCondition is a condition based on a vendor.
 type vendorCondition struct {
 	singleCondition `mapstructure:",squash"`
 }
 
-func (m *vendorCondition) Check(ctx context.Context) (bool, error) {
+func (m *vendorCondition) Check(ctx context.Context) (matched bool, err error) {
+	ctx, explanation := explain.StartCondition(ctx, explain.Condition{Type: m.Type, MatchMode: string(m.MatchMode), Values: m.Value})
+	defer func() { explanation.Finish(matched, err) }()
+
 	properties, ok := device.DevicePropertiesFromContext(ctx)
 	if !ok {
 		return false, errors.New("no properties found in context")
@@ -359,7 +437,10 @@ type modelCondition struct {
 	singleCondition `mapstructure:",squash"`
 }
 
-func (m *modelCondition) Check(ctx context.Context) (bool, error) {
+func (m *modelCondition) Check(ctx context.Context) (matched bool, err error) {
+	ctx, explanation := explain.StartCondition(ctx, explain.Condition{Type: m.Type, MatchMode: string(m.MatchMode), Values: m.Value})
+	defer func() { explanation.Finish(matched, err) }()
+
 	properties, ok := device.DevicePropertiesFromContext(ctx)
 	if !ok {
 		return false, errors.New("no properties found in context")
@@ -379,7 +460,10 @@ type modelSeriesCondition struct {
 	singleCondition `mapstructure:",squash"`
 }
 
-func (m *modelSeriesCondition) Check(ctx context.Context) (bool, error) {
+func (m *modelSeriesCondition) Check(ctx context.Context) (matched bool, err error) {
+	ctx, explanation := explain.StartCondition(ctx, explain.Condition{Type: m.Type, MatchMode: string(m.MatchMode), Values: m.Value})
+	defer func() { explanation.Finish(matched, err) }()
+
 	properties, ok := device.DevicePropertiesFromContext(ctx)
 	if !ok {
 		return false, errors.New("no properties found in context")
@@ -466,6 +550,7 @@ func (m *MatchMode) Validate() error {
 }
 
 func MatchStrings(ctx context.Context, str string, mode MatchMode, matches ...string) (bool, error) {
+	explain.ConditionFromContext(ctx).SetValue(str)
 	switch mode {
 	case "contains":
 		for _, match := range matches {
@@ -19,7 +19,7 @@ import (
 	"io/fs"
 	"io/ioutil"
 	"os"
-	"path/filepath"
+	"path"
 	"strings"
 )
 
@@ -58,6 +58,10 @@ type deviceClassComponents struct {
 	disk             *deviceClassComponentsDisk
 	hardwareHealth   *deviceClassComponentsHardwareHealth
 	highAvailability *deviceClassComponentsHighAvailability
+	inventory        *deviceClassComponentsInventory
+	neighbors        *deviceClassComponentsNeighbors
+	bgp              *deviceClassComponentsBGP
+	routingProtocols *deviceClassComponentsRoutingProtocols
 }
 
 // deviceClassComponentsUPS represents the ups components part of a device class.
@@ -109,6 +113,29 @@ type deviceClassComponentsDisk struct {
 	properties groupproperty.Reader
 }
 
+// deviceClassComponentsInventory represents the inventory component part of a device class.
+type deviceClassComponentsInventory struct {
+	properties groupproperty.Reader
+}
+
+// deviceClassComponentsNeighbors represents the neighbors component part of a device class.
+type deviceClassComponentsNeighbors struct {
+	properties          groupproperty.Reader
+	localPorts          groupproperty.Reader
+	managementAddresses groupproperty.Reader
+}
+
+// deviceClassComponentsBGP represents the bgp component part of a device class.
+type deviceClassComponentsBGP struct {
+	properties groupproperty.Reader
+}
+
+// deviceClassComponentsRoutingProtocols represents the routing protocols component part of a device class.
+type deviceClassComponentsRoutingProtocols struct {
+	ospfNeighbors   groupproperty.Reader
+	isisAdjacencies groupproperty.Reader
+}
+
 // deviceClassComponentsHardwareHealth represents the hardware health part of a device class.
 type deviceClassComponentsHardwareHealth struct {
 	environmentMonitorState property.Reader
@@ -159,15 +186,19 @@ type yamlDeviceClassIdentify struct {
 
 // yamlDeviceClassComponents represents the components part of a yaml device class.
 type yamlDeviceClassComponents struct {
-	Interfaces       *yamlComponentsInterfaces               `yaml:"interfaces"`
-	UPS              *yamlComponentsUPSProperties            `yaml:"ups"`
-	CPU              *yamlComponentsCPUProperties            `yaml:"cpu"`
-	Memory           *yamlComponentsMemoryProperties         `yaml:"memory"`
-	SBC              *yamlComponentsSBCProperties            `yaml:"sbc"`
-	Server           *yamlComponentsServerProperties         `yaml:"server"`
-	Disk             *yamlComponentsDiskProperties           `yaml:"disk"`
-	HardwareHealth   *yamlComponentsHardwareHealthProperties `yaml:"hardware_health"`
-	HighAvailability *yamlComponentsHighAvailability         `yaml:"high_availability"`
+	Interfaces       *yamlComponentsInterfaces                 `yaml:"interfaces"`
+	UPS              *yamlComponentsUPSProperties              `yaml:"ups"`
+	CPU              *yamlComponentsCPUProperties              `yaml:"cpu"`
+	Memory           *yamlComponentsMemoryProperties           `yaml:"memory"`
+	SBC              *yamlComponentsSBCProperties              `yaml:"sbc"`
+	Server           *yamlComponentsServerProperties           `yaml:"server"`
+	Disk             *yamlComponentsDiskProperties             `yaml:"disk"`
+	HardwareHealth   *yamlComponentsHardwareHealthProperties   `yaml:"hardware_health"`
+	HighAvailability *yamlComponentsHighAvailability           `yaml:"high_availability"`
+	Inventory        *yamlComponentsInventoryProperties        `yaml:"inventory"`
+	Neighbors        *yamlComponentsNeighborsProperties        `yaml:"neighbors"`
+	BGP              *yamlComponentsBGPProperties              `yaml:"bgp"`
+	RoutingProtocols *yamlComponentsRoutingProtocolsProperties `yaml:"routing_protocols"`
 }
 
 // yamlDeviceClassConfig represents the config part of a yaml device class.
@@ -238,6 +269,29 @@ type yamlComponentsDiskProperties struct {
 	Properties interface{} `yaml:"properties"`
 }
 
+// yamlComponentsInventoryProperties represents the specific properties of inventory components of a yaml device class.
+type yamlComponentsInventoryProperties struct {
+	Properties interface{} `yaml:"properties"`
+}
+
+// yamlComponentsNeighborsProperties represents the specific properties of neighbors components of a yaml device class.
+type yamlComponentsNeighborsProperties struct {
+	Properties          interface{} `yaml:"properties"`
+	LocalPorts          interface{} `yaml:"local_ports"`
+	ManagementAddresses interface{} `yaml:"management_addresses"`
+}
+
+// yamlComponentsBGPProperties represents the specific properties of bgp components of a yaml device class.
+type yamlComponentsBGPProperties struct {
+	Properties interface{} `yaml:"properties"`
+}
+
+// yamlComponentsRoutingProtocolsProperties represents the specific properties of routing protocols components of a yaml device class.
+type yamlComponentsRoutingProtocolsProperties struct {
+	OSPFNeighbors   interface{} `yaml:"ospf_neighbors"`
+	ISISAdjacencies interface{} `yaml:"isis_adjacencies"`
+}
+
 // yamlComponentsHardwareHealthProperties represents the specific properties of hardware health components of a yaml device class.
 type yamlComponentsHardwareHealthProperties struct {
 	EnvironmentMonitorState []interface{} `yaml:"environment_monitor_state"`
@@ -264,20 +318,22 @@ type yamlComponentsInterfaces struct {
 }
 
 // GetHierarchy returns the hierarchy of device classes merged with their corresponding code communicator.
+// The device classes are read from config.Files, so device classes of the overlay directory are included.
 func GetHierarchy() (hierarchy.Hierarchy, error) {
+	fsys := config.Files()
 	genericDeviceClassDir := "deviceclass"
-	genericDeviceClassFile, err := config.FileSystem.Open(filepath.Join(genericDeviceClassDir, "generic.yaml"))
+	genericDeviceClassFile, err := fsys.Open(path.Join(genericDeviceClassDir, "generic.yaml"))
 	if err != nil {
 		return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to open generic device class file")
 	}
-	hier, err := yamlFile2Hierarchy(genericDeviceClassFile, genericDeviceClassDir, nil, nil)
+	hier, err := yamlFile2Hierarchy(fsys, genericDeviceClassFile, genericDeviceClassDir, nil, nil)
 	if err != nil {
 		return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to read in generic device class")
 	}
 	return hier, nil
 }
 
-func yamlFile2Hierarchy(file fs.File, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (hierarchy.Hierarchy, error) {
+func yamlFile2Hierarchy(fsys fs.FS, file fs.File, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (hierarchy.Hierarchy, error) {
 	//get file info
 	fileInfo, err := file.Stat()
 	if err != nil {
@@ -314,14 +370,14 @@ func yamlFile2Hierarchy(file fs.File, directory string, parentDeviceClass *devic
 	}
 
 	// check for sub device classes
-	subDirPath := filepath.Join(directory, devClass.name)
-	subDir, err := config.FileSystem.ReadDir(subDirPath)
+	subDirPath := path.Join(directory, devClass.name)
+	subDir, err := fs.ReadDir(fsys, subDirPath)
 	if err != nil {
 		if !os.IsNotExist(err) {
 			return hierarchy.Hierarchy{}, errors.Wrap(err, "an unexpected error occurred while trying to open sub device class directory")
 		}
 	} else {
-		subHierarchies, err := readDeviceClassDirectory(subDir, subDirPath, &devClass, networkDeviceCommunicator)
+		subHierarchies, err := readDeviceClassDirectory(fsys, subDir, subDirPath, &devClass, networkDeviceCommunicator)
 		if err != nil {
 			return hierarchy.Hierarchy{}, errors.Wrap(err, "failed to read sub device classes")
 		}
@@ -340,7 +396,7 @@ func createNetworkDeviceCommunicator(devClass *deviceClass, parentCommunicator c
 	return communicator.CreateNetworkDeviceCommunicator(&(deviceClassCommunicator{devClass}), codeCommunicator), nil
 }
 
-func readDeviceClassDirectory(dir []fs.DirEntry, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (map[string]hierarchy.Hierarchy, error) {
+func readDeviceClassDirectory(fsys fs.FS, dir []fs.DirEntry, directory string, parentDeviceClass *deviceClass, parentCommunicator communicator.Communicator) (map[string]hierarchy.Hierarchy, error) {
 	deviceClasses := make(map[string]hierarchy.Hierarchy)
 	for _, dirEntry := range dir {
 		// directories will be ignored here, sub device classes dirs will be called when
@@ -357,12 +413,12 @@ func readDeviceClassDirectory(dir []fs.DirEntry, directory string, parentDeviceC
 			// all non directory files need to be yaml file and end with ".yaml"
 			return nil, errors.New("only yaml config files are allowed in device class directories")
 		}
-		fullPathToFile := filepath.Join(directory, fileInfo.Name())
-		file, err := config.FileSystem.Open(fullPathToFile)
+		fullPathToFile := path.Join(directory, fileInfo.Name())
+		file, err := fsys.Open(fullPathToFile)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to open file "+fullPathToFile)
 		}
-		hier, err := yamlFile2Hierarchy(file, directory, parentDeviceClass, parentCommunicator)
+		hier, err := yamlFile2Hierarchy(fsys, file, directory, parentDeviceClass, parentCommunicator)
 		if err != nil {
 			return nil, errors.Wrapf(err, "an error occurred while trying to read in yaml config file %s", fileInfo.Name())
 		}
@@ -538,6 +594,38 @@ func (y *yamlDeviceClassComponents) convert(parentComponents deviceClassComponen
 		components.highAvailability = &ha
 	}
 
+	if y.Inventory != nil {
+		inventory, err := y.Inventory.convert(parentComponents.inventory)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml inventory properties")
+		}
+		components.inventory = &inventory
+	}
+
+	if y.Neighbors != nil {
+		neighbors, err := y.Neighbors.convert(parentComponents.neighbors)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml neighbors properties")
+		}
+		components.neighbors = &neighbors
+	}
+
+	if y.BGP != nil {
+		bgp, err := y.BGP.convert(parentComponents.bgp)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml bgp properties")
+		}
+		components.bgp = &bgp
+	}
+
+	if y.RoutingProtocols != nil {
+		routingProtocols, err := y.RoutingProtocols.convert(parentComponents.routingProtocols)
+		if err != nil {
+			return deviceClassComponents{}, errors.Wrap(err, "failed to read yaml routing protocols properties")
+		}
+		components.routingProtocols = &routingProtocols
+	}
+
 	return components, nil
 }
 
@@ -799,6 +887,92 @@ func (y *yamlComponentsDiskProperties) convert(parentDisk *deviceClassComponents
 	return prop, nil
 }
 
+func (y *yamlComponentsInventoryProperties) convert(parentInventory *deviceClassComponentsInventory) (deviceClassComponentsInventory, error) {
+	var prop deviceClassComponentsInventory
+	var err error
+
+	if parentInventory != nil {
+		prop = *parentInventory
+	}
+
+	if y.Properties != nil {
+		prop.properties, err = groupproperty.Interface2Reader(y.Properties, prop.properties)
+		if err != nil {
+			return deviceClassComponentsInventory{}, errors.Wrap(err, "failed to convert entities property to group property reader")
+		}
+	}
+	return prop, nil
+}
+
+func (y *yamlComponentsNeighborsProperties) convert(parentNeighbors *deviceClassComponentsNeighbors) (deviceClassComponentsNeighbors, error) {
+	var prop deviceClassComponentsNeighbors
+	var err error
+
+	if parentNeighbors != nil {
+		prop = *parentNeighbors
+	}
+
+	if y.Properties != nil {
+		prop.properties, err = groupproperty.Interface2Reader(y.Properties, prop.properties)
+		if err != nil {
+			return deviceClassComponentsNeighbors{}, errors.Wrap(err, "failed to convert neighbors property to group property reader")
+		}
+	}
+	if y.LocalPorts != nil {
+		prop.localPorts, err = groupproperty.Interface2Reader(y.LocalPorts, prop.localPorts)
+		if err != nil {
+			return deviceClassComponentsNeighbors{}, errors.Wrap(err, "failed to convert local ports property to group property reader")
+		}
+	}
+	if y.ManagementAddresses != nil {
+		prop.managementAddresses, err = groupproperty.Interface2Reader(y.ManagementAddresses, prop.managementAddresses)
+		if err != nil {
+			return deviceClassComponentsNeighbors{}, errors.Wrap(err, "failed to convert management addresses property to group property reader")
+		}
+	}
+	return prop, nil
+}
+
+func (y *yamlComponentsBGPProperties) convert(parentBGP *deviceClassComponentsBGP) (deviceClassComponentsBGP, error) {
+	var prop deviceClassComponentsBGP
+	var err error
+
+	if parentBGP != nil {
+		prop = *parentBGP
+	}
+
+	if y.Properties != nil {
+		prop.properties, err = groupproperty.Interface2Reader(y.Properties, prop.properties)
+		if err != nil {
+			return deviceClassComponentsBGP{}, errors.Wrap(err, "failed to convert peers property to group property reader")
+		}
+	}
+	return prop, nil
+}
+
+func (y *yamlComponentsRoutingProtocolsProperties) convert(parentRoutingProtocols *deviceClassComponentsRoutingProtocols) (deviceClassComponentsRoutingProtocols, error) {
+	var prop deviceClassComponentsRoutingProtocols
+	var err error
+
+	if parentRoutingProtocols != nil {
+		prop = *parentRoutingProtocols
+	}
+
+	if y.OSPFNeighbors != nil {
+		prop.ospfNeighbors, err = groupproperty.Interface2Reader(y.OSPFNeighbors, prop.ospfNeighbors)
+		if err != nil {
+			return deviceClassComponentsRoutingProtocols{}, errors.Wrap(err, "failed to convert ospf neighbors property to group property reader")
+		}
+	}
+	if y.ISISAdjacencies != nil {
+		prop.isisAdjacencies, err = groupproperty.Interface2Reader(y.ISISAdjacencies, prop.isisAdjacencies)
+		if err != nil {
+			return deviceClassComponentsRoutingProtocols{}, errors.Wrap(err, "failed to convert is-is adjacencies property to group property reader")
+		}
+	}
+	return prop, nil
+}
+
 func (y *yamlComponentsSBCProperties) convert(parentComponentsSBC *deviceClassComponentsSBC) (deviceClassComponentsSBC, error) {
 	var prop deviceClassComponentsSBC
 	var err error
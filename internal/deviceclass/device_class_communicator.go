@@ -8,10 +8,14 @@ import (
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
 	"math"
+	"net"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -35,6 +39,7 @@ func (o *deviceClassCommunicator) GetAvailableComponents() []string {
 			res = append(res, comp)
 		}
 	}
+	sort.Strings(res)
 	return res
 }
 
@@ -517,6 +522,120 @@ func (o *deviceClassCommunicator) GetHighAvailabilityComponent(ctx context.Conte
 	return ha, nil
 }
 
+func (o *deviceClassCommunicator) GetInventoryComponent(ctx context.Context) (device.InventoryComponent, error) {
+	if !o.HasComponent(component.Inventory) {
+		return device.InventoryComponent{}, tholaerr.NewComponentNotFoundError("no inventory component available for this device")
+	}
+
+	var inventory device.InventoryComponent
+
+	empty := true
+
+	entities, err := o.GetInventoryComponentEntities(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.InventoryComponent{}, errors.Wrap(err, "error occurred during get inventory component entities")
+		}
+	} else {
+		inventory.Entities = entities
+		empty = false
+	}
+
+	if empty {
+		return device.InventoryComponent{}, tholaerr.NewNotFoundError("no inventory data available")
+	}
+
+	return inventory, nil
+}
+
+func (o *deviceClassCommunicator) GetNeighborsComponent(ctx context.Context) (device.NeighborsComponent, error) {
+	if !o.HasComponent(component.Neighbors) {
+		return device.NeighborsComponent{}, tholaerr.NewComponentNotFoundError("no neighbors component available for this device")
+	}
+
+	var neighbors device.NeighborsComponent
+
+	empty := true
+
+	res, err := o.GetNeighborsComponentNeighbors(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.NeighborsComponent{}, errors.Wrap(err, "error occurred during get neighbors component neighbors")
+		}
+	} else {
+		neighbors.Neighbors = res
+		empty = false
+	}
+
+	if empty {
+		return device.NeighborsComponent{}, tholaerr.NewNotFoundError("no neighbors data available")
+	}
+
+	return neighbors, nil
+}
+
+func (o *deviceClassCommunicator) GetBGPComponent(ctx context.Context) (device.BGPComponent, error) {
+	if !o.HasComponent(component.BGP) {
+		return device.BGPComponent{}, tholaerr.NewComponentNotFoundError("no bgp component available for this device")
+	}
+
+	var bgp device.BGPComponent
+
+	empty := true
+
+	res, err := o.GetBGPComponentPeers(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.BGPComponent{}, errors.Wrap(err, "error occurred during get bgp component peers")
+		}
+	} else {
+		bgp.Peers = res
+		empty = false
+	}
+
+	if empty {
+		return device.BGPComponent{}, tholaerr.NewNotFoundError("no bgp data available")
+	}
+
+	return bgp, nil
+}
+
+func (o *deviceClassCommunicator) GetRoutingProtocolsComponent(ctx context.Context) (device.RoutingProtocolsComponent, error) {
+	if !o.HasComponent(component.RoutingProtocols) {
+		return device.RoutingProtocolsComponent{}, tholaerr.NewComponentNotFoundError("no routing protocols component available for this device")
+	}
+
+	var routingProtocols device.RoutingProtocolsComponent
+
+	empty := true
+
+	ospfNeighbors, err := o.GetRoutingProtocolsComponentOSPFNeighbors(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.RoutingProtocolsComponent{}, errors.Wrap(err, "error occurred during get ospf neighbors")
+		}
+	} else {
+		routingProtocols.OSPFNeighbors = ospfNeighbors
+		empty = false
+	}
+
+	isisAdjacencies, err := o.GetRoutingProtocolsComponentISISAdjacencies(ctx)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) && !tholaerr.IsNotImplementedError(err) {
+			return device.RoutingProtocolsComponent{}, errors.Wrap(err, "error occurred during get is-is adjacencies")
+		}
+	} else {
+		routingProtocols.ISISAdjacencies = isisAdjacencies
+		empty = false
+	}
+
+	if empty {
+		return device.RoutingProtocolsComponent{}, tholaerr.NewNotFoundError("no routing protocols data available")
+	}
+
+	return routingProtocols, nil
+}
+
 func (o *deviceClassCommunicator) GetVendor(ctx context.Context) (string, error) {
 	if o.identify.properties.vendor == nil {
 		log.Ctx(ctx).Debug().Str("property", "vendor").Str("device_class", o.name).Msg("no detection information available")
@@ -1287,3 +1406,252 @@ func (o *deviceClassCommunicator) GetHighAvailabilityComponentNodes(ctx context.
 
 	return v, nil
 }
+
+func (o *deviceClassCommunicator) GetInventoryComponentEntities(ctx context.Context) ([]device.InventoryComponentEntity, error) {
+	if o.components.inventory == nil || o.components.inventory.properties == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "InventoryComponentEntities").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "InventoryComponentEntities").Logger()
+	ctx = logger.WithContext(ctx)
+	res, indices, err := o.components.inventory.properties.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var entities []device.InventoryComponentEntity
+	err = mapstructure.WeakDecode(res, &entities)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into inventory entity struct")
+	}
+	for i := range entities {
+		if entities[i].Index == nil && i < len(indices) {
+			index := indices[i].String()
+			entities[i].Index = &index
+		}
+	}
+	return entities, nil
+}
+
+func (o *deviceClassCommunicator) GetNeighborsComponentNeighbors(ctx context.Context) ([]device.NeighborsComponentNeighbor, error) {
+	if o.components.neighbors == nil || o.components.neighbors.properties == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "NeighborsComponentNeighbors").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "NeighborsComponentNeighbors").Logger()
+	ctx = logger.WithContext(ctx)
+	res, indices, err := o.components.neighbors.properties.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var neighbors []device.NeighborsComponentNeighbor
+	err = res.Decode(&neighbors)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into neighbor struct")
+	}
+
+	localPorts := o.getNeighborsComponentLocalPorts(ctx)
+	managementAddresses := o.getNeighborsComponentManagementAddresses(ctx, indices)
+
+	// normalize neighbors, the indices of neighbors consist of <time mark>.<local port>.<remote index> (LLDP-MIB lldpRemTable)
+	for i := range neighbors {
+		if i >= len(indices) {
+			break
+		}
+		index := indices[i].String()
+		if neighbors[i].LocalPort == nil {
+			if parts := strings.Split(index, "."); len(parts) >= 2 {
+				if port, ok := localPorts[parts[len(parts)-2]]; ok {
+					neighbors[i].LocalPort = &port
+				}
+			}
+		}
+		if neighbors[i].RemoteManagementAddress == nil {
+			if address, ok := managementAddresses[index]; ok {
+				neighbors[i].RemoteManagementAddress = &address
+			}
+		}
+	}
+
+	return neighbors, nil
+}
+
+// getNeighborsComponentLocalPorts returns the names of the local ports mapped by their index.
+func (o *deviceClassCommunicator) getNeighborsComponentLocalPorts(ctx context.Context) map[string]string {
+	localPorts := make(map[string]string)
+	if o.components.neighbors.localPorts == nil {
+		return localPorts
+	}
+
+	res, indices, err := o.components.neighbors.localPorts.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get local ports of neighbors")
+		return localPorts
+	}
+	var ports []struct {
+		ID          *string `mapstructure:"id"`
+		Description *string `mapstructure:"description"`
+	}
+	err = res.Decode(&ports)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to decode local ports of neighbors")
+		return localPorts
+	}
+
+	for i, port := range ports {
+		if i >= len(indices) {
+			break
+		}
+		if port.Description != nil && *port.Description != "" {
+			localPorts[indices[i].String()] = *port.Description
+		} else if port.ID != nil && *port.ID != "" {
+			localPorts[indices[i].String()] = *port.ID
+		}
+	}
+
+	return localPorts
+}
+
+// getNeighborsComponentManagementAddresses returns the first management address per neighbor index.
+func (o *deviceClassCommunicator) getNeighborsComponentManagementAddresses(ctx context.Context, neighborIndices []value.Value) map[string]string {
+	addresses := make(map[string]string)
+	if o.components.neighbors.managementAddresses == nil {
+		return addresses
+	}
+
+	_, indices, err := o.components.neighbors.managementAddresses.GetProperty(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to get management addresses of neighbors")
+		return addresses
+	}
+
+	for _, index := range indices {
+		for _, neighborIndex := range neighborIndices {
+			prefix := neighborIndex.String() + "."
+			if !strings.HasPrefix(index.String(), prefix) {
+				continue
+			}
+			if _, ok := addresses[neighborIndex.String()]; ok {
+				break
+			}
+			address, err := parseManagementAddressIndex(strings.TrimPrefix(index.String(), prefix))
+			if err != nil {
+				log.Ctx(ctx).Debug().Err(err).Msgf("failed to parse management address index '%s'", index.String())
+				break
+			}
+			addresses[neighborIndex.String()] = address
+			break
+		}
+	}
+
+	return addresses
+}
+
+// parseManagementAddressIndex parses a management address which is encoded in an index
+// in the form of <address subtype>.<address length>.<address> (LLDP-MIB lldpRemManAddrTable).
+func parseManagementAddressIndex(index string) (string, error) {
+	parts := strings.Split(index, ".")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid management address index '%s'", index)
+	}
+
+	length, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse management address length")
+	}
+	if len(parts) != length+2 {
+		return "", fmt.Errorf("management address length '%d' does not match index '%s'", length, index)
+	}
+
+	address := make(net.IP, length)
+	for i, part := range parts[2:] {
+		b, err := strconv.ParseUint(part, 10, 8)
+		if err != nil {
+			return "", errors.Wrap(err, "failed to parse management address")
+		}
+		address[i] = byte(b)
+	}
+
+	// address subtypes are defined in IANA-ADDRESS-FAMILY-NUMBERS-MIB, 1 = ipV4, 2 = ipV6
+	switch parts[0] {
+	case "1", "2":
+		if length != net.IPv4len && length != net.IPv6len {
+			return "", fmt.Errorf("invalid ip address length '%d'", length)
+		}
+		return address.String(), nil
+	}
+
+	return "", fmt.Errorf("unsupported management address subtype '%s'", parts[0])
+}
+
+func (o *deviceClassCommunicator) GetBGPComponentPeers(ctx context.Context) ([]device.BGPComponentPeer, error) {
+	if o.components.bgp == nil || o.components.bgp.properties == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "BGPComponentPeers").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "BGPComponentPeers").Logger()
+	ctx = logger.WithContext(ctx)
+	res, indices, err := o.components.bgp.properties.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var peers []device.BGPComponentPeer
+	err = res.Decode(&peers)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into bgp peer struct")
+	}
+	// the peer address is the index of the peer table if not given
+	for i := range peers {
+		if peers[i].PeerAddress == nil && i < len(indices) {
+			address := indices[i].String()
+			peers[i].PeerAddress = &address
+		}
+	}
+	return peers, nil
+}
+
+func (o *deviceClassCommunicator) GetRoutingProtocolsComponentOSPFNeighbors(ctx context.Context) ([]device.RoutingProtocolsComponentOSPFNeighbor, error) {
+	if o.components.routingProtocols == nil || o.components.routingProtocols.ospfNeighbors == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "RoutingProtocolsComponentOSPFNeighbors").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "RoutingProtocolsComponentOSPFNeighbors").Logger()
+	ctx = logger.WithContext(ctx)
+	res, _, err := o.components.routingProtocols.ospfNeighbors.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var neighbors []device.RoutingProtocolsComponentOSPFNeighbor
+	err = res.Decode(&neighbors)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into ospf neighbor struct")
+	}
+	return neighbors, nil
+}
+
+func (o *deviceClassCommunicator) GetRoutingProtocolsComponentISISAdjacencies(ctx context.Context) ([]device.RoutingProtocolsComponentISISAdjacency, error) {
+	if o.components.routingProtocols == nil || o.components.routingProtocols.isisAdjacencies == nil {
+		log.Ctx(ctx).Debug().Str("groupProperty", "RoutingProtocolsComponentISISAdjacencies").Str("device_class", o.name).Msg("no detection information available")
+		return nil, tholaerr.NewNotImplementedError("no detection information available")
+	}
+	logger := log.Ctx(ctx).With().Str("groupProperty", "RoutingProtocolsComponentISISAdjacencies").Logger()
+	ctx = logger.WithContext(ctx)
+	res, indices, err := o.components.routingProtocols.isisAdjacencies.GetProperty(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get property")
+	}
+	var adjacencies []device.RoutingProtocolsComponentISISAdjacency
+	err = res.Decode(&adjacencies)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode property into is-is adjacency struct")
+	}
+	// the index of the adjacency table consists of <circuit index>.<adjacency index>
+	for i := range adjacencies {
+		if adjacencies[i].CircuitIndex == nil && i < len(indices) {
+			circuitIndex, err := strconv.ParseUint(strings.Split(strings.TrimPrefix(indices[i].String(), "."), ".")[0], 10, 64)
+			if err == nil {
+				adjacencies[i].CircuitIndex = &circuitIndex
+			}
+		}
+	}
+	return adjacencies, nil
+}
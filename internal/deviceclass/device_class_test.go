@@ -9,3 +9,21 @@ func TestDeviceClass_GetHierarchy(t *testing.T) {
 	_, err := GetHierarchy()
 	assert.NoError(t, err, "hierarchy building failed")
 }
+
+func TestParseManagementAddressIndex(t *testing.T) {
+	address, err := parseManagementAddressIndex("1.4.192.168.0.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "192.168.0.1", address)
+	}
+
+	address, err = parseManagementAddressIndex("2.16.32.1.13.184.0.0.0.0.0.0.0.0.0.0.0.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "2001:db8::1", address)
+	}
+
+	_, err = parseManagementAddressIndex("1.4.192.168.0")
+	assert.Error(t, err)
+
+	_, err = parseManagementAddressIndex("6.6.0.17.34.51.68.85")
+	assert.Error(t, err)
+}
@@ -0,0 +1,245 @@
+package groupproperty
+
+import (
+	"context"
+	"fmt"
+	relatedTask "github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/extract"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"strconv"
+)
+
+func interface2HTTPValueReader(i interface{}) (httpValueReader, error) {
+	values, ok := i.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("values needs to be a map")
+	}
+
+	result := make(httpValues)
+
+	for val, data := range values {
+		dataMap, ok := data.(map[interface{}]interface{})
+		if !ok {
+			return nil, errors.New("value data needs to be a map")
+		}
+
+		valString, ok := val.(string)
+		if !ok {
+			return nil, errors.New("key of http property reader must be a string")
+		}
+
+		if v, ok := dataMap["values"]; ok {
+			if len(dataMap) != 1 {
+				return nil, errors.New("value with subvalues has to many keys")
+			}
+			reader, err := interface2HTTPValueReader(v)
+			if err != nil {
+				return nil, err
+			}
+			result[valString] = reader
+			continue
+		}
+
+		if ignore, ok := dataMap["ignore"]; ok {
+			if b, ok := ignore.(bool); ok && b {
+				result[valString] = &emptyHTTPValueReader{}
+				continue
+			}
+		}
+
+		var yamlValue yamlHTTPValue
+		err := mapstructure.Decode(data, &yamlValue)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode values map to yamlHTTPValue")
+		}
+		err = yamlValue.Validate()
+		if err != nil {
+			return nil, errors.Wrapf(err, "http value reader for %s is invalid", valString)
+		}
+		v := httpValue{
+			Expression: yamlValue.Expression,
+		}
+		if yamlValue.Operators != nil {
+			v.operators, err = property.InterfaceSlice2Operators(yamlValue.Operators, relatedTask.PropertyDefault)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read yaml http value operators")
+			}
+		}
+		result[valString] = &v
+	}
+	return &result, nil
+}
+
+// httpValueReader reads a value out of a single item of a http response.
+type httpValueReader interface {
+	readValue(ctx context.Context, item extract.Node) (interface{}, error)
+}
+
+// httpValues is a recursive data structure which maps labels to either a single value (httpValue) or another httpValues
+type httpValues map[string]httpValueReader
+
+func (h *httpValues) readValue(ctx context.Context, item extract.Node) (interface{}, error) {
+	result := make(map[string]interface{})
+	for label, reader := range *h {
+		res, err := reader.readValue(ctx, item)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) || tholaerr.IsComponentNotFoundError(err) {
+				log.Ctx(ctx).Debug().Err(err).Msgf("failed to get value '%s'", label)
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get value '%s'", label)
+		}
+		result[label] = res
+	}
+	if len(result) == 0 {
+		return nil, tholaerr.NewNotFoundError("no values found")
+	}
+	return result, nil
+}
+
+func (h *httpValues) merge(overwrite httpValues) httpValues {
+	res := make(httpValues)
+	for k, v := range *h {
+		res[k] = v
+	}
+	for k, v := range overwrite {
+		if reader, ok := res[k]; ok {
+			valuesOld, oldIsValues := reader.(*httpValues)
+			valuesOverwrite, overwriteIsValues := v.(*httpValues)
+			if oldIsValues && overwriteIsValues {
+				merged := valuesOld.merge(*valuesOverwrite)
+				res[k] = &merged
+				continue
+			}
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// httpValue represents a single value which is extracted from an item
+type httpValue struct {
+	extract.Expression
+	operators property.Operators
+}
+
+func (h *httpValue) readValue(ctx context.Context, item extract.Node) (interface{}, error) {
+	v, ok, err := h.FindValue(item)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract value")
+	}
+	if !ok {
+		return nil, tholaerr.NewNotFoundError("expression did not match")
+	}
+	res, err := h.operators.Apply(ctx, v)
+	if err != nil {
+		if tholaerr.IsDidNotMatchError(err) {
+			return nil, tholaerr.NewNotFoundError("operators did not match")
+		}
+		return nil, errors.Wrapf(err, "value couldn't be normalized (value: %s)", v)
+	}
+	return res, nil
+}
+
+type emptyHTTPValueReader struct{}
+
+func (e *emptyHTTPValueReader) readValue(context.Context, extract.Node) (interface{}, error) {
+	return nil, tholaerr.NewComponentNotFoundError("value is ignored")
+}
+
+type yamlHTTPValue struct {
+	extract.Expression `mapstructure:",squash"`
+	Operators          []interface{}
+}
+
+type httpReader struct {
+	uri     string
+	items   extract.Expression
+	index   *extract.Expression
+	values  *httpValues
+	filters []Filter
+}
+
+func (h httpReader) getProperty(ctx context.Context) (PropertyGroups, []value.Value, error) {
+	logger := log.Ctx(ctx).With().Str("uri", h.uri).Logger()
+	ctx = logger.WithContext(ctx)
+
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.HTTP == nil || con.HTTP.HTTPClient == nil {
+		log.Ctx(ctx).Debug().Msg("http client is empty")
+		return nil, nil, errors.New("http client is empty")
+	}
+
+	response, err := con.HTTP.Get(ctx, h.uri)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "http request failed")
+	}
+
+	items, err := h.items.Find(extract.NewTextNode(string(response.Body())))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to extract items from http response")
+	}
+
+	var res PropertyGroups
+	var indices []value.Value
+	seenIndices := make(map[string]struct{})
+	for i, item := range items {
+		index := strconv.Itoa(i + 1)
+		if h.index != nil {
+			idx, ok, err := h.index.FindValue(item)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to extract index")
+			}
+			if !ok {
+				log.Ctx(ctx).Debug().Msgf("no index found for item %d, skipping it", i+1)
+				continue
+			}
+			index = idx.String()
+		}
+		if _, ok := seenIndices[index]; ok {
+			return nil, nil, fmt.Errorf("http response contains duplicate index '%s'", index)
+		}
+		seenIndices[index] = struct{}{}
+
+		group, err := h.values.readValue(ctx, item)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) {
+				continue
+			}
+			return nil, nil, errors.Wrapf(err, "failed to read values of index '%s'", index)
+		}
+		x, ok := group.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("http value reader for index '%s' returned unexpected data type: %T", index, group)
+		}
+
+		groups := PropertyGroups{x}
+		for _, filter := range h.filters {
+			groups, err = filter.ApplyPropertyGroups(ctx, groups)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to apply filter")
+			}
+		}
+		if len(groups) == 0 {
+			continue
+		}
+
+		res = append(res, groups[0])
+		indices = append(indices, value.New(index))
+	}
+
+	return res, indices, nil
+}
+
+func (h httpReader) applyFilter(_ context.Context, filter Filter) (reader, error) {
+	filters := make([]Filter, len(h.filters), len(h.filters)+1)
+	copy(filters, h.filters)
+	h.filters = append(filters, filter)
+	return h, nil
+}
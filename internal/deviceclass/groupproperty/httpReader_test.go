@@ -0,0 +1,186 @@
+package groupproperty
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/value"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+func newHTTPTestContext(t *testing.T, handler http.Handler) context.Context {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	port, err := strconv.Atoi(u.Port())
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	client, err := network.NewHTTPClient(server.URL)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		HTTP: &network.RequestDeviceConnectionHTTP{
+			HTTPClient: client,
+			ConnectionData: &network.HTTPConnectionData{
+				HTTPPorts: []int{port},
+			},
+		},
+	})
+}
+
+func newYAMLReader(t *testing.T, s string, parent Reader) Reader {
+	t.Helper()
+	var i interface{}
+	if !assert.NoError(t, yaml.Unmarshal([]byte(s), &i)) {
+		t.FailNow()
+	}
+	r, err := Interface2Reader(i, parent)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	return r
+}
+
+func TestHTTPReader_JSON(t *testing.T) {
+	ctx := newHTTPTestContext(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/interfaces", r.URL.Path)
+		_, _ = w.Write([]byte(`{"interfaces": [
+			{"id": 10, "name": "eth0", "speed": 1000, "stats": {"in": 5}},
+			{"id": 20, "name": "lo"},
+			{"id": 30, "name": "eth1", "speed": 10000, "stats": {"in": 7}}
+		]}`))
+	}))
+
+	sut := newYAMLReader(t, `
+detection: http
+uri: /api/interfaces
+json_path: $.interfaces[*]
+index:
+  json_path: $.id
+values:
+  ifDescr:
+    json_path: $.name
+  ifSpeed:
+    json_path: $.speed
+    operators:
+      - type: modify
+        modify_method: multiply
+        value:
+          detection: constant
+          value: 1000000
+  ethernet:
+    values:
+      in:
+        json_path: $.stats.in
+`, nil)
+
+	groups, indices, err := sut.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []value.Value{value.New("10"), value.New("20"), value.New("30")}, indices)
+		assert.Equal(t, PropertyGroups{
+			{
+				"ifDescr":  value.New("eth0"),
+				"ifSpeed":  value.New("1000000000"),
+				"ethernet": map[string]interface{}{"in": value.New("5")},
+			},
+			{
+				"ifDescr": value.New("lo"),
+			},
+			{
+				"ifDescr":  value.New("eth1"),
+				"ifSpeed":  value.New("10000000000"),
+				"ethernet": map[string]interface{}{"in": value.New("7")},
+			},
+		}, groups)
+	}
+
+	groups, indices, err = sut.GetProperty(ctx, GetGroupFilter([]string{"ifDescr"}, "^lo$"), GetValueFilter([]string{"ethernet"}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []value.Value{value.New("10"), value.New("30")}, indices)
+		assert.Equal(t, PropertyGroups{
+			{
+				"ifDescr": value.New("eth0"),
+				"ifSpeed": value.New("1000000000"),
+			},
+			{
+				"ifDescr": value.New("eth1"),
+				"ifSpeed": value.New("10000000000"),
+			},
+		}, groups)
+	}
+}
+
+func TestHTTPReader_XMLInherit(t *testing.T) {
+	ctx := newHTTPTestContext(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`<interfaces>
+			<interface><name>eth0</name><mtu>1500</mtu></interface>
+			<interface><name>eth1</name><mtu>9000</mtu></interface>
+		</interfaces>`))
+	}))
+
+	parent := newYAMLReader(t, `
+detection: http
+uri: /interfaces.xml
+xpath: //interface
+values:
+  ifDescr:
+    xpath: name
+  ifMtu:
+    xpath: mtu
+`, nil)
+
+	sut := newYAMLReader(t, `
+detection: http
+uri: /interfaces.xml
+xpath: //interface
+values:
+  ifAlias:
+    regex: ^eth(\d)
+  ifMtu:
+    ignore: true
+`, parent)
+
+	groups, indices, err := sut.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []value.Value{value.New("1"), value.New("2")}, indices)
+		assert.Equal(t, PropertyGroups{
+			{
+				"ifDescr": value.New("eth0"),
+				"ifAlias": value.New("0"),
+			},
+			{
+				"ifDescr": value.New("eth1"),
+				"ifAlias": value.New("1"),
+			},
+		}, groups)
+	}
+}
+
+func TestHTTPReader_invalid(t *testing.T) {
+	invalid := []string{
+		"detection: http\njson_path: $.a\nvalues: {a: {json_path: $.a}}",
+		"detection: http\nuri: /\nvalues: {a: {json_path: $.a}}",
+		"detection: http\nuri: /\njson_path: $.a\n",
+		"detection: http\nuri: /\njson_path: $.a\nvalues: {a: {json_path: $.a, xpath: /a}}",
+		"detection: http\nuri: /\njson_path: $.a\nindex: {}\nvalues: {a: {json_path: $.a}}",
+	}
+	for _, s := range invalid {
+		var i interface{}
+		if assert.NoError(t, yaml.Unmarshal([]byte(s), &i)) {
+			_, err := Interface2Reader(i, nil)
+			assert.Error(t, err, s)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	relatedTask "github.com/inexio/thola/internal/deviceclass/condition"
 	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/explain"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/value"
@@ -82,7 +83,7 @@ type deviceClassOIDs map[string]OIDReader
 func (d *deviceClassOIDs) readOID(ctx context.Context, indices []string, skipEmpty bool) (map[string]interface{}, error) {
 	result := make(map[string]map[string]interface{})
 	for label, reader := range *d {
-		res, err := reader.readOID(ctx, indices, skipEmpty)
+		res, err := reader.readOID(explain.WithLabel(ctx, label), indices, skipEmpty)
 		if err != nil {
 			if tholaerr.IsNotFoundError(err) || tholaerr.IsComponentNotFoundError(err) {
 				log.Ctx(ctx).Debug().Err(err).Msgf("failed to get value '%s'", label)
@@ -135,8 +136,12 @@ type deviceClassOID struct {
 	indicesMapping OIDReader
 }
 
-func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpty bool) (map[string]interface{}, error) {
+func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpty bool) (_ map[string]interface{}, err error) {
 	result := make(map[string]interface{})
+	trace := explain.StartOID(ctx, d.OID.String())
+	defer func() {
+		trace.SetError(err)
+	}()
 
 	logger := log.Ctx(ctx).With().Str("oid", d.OID.String()).Logger()
 	ctx = logger.WithContext(ctx)
@@ -148,7 +153,6 @@ func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpt
 	}
 
 	var snmpResponse []network.SNMPResponse
-	var err error
 	if len(indices) > 0 {
 		log.Ctx(ctx).Debug().Msg("indices given, using SNMP Gets instead of Walk")
 
@@ -208,14 +212,17 @@ func (d *deviceClassOID) readOID(ctx context.Context, indices []string, skipEmpt
 			continue
 		}
 		if !res.IsEmpty() || !skipEmpty {
-			resNormalized, err := d.operators.Apply(ctx, res)
+			responseCtx, responseTrace := trace.StartResponse(ctx, response.GetOID().String(), res.String())
+			resNormalized, err := d.operators.Apply(responseCtx, res)
 			if err != nil {
+				responseTrace.Finish("", err)
 				if tholaerr.IsDidNotMatchError(err) {
 					continue
 				}
 				log.Ctx(ctx).Debug().Err(err).Msgf("response couldn't be normalized (response: %s)", res)
 				return nil, errors.Wrapf(err, "response couldn't be normalized (response: %s)", res)
 			}
+			responseTrace.Finish(fmt.Sprint(resNormalized), nil)
 			idx, err := response.GetOID().GetIndexAfterOID(d.OID)
 			if err != nil {
 				return nil, errors.Wrap(err, "failed to get index after oid")
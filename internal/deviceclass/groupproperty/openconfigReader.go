@@ -0,0 +1,263 @@
+package groupproperty
+
+import (
+	"context"
+	"fmt"
+	relatedTask "github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/deviceclass/property"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/openconfig"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/value"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"strconv"
+)
+
+func interface2OpenConfigValueReader(i interface{}) (openconfigValueReader, error) {
+	values, ok := i.(map[interface{}]interface{})
+	if !ok {
+		return nil, errors.New("values needs to be a map")
+	}
+
+	result := make(openconfigValues)
+
+	for val, data := range values {
+		dataMap, ok := data.(map[interface{}]interface{})
+		if !ok {
+			return nil, errors.New("value data needs to be a map")
+		}
+
+		valString, ok := val.(string)
+		if !ok {
+			return nil, errors.New("key of openconfig property reader must be a string")
+		}
+
+		if v, ok := dataMap["values"]; ok {
+			if len(dataMap) != 1 {
+				return nil, errors.New("value with subvalues has to many keys")
+			}
+			reader, err := interface2OpenConfigValueReader(v)
+			if err != nil {
+				return nil, err
+			}
+			result[valString] = reader
+			continue
+		}
+
+		if ignore, ok := dataMap["ignore"]; ok {
+			if b, ok := ignore.(bool); ok && b {
+				result[valString] = &emptyOpenConfigValueReader{}
+				continue
+			}
+		}
+
+		var yamlValue yamlOpenConfigValue
+		err := mapstructure.Decode(data, &yamlValue)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode values map to yamlOpenConfigValue")
+		}
+		if yamlValue.Path == "" {
+			return nil, fmt.Errorf("path of openconfig value reader for %s is missing", valString)
+		}
+		path, err := openconfig.ParsePath(yamlValue.Path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "openconfig value reader for %s is invalid", valString)
+		}
+		v := openconfigValue{
+			path: path,
+		}
+		if yamlValue.Operators != nil {
+			v.operators, err = property.InterfaceSlice2Operators(yamlValue.Operators, relatedTask.PropertyDefault)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to read yaml openconfig value operators")
+			}
+		}
+		result[valString] = &v
+	}
+	return &result, nil
+}
+
+// openconfigValueReader reads a value out of a single item of an OpenConfig tree.
+type openconfigValueReader interface {
+	readValue(ctx context.Context, item *openconfig.Node) (interface{}, error)
+}
+
+// openconfigValues is a recursive data structure which maps labels to either a single value (openconfigValue) or another openconfigValues
+type openconfigValues map[string]openconfigValueReader
+
+func (o *openconfigValues) readValue(ctx context.Context, item *openconfig.Node) (interface{}, error) {
+	result := make(map[string]interface{})
+	for label, reader := range *o {
+		res, err := reader.readValue(ctx, item)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) || tholaerr.IsComponentNotFoundError(err) {
+				log.Ctx(ctx).Debug().Err(err).Msgf("failed to get value '%s'", label)
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to get value '%s'", label)
+		}
+		result[label] = res
+	}
+	if len(result) == 0 {
+		return nil, tholaerr.NewNotFoundError("no values found")
+	}
+	return result, nil
+}
+
+func (o *openconfigValues) merge(overwrite openconfigValues) openconfigValues {
+	res := make(openconfigValues)
+	for k, v := range *o {
+		res[k] = v
+	}
+	for k, v := range overwrite {
+		if reader, ok := res[k]; ok {
+			valuesOld, oldIsValues := reader.(*openconfigValues)
+			valuesOverwrite, overwriteIsValues := v.(*openconfigValues)
+			if oldIsValues && overwriteIsValues {
+				merged := valuesOld.merge(*valuesOverwrite)
+				res[k] = &merged
+				continue
+			}
+		}
+		res[k] = v
+	}
+	return res
+}
+
+// openconfigValue represents a single leaf which is read relative to an item
+type openconfigValue struct {
+	path      openconfig.Path
+	operators property.Operators
+}
+
+func (o *openconfigValue) readValue(ctx context.Context, item *openconfig.Node) (interface{}, error) {
+	leaf := findLeaf(item, o.path)
+	if leaf == nil {
+		return nil, tholaerr.NewNotFoundError("no leaf found at path")
+	}
+	v := value.New(leaf.String())
+	res, err := o.operators.Apply(ctx, v)
+	if err != nil {
+		if tholaerr.IsDidNotMatchError(err) {
+			return nil, tholaerr.NewNotFoundError("operators did not match")
+		}
+		return nil, errors.Wrapf(err, "value couldn't be normalized (value: %s)", v)
+	}
+	return res, nil
+}
+
+type emptyOpenConfigValueReader struct{}
+
+func (e *emptyOpenConfigValueReader) readValue(context.Context, *openconfig.Node) (interface{}, error) {
+	return nil, tholaerr.NewComponentNotFoundError("value is ignored")
+}
+
+type yamlOpenConfigValue struct {
+	Path      string
+	Operators []interface{}
+}
+
+// openconfigReader reads group properties out of the entries of an OpenConfig list (e.g. /interfaces/interface)
+// which is retrieved via gNMI or NETCONF. If neither is available, the fallback reader is used.
+// Entries without a leaf at the require path (e.g. components that are no cpus) are skipped.
+type openconfigReader struct {
+	path      openconfig.Path
+	namespace string
+	index     openconfig.Path
+	require   openconfig.Path
+	values    *openconfigValues
+	fallback  Reader
+	filters   []Filter
+}
+
+func (o openconfigReader) getProperty(ctx context.Context) (PropertyGroups, []value.Value, error) {
+	logger := log.Ctx(ctx).With().Str("openconfig_path", o.path.String()).Logger()
+	ctx = logger.WithContext(ctx)
+
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || !con.HasTelemetryConnection() {
+		if o.fallback != nil {
+			log.Ctx(ctx).Debug().Msg("no gnmi or netconf connection available, using fallback reader")
+			return o.fallback.GetProperty(ctx, o.filters...)
+		}
+		return nil, nil, tholaerr.NewComponentNotFoundError("no gnmi or netconf connection available")
+	}
+
+	tree, err := con.GetOpenConfig(ctx, o.path, o.namespace)
+	if err != nil {
+		if o.fallback != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("openconfig request failed, using fallback reader")
+			return o.fallback.GetProperty(ctx, o.filters...)
+		}
+		return nil, nil, errors.Wrap(err, "openconfig request failed")
+	}
+
+	var res PropertyGroups
+	var indices []value.Value
+	seenIndices := make(map[string]struct{})
+	for i, item := range tree.Find(o.path) {
+		if o.require != nil && findLeaf(item, o.require) == nil {
+			continue
+		}
+		index := strconv.Itoa(i + 1)
+		if o.index != nil {
+			idx := findLeaf(item, o.index)
+			if idx == nil {
+				log.Ctx(ctx).Debug().Msgf("no index found for item %d, skipping it", i+1)
+				continue
+			}
+			index = idx.String()
+		}
+		if _, ok := seenIndices[index]; ok {
+			return nil, nil, fmt.Errorf("openconfig data contains duplicate index '%s'", index)
+		}
+		seenIndices[index] = struct{}{}
+
+		group, err := o.values.readValue(ctx, item)
+		if err != nil {
+			if tholaerr.IsNotFoundError(err) {
+				continue
+			}
+			return nil, nil, errors.Wrapf(err, "failed to read values of index '%s'", index)
+		}
+		x, ok := group.(map[string]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("openconfig value reader for index '%s' returned unexpected data type: %T", index, group)
+		}
+
+		groups := PropertyGroups{x}
+		for _, filter := range o.filters {
+			groups, err = filter.ApplyPropertyGroups(ctx, groups)
+			if err != nil {
+				return nil, nil, errors.Wrap(err, "failed to apply filter")
+			}
+		}
+		if len(groups) == 0 {
+			continue
+		}
+
+		res = append(res, groups[0])
+		indices = append(indices, value.New(index))
+	}
+
+	return res, indices, nil
+}
+
+// findLeaf returns the first leaf at the path relative to the item or nil if there is none.
+func findLeaf(item *openconfig.Node, path openconfig.Path) *openconfig.Node {
+	for _, node := range item.Find(path) {
+		if node.IsLeaf() {
+			return node
+		}
+	}
+	return nil
+}
+
+func (o openconfigReader) applyFilter(_ context.Context, filter Filter) (reader, error) {
+	filters := make([]Filter, len(o.filters), len(o.filters)+1)
+	copy(filters, o.filters)
+	o.filters = append(filters, filter)
+	return o, nil
+}
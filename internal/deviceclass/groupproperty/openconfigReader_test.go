@@ -0,0 +1,150 @@
+package groupproperty
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/openconfig"
+	"github.com/inexio/thola/internal/value"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"testing"
+)
+
+func newGNMITestContext(t *testing.T, path string, data string) context.Context {
+	t.Helper()
+	p, err := openconfig.ParsePath(path)
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	tree := openconfig.NewTree()
+	if !assert.NoError(t, tree.AddJSON(p, []byte(data))) {
+		t.FailNow()
+	}
+
+	client := network.MockGNMIClient{}
+	client.On("Get", mock.Anything, p).Return(tree, nil)
+
+	return network.NewContextWithDeviceConnection(context.Background(), &network.RequestDeviceConnection{
+		GNMI: &network.RequestDeviceConnectionGNMI{
+			GNMIClient:     &client,
+			ConnectionData: &network.GNMIConnectionData{},
+		},
+	})
+}
+
+func TestOpenConfigReader(t *testing.T) {
+	ctx := newGNMITestContext(t, "/interfaces/interface", `[
+		{"name": "et-0/0/0", "state": {"ifindex": 510, "type": "iana-if-type:ethernetCsmacd", "oper-status": "UP", "counters": {"in-octets": "1234"}}},
+		{"name": "lo0", "state": {"ifindex": 6, "oper-status": "DOWN"}},
+		{"name": "no-index", "state": {"oper-status": "UP"}}
+	]`)
+
+	sut := newYAMLReader(t, `
+detection: openconfig
+path: /interfaces/interface
+index: state/ifindex
+values:
+  ifDescr:
+    path: name
+  ifType:
+    path: state/type
+    operators:
+      - type: modify
+        modify_method: regexReplace
+        regex: '^.*:'
+        replace: ""
+  ifOperStatus:
+    path: state/oper-status
+    operators:
+      - type: modify
+        modify_method: toLowerCase
+  counters:
+    values:
+      in:
+        path: state/counters/in-octets
+`, nil)
+
+	groups, indices, err := sut.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []value.Value{value.New("510"), value.New("6")}, indices)
+		assert.Equal(t, PropertyGroups{
+			{
+				"ifDescr":      value.New("et-0/0/0"),
+				"ifType":       value.New("ethernetCsmacd"),
+				"ifOperStatus": value.New("up"),
+				"counters":     map[string]interface{}{"in": value.New("1234")},
+			},
+			{
+				"ifDescr":      value.New("lo0"),
+				"ifOperStatus": value.New("down"),
+			},
+		}, groups)
+	}
+
+	groups, indices, err = sut.GetProperty(ctx, GetGroupFilter([]string{"ifDescr"}, "^lo"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []value.Value{value.New("510")}, indices)
+		assert.Len(t, groups, 1)
+	}
+}
+
+func TestOpenConfigReader_require(t *testing.T) {
+	ctx := newGNMITestContext(t, "/components/component", `[
+		{"name": "FPC0", "state": {"type": "LINECARD"}},
+		{"name": "Routing Engine0", "cpu": {"utilization": {"state": {"instant": 12}}}}
+	]`)
+
+	sut := newYAMLReader(t, `
+detection: openconfig
+path: /components/component
+require: cpu/utilization/state/instant
+values:
+  label:
+    path: name
+  load:
+    path: cpu/utilization/state/instant
+`, nil)
+
+	groups, indices, err := sut.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []value.Value{value.New("2")}, indices)
+		assert.Equal(t, PropertyGroups{
+			{
+				"label": value.New("Routing Engine0"),
+				"load":  value.New("12"),
+			},
+		}, groups)
+	}
+}
+
+func TestOpenConfigReader_fallback(t *testing.T) {
+	ctx := newHTTPTestContext(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": 1, "name": "eth0"}]`))
+	}))
+
+	parent := newYAMLReader(t, `
+detection: http
+uri: /
+json_path: $[*]
+index:
+  json_path: $.id
+values:
+  ifDescr:
+    json_path: $.name
+`, nil)
+
+	sut := newYAMLReader(t, `
+detection: openconfig
+path: /interfaces/interface
+values:
+  ifDescr:
+    path: name
+`, parent)
+
+	groups, indices, err := sut.GetProperty(ctx)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []value.Value{value.New("1")}, indices)
+		assert.Equal(t, PropertyGroups{{"ifDescr": value.New("eth0")}}, groups)
+	}
+}
@@ -3,7 +3,9 @@ package groupproperty
 import (
 	"context"
 	"fmt"
+	"github.com/inexio/thola/internal/extract"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/openconfig"
 	"github.com/inexio/thola/internal/value"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
@@ -95,6 +97,194 @@ func Interface2Reader(i interface{}, parentReader Reader) (Reader, error) {
 				oids:  devClassOIDs,
 			},
 		}, nil
+	case "http":
+		uri, ok := m["uri"].(string)
+		if !ok || uri == "" {
+			return nil, errors.New("uri is missing or not a string")
+		}
+
+		var items extract.Expression
+		err := mapstructure.Decode(i, &items)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode items expression")
+		}
+		if err := items.Validate(); err != nil {
+			return nil, errors.Wrap(err, "items expression is invalid")
+		}
+
+		var index *extract.Expression
+		if idx, ok := m["index"]; ok {
+			index = &extract.Expression{}
+			err := mapstructure.Decode(idx, index)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to decode index expression")
+			}
+			if err := index.Validate(); err != nil {
+				return nil, errors.Wrap(err, "index expression is invalid")
+			}
+		}
+
+		if _, ok := m["values"]; !ok {
+			return nil, errors.New("values are missing")
+		}
+		valueReader, err := interface2HTTPValueReader(m["values"])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse http value reader")
+		}
+		values, ok := valueReader.(*httpValues)
+		if !ok {
+			return nil, errors.New("http value reader is no list of values")
+		}
+
+		inheritValuesFromParent := true
+		if b, ok := m["inherit_values"]; ok {
+			bb, ok := b.(bool)
+			if !ok {
+				return nil, errors.New("inherit_values needs to be a boolean")
+			}
+			inheritValuesFromParent = bb
+		}
+
+		//overwrite parent
+		if inheritValuesFromParent && parentReader != nil {
+			parentBaseReader, ok := parentReader.(*baseReader)
+			if !ok {
+				return nil, errors.New("parent group property reader is not of type base group property reader")
+			}
+
+			parentHTTPReader, ok := parentBaseReader.reader.(*httpReader)
+			if !ok {
+				return nil, errors.New("can't merge HTTP group property reader with property reader of different type")
+			}
+
+			valuesMerged := parentHTTPReader.values.merge(*values)
+			values = &valuesMerged
+
+			if index == nil {
+				index = parentHTTPReader.index
+			}
+		}
+
+		return &baseReader{
+			reader: &httpReader{
+				uri:    uri,
+				items:  items,
+				index:  index,
+				values: values,
+			},
+		}, nil
+	case "openconfig":
+		var parentOpenConfigReader *openconfigReader
+		if parentReader != nil {
+			parentBaseReader, ok := parentReader.(*baseReader)
+			if !ok {
+				return nil, errors.New("parent group property reader is not of type base group property reader")
+			}
+			if r, ok := parentBaseReader.reader.(*openconfigReader); ok {
+				parentOpenConfigReader = r
+			}
+		}
+
+		var reader openconfigReader
+		if p, ok := m["path"]; ok {
+			pathString, ok := p.(string)
+			if !ok {
+				return nil, errors.New("path needs to be a string")
+			}
+			path, err := openconfig.ParsePath(pathString)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid path")
+			}
+			reader.path = path
+		} else if parentOpenConfigReader != nil {
+			reader.path = parentOpenConfigReader.path
+		} else {
+			return nil, errors.New("path is missing")
+		}
+
+		if ns, ok := m["namespace"]; ok {
+			reader.namespace, ok = ns.(string)
+			if !ok {
+				return nil, errors.New("namespace needs to be a string")
+			}
+		}
+
+		if idx, ok := m["index"]; ok {
+			idxString, ok := idx.(string)
+			if !ok {
+				return nil, errors.New("index needs to be a string (path)")
+			}
+			index, err := openconfig.ParsePath(idxString)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid index path")
+			}
+			reader.index = index
+		}
+
+		if req, ok := m["require"]; ok {
+			reqString, ok := req.(string)
+			if !ok {
+				return nil, errors.New("require needs to be a string (path)")
+			}
+			require, err := openconfig.ParsePath(reqString)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid require path")
+			}
+			reader.require = require
+		}
+
+		if _, ok := m["values"]; !ok {
+			return nil, errors.New("values are missing")
+		}
+		valueReader, err := interface2OpenConfigValueReader(m["values"])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse openconfig value reader")
+		}
+		values, ok := valueReader.(*openconfigValues)
+		if !ok {
+			return nil, errors.New("openconfig value reader is no list of values")
+		}
+		reader.values = values
+
+		inheritValuesFromParent := true
+		if b, ok := m["inherit_values"]; ok {
+			bb, ok := b.(bool)
+			if !ok {
+				return nil, errors.New("inherit_values needs to be a boolean")
+			}
+			inheritValuesFromParent = bb
+		}
+
+		if fallback, ok := m["fallback"]; ok {
+			reader.fallback, err = Interface2Reader(fallback, nil)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse fallback reader")
+			}
+		}
+
+		if parentOpenConfigReader != nil {
+			//overwrite parent
+			if inheritValuesFromParent {
+				valuesMerged := parentOpenConfigReader.values.merge(*values)
+				reader.values = &valuesMerged
+				if reader.index == nil {
+					reader.index = parentOpenConfigReader.index
+				}
+				if reader.require == nil {
+					reader.require = parentOpenConfigReader.require
+				}
+			}
+			if reader.fallback == nil {
+				reader.fallback = parentOpenConfigReader.fallback
+			}
+		} else if parentReader != nil && reader.fallback == nil {
+			// readers of other types (e.g. snmpwalk) are used if the device can't be reached via gnmi or netconf
+			reader.fallback = parentReader
+		}
+
+		return &baseReader{
+			reader: &reader,
+		}, nil
 	default:
 		return nil, fmt.Errorf("unknown detection type '%s'", stringDetection)
 	}
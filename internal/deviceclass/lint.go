@@ -0,0 +1,210 @@
+package deviceclass
+
+import (
+	"fmt"
+	"github.com/inexio/thola/config"
+	"github.com/inexio/thola/internal/yamlschema"
+	"github.com/pkg/errors"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// yamlErrorLine matches the line number in errors of the yaml parsers.
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// LintProblem is a problem that was found in a device class file.
+type LintProblem struct {
+	File string
+	// Line and Column are 0 if the problem concerns the whole file.
+	Line    int
+	Column  int
+	Message string
+}
+
+// String returns the problem in the format "<file>:<line>:<column>: <message>".
+func (p LintProblem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Message)
+}
+
+// Lint checks all device classes that are found in the directory of the file system, starting with
+// "generic.yaml" like GetHierarchy. Every file is validated against the device class schema
+// and converted like it is done at runtime, so that all problems of all files are reported at once.
+// Files of sub device classes are only converted if their parent device class is valid.
+func Lint(fsys fs.FS, dir string) ([]LintProblem, error) {
+	schema, err := yamlschema.Compile(config.DeviceClassSchema)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compile device class schema")
+	}
+	l := linter{
+		fsys:   fsys,
+		schema: schema,
+	}
+	l.lintFile(path.Join(dir, "generic.yaml"), dir, "", nil, true)
+	return l.problems, nil
+}
+
+type linter struct {
+	fsys     fs.FS
+	schema   *yamlschema.Schema
+	problems []LintProblem
+}
+
+func (l *linter) addProblem(file string, line, column int, message string) {
+	l.problems = append(l.problems, LintProblem{
+		File:    file,
+		Line:    line,
+		Column:  column,
+		Message: message,
+	})
+}
+
+// addYAMLError adds a problem for an error of a yaml parser, which contains the line number in the message.
+func (l *linter) addYAMLError(file string, err error) {
+	line := 0
+	if m := yamlErrorLine.FindStringSubmatch(err.Error()); m != nil {
+		line, _ = strconv.Atoi(m[1])
+	}
+	column := 0
+	if line != 0 {
+		column = 1
+	}
+	l.addProblem(file, line, column, err.Error())
+}
+
+// lintFile lints the device class file and its sub device classes and returns the name of the device class
+// and the position of the name. The device class is only converted if convert is true, otherwise the parent
+// device class is unknown. parentName is the full name of the parent device class, e.g. "ceraos".
+func (l *linter) lintFile(file, dir, parentName string, parent *deviceClass, convert bool) (string, int, int) {
+	contents, err := fs.ReadFile(l.fsys, file)
+	if err != nil {
+		l.addProblem(file, 0, 0, "failed to read file: "+err.Error())
+		return "", 0, 0
+	}
+
+	var document yaml.Node
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		l.addYAMLError(file, err)
+		return "", 0, 0
+	}
+	schemaProblems := l.schema.Validate(&document)
+	for _, p := range schemaProblems {
+		message := p.Message
+		if p.Path != "" {
+			message = p.Path + ": " + message
+		}
+		l.addProblem(file, p.Line, p.Column, message)
+	}
+
+	var deviceClassYaml yamlDeviceClass
+	if err := yamlv2.Unmarshal(contents, &deviceClassYaml); err != nil {
+		l.addYAMLError(file, err)
+		return "", 0, 0
+	}
+	nameLine, nameColumn := findKey(&document, "name")
+
+	var devClass *deviceClass
+	if convert {
+		converted, err := deviceClassYaml.convert(parent)
+		if err != nil {
+			// the schema problems are most likely the reason, reporting the error again would only be noise
+			if len(schemaProblems) == 0 {
+				l.addProblem(file, nameLine, nameColumn, err.Error())
+			}
+		} else {
+			devClass = &converted
+		}
+	}
+
+	if deviceClassYaml.Name == "" || strings.Contains(deviceClassYaml.Name, "/") {
+		return deviceClassYaml.Name, nameLine, nameColumn
+	}
+	fullName := deviceClassFullName(parentName, deviceClassYaml.Name)
+	l.lintDirectory(path.Join(dir, fullName), fullName, devClass)
+	return deviceClassYaml.Name, nameLine, nameColumn
+}
+
+// deviceClassFullName returns the full name of a device class like deviceClass.convert.
+// Sub device classes are looked up in the directory with this name, like in yamlFile2Hierarchy.
+func deviceClassFullName(parentName, name string) string {
+	if parentName == "" || parentName == "generic" {
+		return name
+	}
+	return parentName + "/" + name
+}
+
+// lintDirectory lints all sub device classes in the directory, if it exists.
+func (l *linter) lintDirectory(dir, parentName string, parent *deviceClass) {
+	entries, err := fs.ReadDir(l.fsys, dir)
+	if err != nil {
+		// device classes without sub device classes don't have a directory
+		return
+	}
+
+	names := make(map[string]string)
+	var subDirs, readDirs []string
+	for _, entry := range entries {
+		file := path.Join(dir, entry.Name())
+		if entry.IsDir() {
+			subDirs = append(subDirs, entry.Name())
+			continue
+		}
+		if !strings.HasSuffix(entry.Name(), ".yaml") {
+			l.addProblem(file, 0, 0, "only yaml files are allowed in device class directories")
+			continue
+		}
+		name, line, column := l.lintFile(file, dir, parentName, parent, parent != nil)
+		if name == "" {
+			continue
+		}
+		if other, ok := names[name]; ok {
+			l.addProblem(file, line, column, fmt.Sprintf("device class name %q is already used in %s", name, other))
+			continue
+		}
+		names[name] = file
+		readDirs = append(readDirs, path.Join(dir, deviceClassFullName(parentName, name)))
+	}
+
+	sort.Strings(subDirs)
+out:
+	for _, subDir := range subDirs {
+		subDirPath := path.Join(dir, subDir)
+		for _, readDir := range readDirs {
+			if readDir == subDirPath || strings.HasPrefix(readDir, subDirPath+"/") {
+				continue out
+			}
+		}
+		if _, ok := names[subDir]; ok {
+			l.addProblem(subDirPath, 0, 0, fmt.Sprintf("directory is never read, sub device classes of %q are read from %s", subDir, path.Join(dir, deviceClassFullName(parentName, subDir))))
+		} else {
+			l.addProblem(subDirPath, 0, 0, fmt.Sprintf("directory is never read, there is no device class named %q in %s", subDir, dir))
+		}
+	}
+}
+
+// findKey returns the position of the value of the top level key in the document, or of the document itself.
+func findKey(document *yaml.Node, key string) (int, int) {
+	node := document
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1].Line, node.Content[i+1].Column
+			}
+		}
+	}
+	if node.Line == 0 {
+		return 1, 1
+	}
+	return node.Line, node.Column
+}
@@ -0,0 +1,82 @@
+package deviceclass
+
+import (
+	"github.com/inexio/thola/config"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLint(t *testing.T) {
+	problems, err := Lint(config.FileSystem, "deviceclass")
+	if assert.NoError(t, err) {
+		assert.Empty(t, problems, "built-in device classes have problems")
+	}
+}
+
+func TestLint_Problems(t *testing.T) {
+	fsys := fstest.MapFS{
+		"generic.yaml": {Data: []byte("name: generic\n")},
+		"generic/test.yaml": {Data: []byte(`name: test
+match:
+  conditions:
+    - type: SysObjectId
+      match_mode: startsWith
+      values:
+        - .1.3.6.1.4.1.9.
+  logical_operator: OR
+identify:
+  properties:
+    vendor:
+      - detection: snmpget
+        oid: 1.3.6.1.2.1.1.1.0
+        operators:
+          - type: modify
+            modify_method: regexSubmatch
+            regex: '('
+            format: "$1"
+    os_version:
+      - detection: SysDescription
+        oid: 1.3.6.1.2.1.1.1.0
+components:
+  interfaces:
+    properties:
+      detection: snmpwalk
+      values:
+        ifDescr:
+          oid: 1.3.6.1.2.1.2.2.1.2
+          opertors: []
+`)},
+		"generic/vendor.yaml": {Data: []byte(`name: vendor
+match:
+  type: Vendor
+  match_mode: equals
+  values:
+    - test
+`)},
+		"generic/vendor/sub.yaml": {Data: []byte("name: sub\n")},
+		"generic/README.md":       {Data: []byte("readme")},
+		"generic/unknown/a.yaml":  {Data: []byte("name: a\n")},
+		"generic/broken.yaml":     {Data: []byte("name: [\n")},
+	}
+
+	problems, err := Lint(fsys, ".")
+	if !assert.NoError(t, err) {
+		return
+	}
+	var res []string
+	for _, p := range problems {
+		res = append(res, p.String())
+	}
+	assert.Equal(t, []string{
+		"generic/README.md: only yaml files are allowed in device class directories",
+		"generic/broken.yaml:1:1: yaml: line 1: did not find expected node content",
+		`generic/test.yaml:4:13: match.conditions[0].type: invalid value "SysObjectId", did you mean "SysObjectID"?`,
+		"generic/test.yaml:17:20: identify.properties.vendor[0].operators[0].regex: invalid regex: error parsing regexp: missing closing ): `(`",
+		`generic/test.yaml:21:9: identify.properties.os_version[0]: property "oid" is not allowed`,
+		`generic/test.yaml:29:11: components.interfaces.properties.values.ifDescr: property "opertors" is not allowed, did you mean "operators"?`,
+		"generic/vendor.yaml:1:7: failed to convert device class condition: cannot use vendor condition, vendor is not available here yet",
+		`generic/vendor/sub.yaml:1:1: property "match" is missing`,
+		`generic/unknown: directory is never read, there is no device class named "unknown" in generic`,
+	}, res)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/explain"
 	"github.com/inexio/thola/internal/mapping"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/tholaerr"
@@ -278,6 +279,7 @@ func InterfaceSlice2Operators(i []interface{}, task condition.RelatedTask) (Oper
 			default:
 				return nil, fmt.Errorf("invalid modify method '%s'", modifyMethod)
 			}
+			modifier.method = modifyMethodString
 			propertyOperators = append(propertyOperators, &modifier)
 		case "switch":
 			var sw switchOperatorAdapter
@@ -387,8 +389,10 @@ type Operators []operator
 
 func (o *Operators) Apply(ctx context.Context, v value.Value) (value.Value, error) {
 	for _, operator := range *o {
-		x, err := operator.operate(ctx, v)
+		operatorCtx, trace := explain.StartOperator(ctx, describeOperator(operator), traceValue(v))
+		x, err := operator.operate(operatorCtx, v)
 		if err != nil {
+			trace.Finish("", err)
 			// if an error occurs, we check if the current operator is
 			// a strFilter should still return the previous value
 			if operator.returnOnError() {
@@ -396,11 +400,43 @@ func (o *Operators) Apply(ctx context.Context, v value.Value) (value.Value, erro
 			}
 			return nil, errors.Wrap(err, "operator failed")
 		}
+		trace.Finish(traceValue(x), nil)
 		v = x
 	}
 	return v, nil
 }
 
+// describeOperator returns the type of the operator and its configuration for traces.
+func describeOperator(o operator) string {
+	switch op := o.(type) {
+	case *filterOperatorAdapter:
+		if f, ok := op.operator.(*baseStringFilter); ok {
+			return fmt.Sprintf("filter %s %q", f.FilterMethod, f.Value)
+		}
+		return "filter"
+	case *modifyOperatorAdapter:
+		return "modify " + op.method
+	case *switchOperatorAdapter:
+		if w, ok := op.operator.(*genericStringSwitch); ok {
+			if _, ok := w.switchValueGetter.(*snmpWalkCountStringSwitchValueGetter); ok {
+				return "switch snmpwalkCount " + string(w.switchMode)
+			}
+			return "switch " + string(w.switchMode)
+		}
+		return "switch"
+	default:
+		return fmt.Sprintf("%T", o)
+	}
+}
+
+// traceValue returns the value as string for traces.
+func traceValue(v value.Value) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
 type operator interface {
 	operate(context.Context, value.Value) (value.Value, error)
 	returnOnErrorOperator
@@ -424,6 +460,7 @@ type switchOperator interface {
 
 type modifyOperatorAdapter struct {
 	operator modifyOperator
+	method   string
 }
 
 func (o *modifyOperatorAdapter) operate(ctx context.Context, v value.Value) (value.Value, error) {
@@ -2,9 +2,14 @@ package property
 
 import (
 	"context"
+	"fmt"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/condition"
+	"github.com/inexio/thola/internal/explain"
+	"github.com/inexio/thola/internal/extract"
 	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/openconfig"
+	"github.com/inexio/thola/internal/textfsm"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/value"
 	"github.com/mitchellh/mapstructure"
@@ -106,6 +111,39 @@ func interface2PReader(i interface{}, task condition.RelatedTask) (Reader, error
 			return nil, errors.Wrap(err, "failed to decode model series Reader")
 		}
 		basePropReader.reader = &pr
+	case "http":
+		var pr httpReader
+		err := mapstructure.Decode(i, &pr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode http reader")
+		}
+		err = pr.validate()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid http reader")
+		}
+		basePropReader.reader = &pr
+	case "ssh":
+		var pr sshReader
+		err := mapstructure.Decode(i, &pr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode ssh reader")
+		}
+		err = pr.init()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid ssh reader")
+		}
+		basePropReader.reader = &pr
+	case "openconfig":
+		var pr openconfigReader
+		err := mapstructure.Decode(i, &pr)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode openconfig reader")
+		}
+		err = pr.init()
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid openconfig reader")
+		}
+		basePropReader.reader = &pr
 
 	default:
 		return nil, errors.New("invalid detection type " + stringDetection)
@@ -154,7 +192,13 @@ type baseReader struct {
 	preCondition condition.Condition
 }
 
-func (b *baseReader) GetProperty(ctx context.Context) (value.Value, error) {
+func (b *baseReader) GetProperty(ctx context.Context) (v value.Value, err error) {
+	detection, source := describeReader(b.reader)
+	ctx, trace := explain.StartReader(ctx, detection, source)
+	defer func() {
+		trace.Finish(traceValue(v), err)
+	}()
+
 	if b.preCondition != nil {
 		conditionsMatched, err := b.preCondition.Check(ctx)
 		if err != nil {
@@ -166,10 +210,11 @@ func (b *baseReader) GetProperty(ctx context.Context) (value.Value, error) {
 			return nil, errors.New("pre condition failed")
 		}
 	}
-	v, err := b.reader.GetProperty(ctx)
+	v, err = b.reader.GetProperty(ctx)
 	if err != nil {
 		return nil, err
 	}
+	trace.SetRawValue(traceValue(v))
 	v, err = b.applyOperators(ctx, v)
 	if err != nil {
 		log.Ctx(ctx).Debug().Err(err).Msg("error while applying operators")
@@ -179,6 +224,34 @@ func (b *baseReader) GetProperty(ctx context.Context) (value.Value, error) {
 	return v, nil
 }
 
+// describeReader returns the detection of the reader and the OID, URI, command or path it reads for traces.
+func describeReader(r Reader) (string, string) {
+	switch reader := r.(type) {
+	case *snmpGetReader:
+		return "snmpget", reader.OID.String()
+	case *constantReader:
+		return "constant", ""
+	case *sysObjectIDReader:
+		return "SysObjectID", ""
+	case *sysDescriptionReader:
+		return "SysDescription", ""
+	case *vendorReader:
+		return "Vendor", ""
+	case *modelReader:
+		return "Model", ""
+	case *modelSeriesReader:
+		return "ModelSeries", ""
+	case *httpReader:
+		return "http", reader.URI
+	case *sshReader:
+		return "ssh", reader.Command
+	case *openconfigReader:
+		return "openconfig", reader.Path
+	default:
+		return fmt.Sprintf("%T", r), ""
+	}
+}
+
 func (b *baseReader) applyOperators(ctx context.Context, v value.Value) (value.Value, error) {
 	return b.operators.Apply(ctx, v)
 }
@@ -302,3 +375,164 @@ func (m *modelSeriesReader) GetProperty(ctx context.Context) (value.Value, error
 	}
 	return value.New(*properties.Properties.ModelSeries), nil
 }
+
+type httpReader struct {
+	URI                string `mapstructure:"uri"`
+	extract.Expression `mapstructure:",squash"`
+}
+
+func (h *httpReader) validate() error {
+	if h.URI == "" {
+		return errors.New("uri is missing")
+	}
+	return h.Expression.Validate()
+}
+
+func (h *httpReader) GetProperty(ctx context.Context) (value.Value, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.HTTP == nil || con.HTTP.HTTPClient == nil {
+		return nil, errors.New("http data is missing, http property cannot be read")
+	}
+
+	response, err := con.HTTP.Get(ctx, h.URI)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "http").Msg("http request on uri " + h.URI + " failed")
+		return nil, errors.Wrap(err, "http request failed")
+	}
+
+	v, ok, err := h.FindValue(extract.NewTextNode(string(response.Body())))
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "http").Msg("failed to extract value from http response")
+		return nil, errors.Wrap(err, "failed to extract value from http response")
+	}
+	if !ok {
+		log.Ctx(ctx).Debug().Str("property_reader", "http").Msg("expression did not match http response")
+		return nil, tholaerr.NewNotFoundError("expression did not match http response")
+	}
+	log.Ctx(ctx).Debug().Str("property_reader", "http").Msg("http request successful")
+	return v, nil
+}
+
+// openconfigReader reads a leaf of an OpenConfig model via gNMI or NETCONF.
+type openconfigReader struct {
+	Path string `mapstructure:"path"`
+	// Namespace is the XML namespace of the top level container, which is only needed for NETCONF if the model is not well-known.
+	Namespace string `mapstructure:"namespace"`
+
+	path openconfig.Path
+}
+
+func (o *openconfigReader) init() error {
+	if o.Path == "" {
+		return errors.New("path is missing")
+	}
+	var err error
+	o.path, err = openconfig.ParsePath(o.Path)
+	if err != nil {
+		return errors.Wrap(err, "invalid path")
+	}
+	return nil
+}
+
+func (o *openconfigReader) GetProperty(ctx context.Context) (value.Value, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || !con.HasTelemetryConnection() {
+		return nil, errors.New("gnmi and netconf data is missing, openconfig property cannot be read")
+	}
+
+	tree, err := con.GetOpenConfig(ctx, o.path, o.Namespace)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "openconfig").Msg("failed to get openconfig data of path " + o.Path)
+		return nil, errors.Wrap(err, "failed to get openconfig data")
+	}
+
+	for _, node := range tree.Find(o.path) {
+		if node.IsLeaf() {
+			log.Ctx(ctx).Debug().Str("property_reader", "openconfig").Msg("openconfig request successful")
+			return value.New(node.String()), nil
+		}
+	}
+	log.Ctx(ctx).Debug().Str("property_reader", "openconfig").Msg("no leaf found at path " + o.Path)
+	return nil, tholaerr.NewNotFoundError("no leaf found at path")
+}
+
+// sshReader runs a command via ssh and extracts the property out of its output,
+// either with an expression or with a TextFSM template.
+type sshReader struct {
+	Command            string `mapstructure:"command"`
+	extract.Expression `mapstructure:",squash"`
+	TextFSM            string `mapstructure:"textfsm"`
+	// Value is the name of the TextFSM value that contains the property. The first record is used.
+	Value string `mapstructure:"value"`
+
+	template *textfsm.Template
+}
+
+func (s *sshReader) init() error {
+	if s.Command == "" {
+		return errors.New("command is missing")
+	}
+	if s.TextFSM == "" {
+		return s.Expression.Validate()
+	}
+	if !s.Expression.IsEmpty() {
+		return errors.New("textfsm must not be used together with json_path, xpath or regex")
+	}
+	var err error
+	s.template, err = textfsm.NewTemplate(s.TextFSM)
+	if err != nil {
+		return errors.Wrap(err, "invalid textfsm template")
+	}
+	if !s.template.HasValue(s.Value) {
+		return fmt.Errorf("value '%s' is not defined in textfsm template", s.Value)
+	}
+	return nil
+}
+
+func (s *sshReader) GetProperty(ctx context.Context) (value.Value, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SSH == nil || con.SSH.SSHClient == nil {
+		return nil, errors.New("ssh data is missing, ssh property cannot be read")
+	}
+
+	output, err := con.SSH.SSHClient.Run(ctx, s.Command)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "ssh").Msg("ssh command '" + s.Command + "' failed")
+		return nil, errors.Wrap(err, "ssh command failed")
+	}
+
+	if s.template == nil {
+		v, ok, err := s.FindValue(extract.NewTextNode(output))
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Str("property_reader", "ssh").Msg("failed to extract value from ssh output")
+			return nil, errors.Wrap(err, "failed to extract value from ssh output")
+		}
+		if !ok {
+			log.Ctx(ctx).Debug().Str("property_reader", "ssh").Msg("expression did not match ssh output")
+			return nil, tholaerr.NewNotFoundError("expression did not match ssh output")
+		}
+		return v, nil
+	}
+
+	records, err := s.template.Parse(output)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("property_reader", "ssh").Msg("failed to parse ssh output")
+		return nil, errors.Wrap(err, "failed to parse ssh output")
+	}
+	for _, record := range records {
+		val := record[s.Value]
+		if list, ok := val.([]string); ok {
+			// only the first entry of list values is used
+			if len(list) == 0 {
+				continue
+			}
+			val = list[0]
+		}
+		if v := value.New(val); !v.IsEmpty() {
+			log.Ctx(ctx).Debug().Str("property_reader", "ssh").Msg("ssh command successful")
+			return v, nil
+		}
+	}
+	log.Ctx(ctx).Debug().Str("property_reader", "ssh").Msg("textfsm template did not match ssh output")
+	return nil, tholaerr.NewNotFoundError("textfsm template did not match ssh output")
+}
@@ -0,0 +1,162 @@
+//go:build !client
+// +build !client
+
+// Package deviceclasstest runs identify and all read requests against an SNMP recording without any network communication,
+// so that changes of device classes can be validated against previously recorded results.
+package deviceclasstest
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/google/go-cmp/cmp"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/request"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// GoldenFileExtension is the extension of golden files.
+const GoldenFileExtension = ".golden.json"
+
+// Result contains the responses of all requests of a test, the key is the name of the request (e.g. "read cpu-load").
+// Failed requests are represented by an object which contains the error message.
+type Result map[string]json.RawMessage
+
+type errorResult struct {
+	Error string `json:"error"`
+}
+
+// Run identifies the device of the recording and runs the read requests of all available components.
+// The device cache has to be disabled, otherwise cached results of previous runs may be used.
+func Run(ctx context.Context, rec *snmpsim.Recording) (Result, error) {
+	ctx = network.NewContextWithSNMPClient(ctx, snmpsim.NewSNMPClient(rec, "public"))
+	res := make(Result)
+
+	identify, err := request.ProcessRequest(ctx, &request.IdentifyRequest{BaseRequest: baseRequest()})
+	if err != nil {
+		return nil, errors.Wrap(err, "identify failed")
+	}
+	if err := res.add("identify", identify, nil); err != nil {
+		return nil, err
+	}
+
+	components, err := request.ProcessRequest(ctx, &request.ReadAvailableComponentsRequest{ReadRequest: request.ReadRequest{BaseRequest: baseRequest()}})
+	if err != nil {
+		return nil, errors.Wrap(err, "read available components failed")
+	}
+	if err := res.add("read available-components", components, nil); err != nil {
+		return nil, err
+	}
+
+	available := make(map[string]bool)
+	for _, component := range components.(*request.ReadAvailableComponentsResponse).AvailableComponents {
+		available[component] = true
+	}
+
+	for _, r := range request.ComponentReadRequests {
+		if !available[r.Component] {
+			continue
+		}
+		log.Ctx(ctx).Debug().Str("request", r.Name).Msg("running request")
+		response, err := request.ProcessRequest(ctx, r.New(request.ReadRequest{BaseRequest: baseRequest()}))
+		if err := res.add(r.Name, response, err); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
+func baseRequest() request.BaseRequest {
+	parallelRequests, timeout, retries := 1, 1, 0
+	return request.BaseRequest{
+		DeviceData: request.DeviceData{
+			IPAddress: "127.0.0.1",
+			ConnectionData: network.ConnectionData{
+				SNMP: &network.SNMPConnectionData{
+					Communities:              []string{"public"},
+					Versions:                 []string{"2c"},
+					Ports:                    []int{161},
+					DiscoverParallelRequests: &parallelRequests,
+					DiscoverTimeout:          &timeout,
+					DiscoverRetries:          &retries,
+				},
+			},
+		},
+	}
+}
+
+func (r Result) add(name string, response request.Response, err error) error {
+	var v interface{} = response
+	if err != nil {
+		v = errorResult{Error: err.Error()}
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal response of '%s'", name)
+	}
+	r[name] = b
+	return nil
+}
+
+// Diff returns the differences between the expected and the actual result in a human readable form.
+// An empty string is returned if the results are equal.
+func Diff(expected, actual Result) (string, error) {
+	e, err := normalize(expected)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode expected result")
+	}
+	a, err := normalize(actual)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode actual result")
+	}
+	return cmp.Diff(e, a), nil
+}
+
+// normalize decodes the result, so that the formatting of the JSON does not matter.
+func normalize(r Result) (map[string]interface{}, error) {
+	res := make(map[string]interface{}, len(r))
+	for name, raw := range r {
+		var v interface{}
+		if err := json.Unmarshal(raw, &v); err != nil {
+			return nil, errors.Wrapf(err, "invalid result of '%s'", name)
+		}
+		res[name] = v
+	}
+	return res, nil
+}
+
+// GoldenFile returns the default golden file of a recording, which is located next to it,
+// e.g. "public.golden.json" for "public.snmprec".
+func GoldenFile(recording string) string {
+	return strings.TrimSuffix(recording, filepath.Ext(recording)) + GoldenFileExtension
+}
+
+// ReadGoldenFile reads the expected result from a golden file.
+func ReadGoldenFile(file string) (Result, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read golden file")
+	}
+	var res Result
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode golden file '%s'", file)
+	}
+	return res, nil
+}
+
+// WriteGoldenFile writes the result to a golden file.
+func WriteGoldenFile(file string, r Result) error {
+	b, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal result")
+	}
+	if err := ioutil.WriteFile(file, append(b, '\n'), 0644); err != nil {
+		return errors.Wrap(err, "failed to write golden file")
+	}
+	return nil
+}
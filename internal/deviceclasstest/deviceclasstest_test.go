@@ -0,0 +1,95 @@
+//go:build !client
+// +build !client
+
+package deviceclasstest
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	viper.Set("db.no-cache", true)
+
+	rec, err := snmpsim.ReadRecordingFile("../../test/testdata/devices/ios/7206VXR/public.snmprec")
+	if !assert.NoError(t, err) {
+		return
+	}
+	res, err := Run(context.Background(), rec)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var identify struct {
+		Class string `json:"class"`
+	}
+	if assert.NoError(t, json.Unmarshal(res["identify"], &identify)) {
+		assert.Equal(t, "ios", identify.Class)
+	}
+	assert.Contains(t, res, "read interfaces")
+	assert.Contains(t, res, "read cpu-load")
+	assert.NotContains(t, res, "read ups")
+
+	file := filepath.Join(t.TempDir(), "public"+GoldenFileExtension)
+	if !assert.NoError(t, WriteGoldenFile(file, res)) {
+		return
+	}
+	expected, err := ReadGoldenFile(file)
+	if !assert.NoError(t, err) {
+		return
+	}
+	diff, err := Diff(expected, res)
+	if assert.NoError(t, err) {
+		assert.Empty(t, diff)
+	}
+
+	expected["read count-interfaces"] = json.RawMessage(`{"count": 4}`)
+	diff, err = Diff(expected, res)
+	if assert.NoError(t, err) {
+		assert.Contains(t, diff, "count")
+	}
+}
+
+func TestGoldenFile(t *testing.T) {
+	assert.Equal(t, "devices/ios/public.golden.json", GoldenFile("devices/ios/public.snmprec"))
+	assert.Equal(t, "device.golden.json", GoldenFile("device.walk"))
+}
+
+func TestGoldenFiles(t *testing.T) {
+	viper.Set("db.no-cache", true)
+
+	recordings, err := filepath.Glob("../../test/testdata/devices/*/*/*.snmprec")
+	if !assert.NoError(t, err) {
+		return
+	}
+	for _, recording := range recordings {
+		golden := GoldenFile(recording)
+		if _, err := os.Stat(golden); err != nil {
+			continue
+		}
+		t.Run(recording, func(t *testing.T) {
+			rec, err := snmpsim.ReadRecordingFile(recording)
+			if !assert.NoError(t, err) {
+				return
+			}
+			res, err := Run(context.Background(), rec)
+			if !assert.NoError(t, err) {
+				return
+			}
+			expected, err := ReadGoldenFile(golden)
+			if !assert.NoError(t, err) {
+				return
+			}
+			diff, err := Diff(expected, res)
+			if assert.NoError(t, err) {
+				assert.Empty(t, diff, "results differ from %s (-expected +actual), run 'thola test-deviceclass --update %s' if the changes are intended", golden, recording)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+// Package encryption encrypts data at rest, e.g. cached connection data and credential files.
+//
+// Data is encrypted with AES-256-GCM. The key is derived from a passphrase with scrypt.
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/scrypt"
+	"io"
+)
+
+// SaltSize is the size of the salts that are generated by NewSalt.
+const SaltSize = 16
+
+// Cipher encrypts and decrypts data with a key derived from a passphrase.
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+// NewCipher returns a cipher with a key that is derived from the passphrase and the salt.
+// Deriving the key is expensive on purpose, so a cipher should be reused.
+func NewCipher(passphrase string, salt []byte) (*Cipher, error) {
+	if passphrase == "" {
+		return nil, errors.New("empty passphrase")
+	}
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to derive key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create cipher")
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gcm")
+	}
+	return &Cipher{aead: aead}, nil
+}
+
+// NewSalt returns a random salt.
+func NewSalt() ([]byte, error) {
+	salt := make([]byte, SaltSize)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return nil, errors.Wrap(err, "failed to generate salt")
+	}
+	return salt, nil
+}
+
+// Encrypt encrypts the data. The random nonce is prepended to the result.
+func (c *Cipher) Encrypt(data []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, errors.Wrap(err, "failed to generate nonce")
+	}
+	return c.aead.Seal(nonce, nonce, data, nil), nil
+}
+
+// Decrypt decrypts data that was encrypted with Encrypt.
+// It returns an error if the data was encrypted with another key or was modified.
+func (c *Cipher) Decrypt(data []byte) ([]byte, error) {
+	if len(data) < c.aead.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, ciphertext := data[:c.aead.NonceSize()], data[c.aead.NonceSize():]
+	res, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.New("failed to decrypt data, the key is wrong or the data was modified")
+	}
+	return res, nil
+}
@@ -0,0 +1,41 @@
+package encryption
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCipher(t *testing.T) {
+	salt, err := NewSalt()
+	if !assert.NoError(t, err) {
+		return
+	}
+	c, err := NewCipher("secret", salt)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	encrypted, err := c.Encrypt([]byte("public"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NotContains(t, string(encrypted), "public")
+
+	decrypted, err := c.Decrypt(encrypted)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "public", string(decrypted))
+	}
+
+	other, err := NewCipher("other", salt)
+	if assert.NoError(t, err) {
+		_, err = other.Decrypt(encrypted)
+		assert.Error(t, err)
+	}
+
+	encrypted[len(encrypted)-1] ^= 1
+	_, err = c.Decrypt(encrypted)
+	assert.Error(t, err)
+
+	_, err = NewCipher("", salt)
+	assert.Error(t, err)
+}
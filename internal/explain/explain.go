@@ -0,0 +1,273 @@
+// Package explain records how the device class of a device was identified and how its properties were read,
+// so that it can be shown why a device class was chosen or where a value came from without reading the trace log.
+//
+// Explanations and traces are passed through the context. All functions and methods can be used without an
+// explanation or trace in the context, in this case nothing is recorded.
+package explain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type ctxKey byte
+
+const nodeKey ctxKey = iota + 1
+
+// DeviceClass
+//
+// DeviceClass is the explanation of a device class that was checked during identification.
+//
+// swagger:model
+type DeviceClass struct {
+	// The name of the device class
+	//
+	// example: ceraos/ip10
+	Name string `json:"name" xml:"name"`
+	// If the device class matched
+	Matched bool `json:"matched" xml:"matched"`
+	// If the device class was checked after the other device classes,
+	// because its conditions send requests that are only used for this device class
+	TryToMatchLast bool `json:"try_to_match_last,omitempty" xml:"try_to_match_last,omitempty"`
+	// The error that occurred while checking the device class
+	Error string `json:"error,omitempty" xml:"error,omitempty"`
+	// Decisions that were made after checking the device class
+	Note string `json:"note,omitempty" xml:"note,omitempty"`
+	// The match conditions of the device class
+	Condition *Condition `json:"condition,omitempty" xml:"condition,omitempty"`
+	// The sub device classes that were checked in the order they were checked
+	SubDeviceClasses []*DeviceClass `json:"sub_device_classes,omitempty" xml:"sub_device_classes>device_class,omitempty"`
+}
+
+// Condition
+//
+// Condition is the explanation of a condition that was evaluated during identification.
+//
+// swagger:model
+type Condition struct {
+	// The type of the condition
+	//
+	// example: SysObjectID
+	Type string `json:"type,omitempty" xml:"type,omitempty"`
+	// The logical operator of a condition set
+	//
+	// example: OR
+	LogicalOperator string `json:"logical_operator,omitempty" xml:"logical_operator,omitempty"`
+	// The match mode of the condition
+	//
+	// example: startsWith
+	MatchMode string `json:"match_mode,omitempty" xml:"match_mode,omitempty"`
+	// The OID that was read
+	OID string `json:"oid,omitempty" xml:"oid,omitempty"`
+	// The URI that was requested
+	URI string `json:"uri,omitempty" xml:"uri,omitempty"`
+	// The command that was run
+	Command string `json:"command,omitempty" xml:"command,omitempty"`
+	// The values the value read from the device is matched against
+	Values []string `json:"values,omitempty" xml:"values>value,omitempty"`
+	// The value that was read from the device
+	Value *string `json:"value,omitempty" xml:"value,omitempty"`
+	// If the condition matched
+	Matched bool `json:"matched" xml:"matched"`
+	// Why the condition was not evaluated
+	Message string `json:"message,omitempty" xml:"message,omitempty"`
+	// The error that occurred while evaluating the condition
+	Error string `json:"error,omitempty" xml:"error,omitempty"`
+	// The conditions of a condition set in the order they were evaluated
+	Conditions []*Condition `json:"conditions,omitempty" xml:"conditions>condition,omitempty"`
+}
+
+// node is the position in the explanation that new device classes and conditions are added to.
+type node struct {
+	deviceClass *DeviceClass
+	condition   *Condition
+}
+
+// NewContextWithExplanation returns a new context that records the identification in the given device class,
+// which is the device class the identification starts with.
+func NewContextWithExplanation(ctx context.Context, root *DeviceClass) context.Context {
+	return context.WithValue(ctx, nodeKey, node{deviceClass: root})
+}
+
+func nodeFromContext(ctx context.Context) (node, bool) {
+	n, ok := ctx.Value(nodeKey).(node)
+	return n, ok
+}
+
+// StartDeviceClass adds a sub device class to the device class of the context and returns a context
+// which records the conditions and sub device classes of the new device class.
+// It returns nil if nothing is recorded.
+func StartDeviceClass(ctx context.Context, name string, tryToMatchLast bool) (context.Context, *DeviceClass) {
+	n, ok := nodeFromContext(ctx)
+	if !ok || n.deviceClass == nil || n.condition != nil {
+		return ctx, nil
+	}
+	d := &DeviceClass{
+		Name:           name,
+		TryToMatchLast: tryToMatchLast,
+	}
+	n.deviceClass.SubDeviceClasses = append(n.deviceClass.SubDeviceClasses, d)
+	return context.WithValue(ctx, nodeKey, node{deviceClass: d}), d
+}
+
+// StartCondition adds the condition to the device class or condition set of the context and returns a context
+// which records the conditions of the new condition. It returns nil if nothing is recorded.
+func StartCondition(ctx context.Context, c Condition) (context.Context, *Condition) {
+	n, ok := nodeFromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	res := &c
+	switch {
+	case n.condition != nil:
+		n.condition.Conditions = append(n.condition.Conditions, res)
+	case n.deviceClass != nil && n.deviceClass.Condition == nil:
+		n.deviceClass.Condition = res
+	default:
+		// conditions which are not part of the match conditions, e.g. of property readers, are not recorded
+		return ctx, nil
+	}
+	return context.WithValue(ctx, nodeKey, node{condition: res}), res
+}
+
+// ConditionFromContext returns the condition which is currently evaluated, or nil if nothing is recorded.
+func ConditionFromContext(ctx context.Context) *Condition {
+	n, _ := nodeFromContext(ctx)
+	return n.condition
+}
+
+// Finish sets the result of the device class.
+func (d *DeviceClass) Finish(matched bool, err error) {
+	if d == nil {
+		return
+	}
+	d.Matched = matched
+	if err != nil {
+		d.Error = err.Error()
+	}
+}
+
+// SetNote sets a decision that was made after checking the device class.
+func (d *DeviceClass) SetNote(note string) {
+	if d == nil {
+		return
+	}
+	d.Note = note
+}
+
+// Finish sets the result of the condition.
+func (c *Condition) Finish(matched bool, err error) {
+	if c == nil {
+		return
+	}
+	c.Matched = matched
+	if err != nil {
+		c.Error = err.Error()
+	}
+}
+
+// SetMessage sets why the condition was not evaluated.
+func (c *Condition) SetMessage(message string) {
+	if c == nil {
+		return
+	}
+	c.Message = message
+}
+
+// SetValue sets the value that was read from the device.
+func (c *Condition) SetValue(value string) {
+	if c == nil {
+		return
+	}
+	c.Value = &value
+}
+
+// String returns the explanation as a tree.
+func (d *DeviceClass) String() string {
+	var b strings.Builder
+	d.write(&b, "", "")
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (d *DeviceClass) write(b *strings.Builder, prefix, childPrefix string) {
+	b.WriteString(prefix + d.Name + ": " + result(d.Matched, d.Error))
+	if d.TryToMatchLast {
+		b.WriteString(" (checked last)")
+	}
+	b.WriteString("\n")
+
+	var children []func(prefix, childPrefix string)
+	if d.Condition != nil {
+		children = append(children, func(prefix, childPrefix string) {
+			d.Condition.write(b, prefix, childPrefix)
+		})
+	}
+	for _, sub := range d.SubDeviceClasses {
+		sub := sub
+		children = append(children, func(prefix, childPrefix string) {
+			sub.write(b, prefix, childPrefix)
+		})
+	}
+	if d.Note != "" {
+		children = append(children, func(prefix, _ string) {
+			b.WriteString(prefix + "=> " + d.Note + "\n")
+		})
+	}
+	writeChildren(childPrefix, children)
+}
+
+func (c *Condition) write(b *strings.Builder, prefix, childPrefix string) {
+	b.WriteString(prefix)
+	if c.LogicalOperator != "" {
+		b.WriteString(c.LogicalOperator)
+	} else {
+		b.WriteString(c.Type)
+		for _, detail := range []string{c.OID, c.URI, c.Command} {
+			if detail != "" {
+				b.WriteString(" " + detail)
+			}
+		}
+		if c.MatchMode != "" {
+			fmt.Fprintf(b, " %s %q", c.MatchMode, c.Values)
+		}
+	}
+	b.WriteString(": ")
+	if c.Value != nil {
+		fmt.Fprintf(b, "value %q, ", *c.Value)
+	}
+	if c.Message != "" {
+		b.WriteString(c.Message + ", ")
+	}
+	b.WriteString(result(c.Matched, c.Error) + "\n")
+
+	var children []func(prefix, childPrefix string)
+	for _, sub := range c.Conditions {
+		sub := sub
+		children = append(children, func(prefix, childPrefix string) {
+			sub.write(b, prefix, childPrefix)
+		})
+	}
+	writeChildren(childPrefix, children)
+}
+
+// writeChildren writes the children of a node with the prefixes that connect them in the tree.
+func writeChildren(prefix string, children []func(prefix, childPrefix string)) {
+	for i, child := range children {
+		if i == len(children)-1 {
+			child(prefix+"└─ ", prefix+"   ")
+		} else {
+			child(prefix+"├─ ", prefix+"│  ")
+		}
+	}
+}
+
+func result(matched bool, err string) string {
+	if err != "" {
+		return "error: " + err
+	}
+	if matched {
+		return "matched"
+	}
+	return "no match"
+}
@@ -0,0 +1,162 @@
+package explain
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestStartDeviceClass(t *testing.T) {
+	root := &DeviceClass{Name: "generic", Matched: true}
+	ctx := NewContextWithExplanation(context.Background(), root)
+
+	classCtx, ios := StartDeviceClass(ctx, "ios", false)
+	setCtx, set := StartCondition(classCtx, Condition{LogicalOperator: "OR"})
+	conditionCtx, condition := StartCondition(setCtx, Condition{Type: "SysObjectID", MatchMode: "startsWith", Values: []string{".1.3.6.1.4.1.9."}})
+	ConditionFromContext(conditionCtx).SetValue(".1.3.6.1.4.1.9.1.222")
+	condition.Finish(true, nil)
+	set.Finish(true, nil)
+	ios.Finish(true, nil)
+	ios.SetNote("device class is used")
+
+	_, junos := StartDeviceClass(ctx, "junos", true)
+	junos.Finish(false, errors.New("timeout"))
+
+	assert.Equal(t, `generic: matched
+├─ ios: matched
+│  ├─ OR: matched
+│  │  └─ SysObjectID startsWith [".1.3.6.1.4.1.9."]: value ".1.3.6.1.4.1.9.1.222", matched
+│  └─ => device class is used
+└─ junos: error: timeout (checked last)`, root.String())
+}
+
+func TestWithoutExplanation(t *testing.T) {
+	ctx := context.Background()
+
+	ctx, deviceClass := StartDeviceClass(ctx, "ios", false)
+	assert.Nil(t, deviceClass)
+	deviceClass.Finish(true, nil)
+	deviceClass.SetNote("note")
+
+	ctx, condition := StartCondition(ctx, Condition{Type: "SysObjectID"})
+	assert.Nil(t, condition)
+	assert.Nil(t, ConditionFromContext(ctx))
+	condition.SetValue("value")
+	condition.SetMessage("message")
+	condition.Finish(false, nil)
+
+	ctx, property := StartProperty(ctx, "Vendor")
+	assert.Nil(t, property)
+	property.UseCodeCommunicator(nil)
+
+	ctx, reader := StartReader(ctx, "constant", "")
+	assert.Nil(t, reader)
+	reader.SetRawValue("value")
+	reader.Finish("value", nil)
+
+	oid := StartOID(WithLabel(ctx, "ifDescr"), "1.3.6.1.2.1.2.2.1.2")
+	assert.Nil(t, oid)
+	oid.SetError(errors.New("error"))
+	ctx, response := oid.StartResponse(ctx, "1.3.6.1.2.1.2.2.1.2.1", "value")
+	assert.Nil(t, response)
+	response.Finish("value", nil)
+
+	_, operator := StartOperator(ctx, "modify toUpperCase", "value")
+	assert.Nil(t, operator)
+	operator.Finish("VALUE", nil)
+}
+
+func TestTrace(t *testing.T) {
+	trace := &Trace{}
+	ctx := NewContextWithTrace(context.Background(), trace)
+
+	// conditions are only recorded for identification
+	_, condition := StartCondition(ctx, Condition{Type: "SysObjectID"})
+	assert.Nil(t, condition)
+
+	propertyCtx, property := StartProperty(ctx, "Model")
+	readerCtx, reader := StartReader(propertyCtx, "snmpget", "1.3.6.1.2.1.47.1.1.1.1.13.1")
+	reader.SetRawValue("CISCO7206VXR")
+	switchCtx, switchOperator := StartOperator(readerCtx, "switch equals", "CISCO7206VXR")
+	_, caseOperator := StartOperator(switchCtx, "modify regexReplace", "CISCO7206VXR")
+	caseOperator.Finish("7206VXR", nil)
+	switchOperator.Finish("7206VXR", nil)
+	reader.Finish("7206VXR", nil)
+
+	_, codeProperty := StartProperty(ctx, "CPUComponentCPULoad")
+	codeProperty.UseCodeCommunicator(nil)
+
+	interfacesCtx, _ := StartProperty(ctx, "Interfaces")
+	labelCtx := WithLabel(WithLabel(interfacesCtx, "ethernet_like"), "dot3StatsAlignmentErrors")
+	oid := StartOID(labelCtx, "1.3.6.1.2.1.10.7.2.1.2")
+	responseCtx, response := oid.StartResponse(labelCtx, ".1.3.6.1.2.1.10.7.2.1.2.1", "0")
+	_, operator := StartOperator(responseCtx, `filter equals "0"`, "0")
+	operator.Finish("", errors.New("value didn't match"))
+	response.Finish("", errors.New("operator failed"))
+	StartOID(interfacesCtx, "1.3.6.1.2.1.2.2.1.2").SetError(errors.New("timeout"))
+
+	value := "7206VXR"
+	raw := "CISCO7206VXR"
+	assert.Equal(t, &Trace{
+		Properties: []*Property{
+			{
+				Name: "Model",
+				Readers: []*Reader{
+					{
+						Detection: "snmpget",
+						Source:    "1.3.6.1.2.1.47.1.1.1.1.13.1",
+						RawValue:  &raw,
+						Operators: []*Operator{
+							{
+								Operator: "switch equals",
+								Input:    raw,
+								Output:   &value,
+								Operators: []*Operator{
+									{
+										Operator: "modify regexReplace",
+										Input:    raw,
+										Output:   &value,
+									},
+								},
+							},
+						},
+						Value: &value,
+					},
+				},
+			},
+			{
+				Name:             "CPUComponentCPULoad",
+				CodeCommunicator: true,
+			},
+			{
+				Name: "Interfaces",
+				OIDs: []*OID{
+					{
+						Label: "ethernet_like/dot3StatsAlignmentErrors",
+						OID:   "1.3.6.1.2.1.10.7.2.1.2",
+						Responses: []*Response{
+							{
+								OID:      ".1.3.6.1.2.1.10.7.2.1.2.1",
+								RawValue: "0",
+								Operators: []*Operator{
+									{
+										Operator: `filter equals "0"`,
+										Input:    "0",
+										Error:    "value didn't match",
+									},
+								},
+								Error: "operator failed",
+							},
+						},
+					},
+					{
+						OID:   "1.3.6.1.2.1.2.2.1.2",
+						Error: "timeout",
+					},
+				},
+			},
+		},
+	}, trace)
+	assert.Equal(t, property, trace.Properties[0])
+}
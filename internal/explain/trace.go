@@ -0,0 +1,292 @@
+package explain
+
+import (
+	"context"
+	"strings"
+)
+
+const traceKey ctxKey = nodeKey + 1
+
+// Trace
+//
+// Trace records how the properties of a request were read.
+//
+// swagger:model
+type Trace struct {
+	// The properties in the order they were read
+	Properties []*Property `json:"properties,omitempty" xml:"properties>property,omitempty"`
+}
+
+// Property
+//
+// Property is the trace of a single property, e.g. the vendor or the CPU load.
+//
+// swagger:model
+type Property struct {
+	// The name of the property
+	//
+	// example: Vendor
+	Name string `json:"name" xml:"name"`
+	// If the value of the code communicator of the device class was used instead of the device class files
+	CodeCommunicator bool `json:"code_communicator,omitempty" xml:"code_communicator,omitempty"`
+	// The error of the code communicator
+	Error string `json:"error,omitempty" xml:"error,omitempty"`
+	// The property readers that were tried in the order of the fallback list
+	Readers []*Reader `json:"readers,omitempty" xml:"readers>reader,omitempty"`
+	// The OIDs that were read for group properties, e.g. components
+	OIDs []*OID `json:"oids,omitempty" xml:"oids>oid,omitempty"`
+}
+
+// Reader
+//
+// Reader is the trace of a property reader.
+//
+// swagger:model
+type Reader struct {
+	// The detection of the property reader
+	//
+	// example: snmpget
+	Detection string `json:"detection" xml:"detection"`
+	// The OID, URI, command or path that was read
+	Source string `json:"source,omitempty" xml:"source,omitempty"`
+	// The value that was read before the operators were applied
+	RawValue *string `json:"raw_value,omitempty" xml:"raw_value,omitempty"`
+	// The operators in the order they were applied
+	Operators []*Operator `json:"operators,omitempty" xml:"operators>operator,omitempty"`
+	// The value of the property reader
+	Value *string `json:"value,omitempty" xml:"value,omitempty"`
+	// Why the property reader did not return a value
+	Error string `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// OID
+//
+// OID is the trace of an OID of a group property.
+//
+// swagger:model
+type OID struct {
+	// The label of the value in the group property
+	//
+	// example: ifDescr
+	Label string `json:"label,omitempty" xml:"label,omitempty"`
+	// The OID that was read
+	OID string `json:"oid" xml:"oid"`
+	// The responses of the OID
+	Responses []*Response `json:"responses,omitempty" xml:"responses>response,omitempty"`
+	// Why the OID was not read
+	Error string `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// Response
+//
+// Response is the trace of an SNMP response of an OID of a group property.
+//
+// swagger:model
+type Response struct {
+	// The OID of the response
+	OID string `json:"oid" xml:"oid"`
+	// The value of the response before the operators were applied
+	RawValue string `json:"raw_value" xml:"raw_value"`
+	// The operators in the order they were applied
+	Operators []*Operator `json:"operators,omitempty" xml:"operators>operator,omitempty"`
+	// The value after the operators were applied
+	Value *string `json:"value,omitempty" xml:"value,omitempty"`
+	// Why the response was not used
+	Error string `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// Operator
+//
+// Operator is the trace of an operator that was applied to a value.
+//
+// swagger:model
+type Operator struct {
+	// The operator and its configuration
+	//
+	// example: modify regexSubmatch
+	Operator string `json:"operator" xml:"operator"`
+	// The value before the operator was applied
+	Input string `json:"input" xml:"input"`
+	// The value after the operator was applied
+	Output *string `json:"output,omitempty" xml:"output,omitempty"`
+	// The error of the operator
+	Error string `json:"error,omitempty" xml:"error,omitempty"`
+	// The operators of a switch case that were applied
+	Operators []*Operator `json:"operators,omitempty" xml:"operators>operator,omitempty"`
+}
+
+// traceNode is the position in the trace that new elements are added to.
+type traceNode struct {
+	trace    *Trace
+	property *Property
+	labels   []string
+	reader   *Reader
+	response *Response
+	operator *Operator
+}
+
+// NewContextWithTrace returns a new context that records how properties are read in the given trace.
+func NewContextWithTrace(ctx context.Context, trace *Trace) context.Context {
+	return context.WithValue(ctx, traceKey, traceNode{trace: trace})
+}
+
+func traceNodeFromContext(ctx context.Context) (traceNode, bool) {
+	n, ok := ctx.Value(traceKey).(traceNode)
+	return n, ok
+}
+
+// StartProperty adds a property to the trace of the context and returns a context which records how it is read.
+// It returns nil if nothing is recorded.
+func StartProperty(ctx context.Context, name string) (context.Context, *Property) {
+	n, ok := traceNodeFromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	p := &Property{
+		Name: name,
+	}
+	n.trace.Properties = append(n.trace.Properties, p)
+	return context.WithValue(ctx, traceKey, traceNode{trace: n.trace, property: p}), p
+}
+
+// UseCodeCommunicator records that the code communicator returned the value or the error of the property.
+func (p *Property) UseCodeCommunicator(err error) {
+	if p == nil {
+		return
+	}
+	p.CodeCommunicator = true
+	if err != nil {
+		p.Error = err.Error()
+	}
+}
+
+// StartReader adds a property reader to the property of the context and returns a context
+// which records the operators of the reader. It returns nil if nothing is recorded.
+func StartReader(ctx context.Context, detection, source string) (context.Context, *Reader) {
+	n, ok := traceNodeFromContext(ctx)
+	if !ok || n.property == nil {
+		return ctx, nil
+	}
+	r := &Reader{
+		Detection: detection,
+		Source:    source,
+	}
+	n.property.Readers = append(n.property.Readers, r)
+	return context.WithValue(ctx, traceKey, traceNode{trace: n.trace, property: n.property, reader: r}), r
+}
+
+// SetRawValue sets the value that was read before the operators were applied.
+func (r *Reader) SetRawValue(value string) {
+	if r == nil {
+		return
+	}
+	r.RawValue = &value
+}
+
+// Finish sets the result of the property reader.
+func (r *Reader) Finish(value string, err error) {
+	if r == nil {
+		return
+	}
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+	r.Value = &value
+}
+
+// WithLabel returns a context in which the OIDs of group properties are recorded with the label
+// appended to the labels of the context.
+func WithLabel(ctx context.Context, label string) context.Context {
+	n, ok := traceNodeFromContext(ctx)
+	if !ok || n.property == nil {
+		return ctx
+	}
+	n.labels = append(n.labels[:len(n.labels):len(n.labels)], label)
+	return context.WithValue(ctx, traceKey, n)
+}
+
+// StartOID adds an OID of a group property to the property of the context. It returns nil if nothing is recorded.
+func StartOID(ctx context.Context, oid string) *OID {
+	n, ok := traceNodeFromContext(ctx)
+	if !ok || n.property == nil {
+		return nil
+	}
+	o := &OID{
+		Label: strings.Join(n.labels, "/"),
+		OID:   oid,
+	}
+	n.property.OIDs = append(n.property.OIDs, o)
+	return o
+}
+
+// SetError sets why the OID was not read.
+func (o *OID) SetError(err error) {
+	if o == nil || err == nil {
+		return
+	}
+	o.Error = err.Error()
+}
+
+// StartResponse adds a response to the OID and returns a context which records the operators of the response.
+// It returns nil if nothing is recorded.
+func (o *OID) StartResponse(ctx context.Context, oid, rawValue string) (context.Context, *Response) {
+	n, ok := traceNodeFromContext(ctx)
+	if o == nil || !ok {
+		return ctx, nil
+	}
+	r := &Response{
+		OID:      oid,
+		RawValue: rawValue,
+	}
+	o.Responses = append(o.Responses, r)
+	return context.WithValue(ctx, traceKey, traceNode{trace: n.trace, property: n.property, labels: n.labels, response: r}), r
+}
+
+// Finish sets the result of the response.
+func (r *Response) Finish(value string, err error) {
+	if r == nil {
+		return
+	}
+	if err != nil {
+		r.Error = err.Error()
+		return
+	}
+	r.Value = &value
+}
+
+// StartOperator adds an operator to the reader, response or switch operator of the context and returns a context
+// which records the operators of switch cases. It returns nil if nothing is recorded.
+func StartOperator(ctx context.Context, operator, input string) (context.Context, *Operator) {
+	n, ok := traceNodeFromContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	o := &Operator{
+		Operator: operator,
+		Input:    input,
+	}
+	switch {
+	case n.operator != nil:
+		n.operator.Operators = append(n.operator.Operators, o)
+	case n.response != nil:
+		n.response.Operators = append(n.response.Operators, o)
+	case n.reader != nil:
+		n.reader.Operators = append(n.reader.Operators, o)
+	default:
+		return ctx, nil
+	}
+	return context.WithValue(ctx, traceKey, traceNode{trace: n.trace, property: n.property, labels: n.labels, operator: o}), o
+}
+
+// Finish sets the result of the operator.
+func (o *Operator) Finish(output string, err error) {
+	if o == nil {
+		return
+	}
+	if err != nil {
+		o.Error = err.Error()
+		return
+	}
+	o.Output = &output
+}
@@ -0,0 +1,295 @@
+package exporter
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/request"
+	"github.com/prometheus/client_golang/prometheus"
+	"strconv"
+)
+
+const hardwareHealthStateHelp = " (0 = initial, 1 = normal, 2 = warning, 3 = critical, 4 = shutdown, 5 = not present, 6 = not functioning, 7 = unknown)."
+
+// ifMIBStatus returns the numeric value of the status as defined in the IF-MIB.
+func ifMIBStatus(status device.Status) float64 {
+	switch status {
+	case device.StatusUp:
+		return 1
+	case device.StatusDown:
+		return 2
+	case device.StatusTesting:
+		return 3
+	case device.StatusDormant:
+		return 5
+	case device.StatusNotPresent:
+		return 6
+	case device.StatusLowerLayerDown:
+		return 7
+	}
+	return 4
+}
+
+func collectInterfaces(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadInterfacesResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+
+	for _, interf := range r.Interfaces {
+		var ifIndex string
+		if interf.IfIndex != nil {
+			ifIndex = strconv.FormatUint(*interf.IfIndex, 10)
+		}
+		labels := []labelPair{
+			newLabel("ifIndex", ifIndex),
+			label("ifName", interf.IfName),
+			label("ifDescr", interf.IfDescr),
+			label("ifAlias", interf.IfAlias),
+		}
+
+		if interf.IfAdminStatus != nil {
+			m.Add(namespace+"interface_admin_status", "Admin status of the interface as defined in the IF-MIB (1 = up, 2 = down, 3 = testing).", prometheus.GaugeValue, ifMIBStatus(*interf.IfAdminStatus), labels...)
+		}
+		if interf.IfOperStatus != nil {
+			m.Add(namespace+"interface_oper_status", "Operational status of the interface as defined in the IF-MIB (1 = up, 2 = down, 3 = testing, 4 = unknown, 5 = dormant, 6 = not present, 7 = lower layer down).", prometheus.GaugeValue, ifMIBStatus(*interf.IfOperStatus), labels...)
+		}
+
+		if interf.IfHighSpeed != nil && *interf.IfHighSpeed != 0 {
+			m.Add(namespace+"interface_speed_bits_per_second", "Speed of the interface in bits per second.", prometheus.GaugeValue, float64(*interf.IfHighSpeed)*1000000, labels...)
+		} else {
+			addIfNotNilUint(m, "interface_speed_bits_per_second", "Speed of the interface in bits per second.", prometheus.GaugeValue, interf.IfSpeed, labels...)
+		}
+		addIfNotNilUint(m, "interface_mtu_bytes", "MTU of the interface in bytes.", prometheus.GaugeValue, interf.IfMtu, labels...)
+
+		addIfNotNilUint(m, "interface_in_octets_total", "Number of octets received on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCInOctets, interf.IfInOctets), labels...)
+		addIfNotNilUint(m, "interface_out_octets_total", "Number of octets transmitted on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCOutOctets, interf.IfOutOctets), labels...)
+		addIfNotNilUint(m, "interface_in_unicast_packets_total", "Number of unicast packets received on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCInUcastPkts, interf.IfInUcastPkts), labels...)
+		addIfNotNilUint(m, "interface_out_unicast_packets_total", "Number of unicast packets transmitted on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCOutUcastPkts, interf.IfOutUcastPkts), labels...)
+		addIfNotNilUint(m, "interface_in_multicast_packets_total", "Number of multicast packets received on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCInMulticastPkts, interf.IfInMulticastPkts), labels...)
+		addIfNotNilUint(m, "interface_out_multicast_packets_total", "Number of multicast packets transmitted on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCOutMulticastPkts, interf.IfOutMulticastPkts), labels...)
+		addIfNotNilUint(m, "interface_in_broadcast_packets_total", "Number of broadcast packets received on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCInBroadcastPkts, interf.IfInBroadcastPkts), labels...)
+		addIfNotNilUint(m, "interface_out_broadcast_packets_total", "Number of broadcast packets transmitted on the interface.", prometheus.CounterValue, device.PreferHCCounter(interf.IfHCOutBroadcastPkts, interf.IfOutBroadcastPkts), labels...)
+		addIfNotNilUint(m, "interface_in_errors_total", "Number of inbound packets with errors.", prometheus.CounterValue, interf.IfInErrors, labels...)
+		addIfNotNilUint(m, "interface_out_errors_total", "Number of outbound packets with errors.", prometheus.CounterValue, interf.IfOutErrors, labels...)
+		addIfNotNilUint(m, "interface_in_discards_total", "Number of discarded inbound packets.", prometheus.CounterValue, interf.IfInDiscards, labels...)
+		addIfNotNilUint(m, "interface_out_discards_total", "Number of discarded outbound packets.", prometheus.CounterValue, interf.IfOutDiscards, labels...)
+
+		// the optical components of an interface can report the same power values,
+		// only the first available value is used so that every interface has a single sample
+		var rxPower, txPower *float64
+		setPower := func(rx, tx *float64) {
+			if rxPower == nil {
+				rxPower = rx
+			}
+			if txPower == nil {
+				txPower = tx
+			}
+		}
+		if interf.DWDM != nil {
+			setPower(interf.DWDM.RXPower, interf.DWDM.TXPower)
+		}
+		if interf.OpticalTransponder != nil {
+			setPower(interf.OpticalTransponder.RXPower, interf.OpticalTransponder.TXPower)
+		}
+		if interf.OpticalAmplifier != nil {
+			setPower(interf.OpticalAmplifier.RXPower, interf.OpticalAmplifier.TXPower)
+			addIfNotNilFloat(m, "interface_gain_db", "Gain of the optical amplifier in dB.", prometheus.GaugeValue, interf.OpticalAmplifier.Gain, labels...)
+		}
+		if interf.OpticalOPM != nil {
+			setPower(interf.OpticalOPM.RXPower, nil)
+		}
+		addIfNotNilFloat(m, "interface_rx_power_dbm", "Optical receive power of the interface in dBm.", prometheus.GaugeValue, rxPower, labels...)
+		addIfNotNilFloat(m, "interface_tx_power_dbm", "Optical transmit power of the interface in dBm.", prometheus.GaugeValue, txPower, labels...)
+		if interf.Radio != nil {
+			addIfNotNilFloat(m, "interface_radio_level_in_dbm", "Inbound radio level of the interface in dBm.", prometheus.GaugeValue, interf.Radio.LevelIn, labels...)
+			addIfNotNilFloat(m, "interface_radio_level_out_dbm", "Outbound radio level of the interface in dBm.", prometheus.GaugeValue, interf.Radio.LevelOut, labels...)
+		}
+	}
+
+	return nil
+}
+
+func collectCPULoad(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadCPULoadResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+
+	for i, cpu := range r.CPUs {
+		cpuLabel := strconv.Itoa(i)
+		if cpu.Label != nil {
+			cpuLabel = *cpu.Label
+		}
+		addIfNotNilFloat(m, "cpu_load_percent", "Current load of the CPU in percent.", prometheus.GaugeValue, cpu.Load, newLabel("cpu", cpuLabel))
+	}
+	return nil
+}
+
+func collectMemoryUsage(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadMemoryUsageResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+
+	for _, pool := range r.MemoryPools {
+		addIfNotNilFloat(m, "memory_usage_percent", "Current usage of the memory pool in percent.", prometheus.GaugeValue, pool.Usage, label("pool", pool.Label))
+	}
+	return nil
+}
+
+func collectDisk(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadDiskResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+
+	for _, storage := range r.Disk.Storages {
+		labels := []labelPair{label("description", storage.Description), label("type", storage.Type)}
+		addIfNotNilUint(m, "disk_available_bytes", "Size of the storage in bytes.", prometheus.GaugeValue, storage.Available, labels...)
+		addIfNotNilUint(m, "disk_used_bytes", "Used space of the storage in bytes.", prometheus.GaugeValue, storage.Used, labels...)
+	}
+	return nil
+}
+
+func collectHardwareHealth(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadHardwareHealthResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+	h := r.HardwareHealth
+
+	addState := func(name, help string, state *device.HardwareHealthComponentState, labels ...labelPair) {
+		if state == nil {
+			return
+		}
+		// unknown states are mapped to the unknown state (7)
+		v, _ := state.GetInt()
+		m.Add(namespace+name, help+hardwareHealthStateHelp, prometheus.GaugeValue, float64(v), labels...)
+	}
+
+	addState("hardware_health_environment_monitor_state", "State of the environment monitor", h.EnvironmentMonitorState)
+	for _, fan := range h.Fans {
+		addState("hardware_health_fan_state", "State of the fan", fan.State, label("description", fan.Description))
+	}
+	for _, powerSupply := range h.PowerSupply {
+		addState("hardware_health_power_supply_state", "State of the power supply", powerSupply.State, label("description", powerSupply.Description))
+	}
+	for _, temp := range h.Temperature {
+		addIfNotNilFloat(m, "hardware_health_temperature_celsius", "Temperature of the sensor in degrees celsius.", prometheus.GaugeValue, temp.Temperature, label("description", temp.Description))
+		addState("hardware_health_temperature_state", "State of the temperature sensor", temp.State, label("description", temp.Description))
+	}
+	for _, volt := range h.Voltage {
+		addIfNotNilFloat(m, "hardware_health_voltage_volts", "Voltage of the sensor in volts.", prometheus.GaugeValue, volt.Voltage, label("description", volt.Description))
+		addState("hardware_health_voltage_state", "State of the voltage sensor", volt.State, label("description", volt.Description))
+	}
+	return nil
+}
+
+func collectUPS(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadUPSResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+	u := r.UPS
+
+	addIfNotNilInt(m, "ups_alarm_low_voltage_disconnect", "Low voltage disconnect alarm of the UPS.", prometheus.GaugeValue, u.AlarmLowVoltageDisconnect)
+	addIfNotNilFloat(m, "ups_battery_amperage", "Battery amperage of the UPS.", prometheus.GaugeValue, u.BatteryAmperage)
+	addIfNotNilFloat(m, "ups_battery_capacity_percent", "Remaining battery capacity of the UPS in percent.", prometheus.GaugeValue, u.BatteryCapacity)
+	addIfNotNilFloat(m, "ups_battery_current", "Battery current of the UPS.", prometheus.GaugeValue, u.BatteryCurrent)
+	addIfNotNilFloat(m, "ups_battery_remaining_time", "Remaining battery time of the UPS.", prometheus.GaugeValue, u.BatteryRemainingTime)
+	addIfNotNilFloat(m, "ups_battery_temperature", "Battery temperature of the UPS.", prometheus.GaugeValue, u.BatteryTemperature)
+	addIfNotNilFloat(m, "ups_battery_voltage", "Battery voltage of the UPS.", prometheus.GaugeValue, u.BatteryVoltage)
+	addIfNotNilFloat(m, "ups_current_load", "Current load of the UPS.", prometheus.GaugeValue, u.CurrentLoad)
+	if u.MainsVoltageApplied != nil {
+		var v float64
+		if *u.MainsVoltageApplied {
+			v = 1
+		}
+		m.Add(namespace+"ups_mains_voltage_applied", "Whether the mains voltage is applied to the UPS.", prometheus.GaugeValue, v)
+	}
+	addIfNotNilFloat(m, "ups_rectifier_current", "Rectifier current of the UPS.", prometheus.GaugeValue, u.RectifierCurrent)
+	addIfNotNilFloat(m, "ups_system_voltage", "System voltage of the UPS.", prometheus.GaugeValue, u.SystemVoltage)
+	return nil
+}
+
+func collectSBC(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadSBCResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+	s := r.SBC
+
+	addIfNotNilInt(m, "sbc_global_call_per_second", "Global calls per second of the SBC.", prometheus.GaugeValue, s.GlobalCallPerSecond)
+	addIfNotNilInt(m, "sbc_global_concurrent_sessions", "Global concurrent sessions of the SBC.", prometheus.GaugeValue, s.GlobalConcurrentSessions)
+	addIfNotNilInt(m, "sbc_active_local_contacts", "Active local contacts of the SBC.", prometheus.GaugeValue, s.ActiveLocalContacts)
+	addIfNotNilInt(m, "sbc_transcoding_capacity", "Transcoding capacity of the SBC.", prometheus.GaugeValue, s.TranscodingCapacity)
+	addIfNotNilInt(m, "sbc_license_capacity", "License capacity of the SBC.", prometheus.GaugeValue, s.LicenseCapacity)
+	addIfNotNilInt(m, "sbc_system_redundancy", "System redundancy of the SBC.", prometheus.GaugeValue, s.SystemRedundancy)
+	addIfNotNilInt(m, "sbc_system_health_score", "System health score of the SBC.", prometheus.GaugeValue, s.SystemHealthScore)
+
+	for _, agent := range s.Agents {
+		l := label("agent", agent.Hostname)
+		addIfNotNilInt(m, "sbc_agent_current_active_sessions_inbound", "Current active inbound sessions of the agent.", prometheus.GaugeValue, agent.CurrentActiveSessionsInbound, l)
+		addIfNotNilInt(m, "sbc_agent_current_session_rate_inbound", "Current inbound session rate of the agent.", prometheus.GaugeValue, agent.CurrentSessionRateInbound, l)
+		addIfNotNilInt(m, "sbc_agent_current_active_sessions_outbound", "Current active outbound sessions of the agent.", prometheus.GaugeValue, agent.CurrentActiveSessionsOutbound, l)
+		addIfNotNilInt(m, "sbc_agent_current_session_rate_outbound", "Current outbound session rate of the agent.", prometheus.GaugeValue, agent.CurrentSessionRateOutbound, l)
+		addIfNotNilInt(m, "sbc_agent_period_asr", "Answer seizure ratio of the agent.", prometheus.GaugeValue, agent.PeriodASR, l)
+		addIfNotNilInt(m, "sbc_agent_status", "Status of the agent.", prometheus.GaugeValue, agent.Status, l)
+	}
+
+	for _, realm := range s.Realms {
+		l := label("realm", realm.Name)
+		addIfNotNilInt(m, "sbc_realm_current_active_sessions_inbound", "Current active inbound sessions of the realm.", prometheus.GaugeValue, realm.CurrentActiveSessionsInbound, l)
+		addIfNotNilInt(m, "sbc_realm_current_session_rate_inbound", "Current inbound session rate of the realm.", prometheus.GaugeValue, realm.CurrentSessionRateInbound, l)
+		addIfNotNilInt(m, "sbc_realm_current_active_sessions_outbound", "Current active outbound sessions of the realm.", prometheus.GaugeValue, realm.CurrentActiveSessionsOutbound, l)
+		addIfNotNilInt(m, "sbc_realm_current_session_rate_outbound", "Current outbound session rate of the realm.", prometheus.GaugeValue, realm.CurrentSessionRateOutbound, l)
+		addIfNotNilInt(m, "sbc_realm_period_asr", "Answer seizure ratio of the realm.", prometheus.GaugeValue, realm.PeriodASR, l)
+		addIfNotNilInt(m, "sbc_realm_active_local_contacts", "Active local contacts of the realm.", prometheus.GaugeValue, realm.ActiveLocalContacts, l)
+		addIfNotNilInt(m, "sbc_realm_status", "Status of the realm.", prometheus.GaugeValue, realm.Status, l)
+	}
+	return nil
+}
+
+func collectServer(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadServerResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+
+	addIfNotNilInt(m, "server_procs", "Number of processes on the server.", prometheus.GaugeValue, r.Server.Procs)
+	addIfNotNilInt(m, "server_users", "Number of users logged in on the server.", prometheus.GaugeValue, r.Server.Users)
+	return nil
+}
+
+var bgpPeerStates = map[string]float64{
+	device.BGPPeerStateIdle:        1,
+	device.BGPPeerStateConnect:     2,
+	device.BGPPeerStateActive:      3,
+	device.BGPPeerStateOpenSent:    4,
+	device.BGPPeerStateOpenConfirm: 5,
+	device.BGPPeerStateEstablished: 6,
+}
+
+func collectBGP(res request.Response, m *metrics) error {
+	r, ok := res.(*request.ReadBGPResponse)
+	if !ok {
+		return unexpectedResponseError(res)
+	}
+
+	for _, peer := range r.BGP.Peers {
+		var peerAS string
+		if peer.PeerAS != nil {
+			peerAS = strconv.FormatUint(*peer.PeerAS, 10)
+		}
+		labels := []labelPair{label("peer_address", peer.PeerAddress), newLabel("peer_as", peerAS)}
+
+		if peer.State != nil {
+			if state, ok := bgpPeerStates[*peer.State]; ok {
+				m.Add(namespace+"bgp_peer_state", "State of the BGP peer as defined in the BGP4-MIB (1 = idle, 2 = connect, 3 = active, 4 = opensent, 5 = openconfirm, 6 = established).", prometheus.GaugeValue, state, labels...)
+			}
+		}
+		addIfNotNilUint(m, "bgp_peer_established_time_seconds", "Time in seconds since the BGP session is established.", prometheus.GaugeValue, peer.EstablishedTime, labels...)
+		addIfNotNilUint(m, "bgp_peer_prefixes_received", "Number of prefixes received from the BGP peer.", prometheus.GaugeValue, peer.PrefixesReceived, labels...)
+		addIfNotNilUint(m, "bgp_peer_prefixes_accepted", "Number of prefixes accepted from the BGP peer.", prometheus.GaugeValue, peer.PrefixesAccepted, labels...)
+	}
+	return nil
+}
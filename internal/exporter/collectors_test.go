@@ -0,0 +1,127 @@
+package exporter
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/request"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+// moduleCollector collects the metrics of a module for a fixed response.
+type moduleCollector struct {
+	module Module
+	res    request.Response
+	err    error
+}
+
+func (c *moduleCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *moduleCollector) Collect(ch chan<- prometheus.Metric) {
+	c.err = c.module.Collect(c.res, ch)
+}
+
+func TestCollectInterfaces(t *testing.T) {
+	ifIndex := uint64(1)
+	ifName := "ge-0/0/0"
+	ifAlias := "uplink"
+	up := device.StatusUp
+	down := device.StatusDown
+	inOctets := uint64(100)
+	hcInOctets := uint64(5000000000)
+	outOctets := uint64(200)
+	hcOutOctets := uint64(0)
+	highSpeed := uint64(1000)
+	rxPower := -3.5
+	transponderRXPower := -3.6
+
+	res := &request.ReadInterfacesResponse{
+		Interfaces: []device.Interface{
+			{
+				IfIndex:       &ifIndex,
+				IfName:        &ifName,
+				IfAlias:       &ifAlias,
+				IfAdminStatus: &up,
+				IfOperStatus:  &down,
+				IfInOctets:    &inOctets,
+				IfHCInOctets:  &hcInOctets,
+				IfOutOctets:   &outOctets,
+				IfHCOutOctets: &hcOutOctets,
+				IfHighSpeed:   &highSpeed,
+				DWDM: &device.DWDMInterface{
+					RXPower: &rxPower,
+				},
+				OpticalTransponder: &device.OpticalTransponderInterface{
+					RXPower: &transponderRXPower,
+				},
+			},
+		},
+	}
+
+	module, err := GetModule("interfaces")
+	if !assert.NoError(t, err) {
+		return
+	}
+	c := moduleCollector{module: module, res: res}
+
+	expected := `# HELP thola_interface_admin_status Admin status of the interface as defined in the IF-MIB (1 = up, 2 = down, 3 = testing).
+# TYPE thola_interface_admin_status gauge
+thola_interface_admin_status{ifAlias="uplink",ifDescr="",ifIndex="1",ifName="ge-0/0/0"} 1
+# HELP thola_interface_in_octets_total Number of octets received on the interface.
+# TYPE thola_interface_in_octets_total counter
+thola_interface_in_octets_total{ifAlias="uplink",ifDescr="",ifIndex="1",ifName="ge-0/0/0"} 5e+09
+# HELP thola_interface_oper_status Operational status of the interface as defined in the IF-MIB (1 = up, 2 = down, 3 = testing, 4 = unknown, 5 = dormant, 6 = not present, 7 = lower layer down).
+# TYPE thola_interface_oper_status gauge
+thola_interface_oper_status{ifAlias="uplink",ifDescr="",ifIndex="1",ifName="ge-0/0/0"} 2
+# HELP thola_interface_out_octets_total Number of octets transmitted on the interface.
+# TYPE thola_interface_out_octets_total counter
+thola_interface_out_octets_total{ifAlias="uplink",ifDescr="",ifIndex="1",ifName="ge-0/0/0"} 200
+# HELP thola_interface_rx_power_dbm Optical receive power of the interface in dBm.
+# TYPE thola_interface_rx_power_dbm gauge
+thola_interface_rx_power_dbm{ifAlias="uplink",ifDescr="",ifIndex="1",ifName="ge-0/0/0"} -3.5
+# HELP thola_interface_speed_bits_per_second Speed of the interface in bits per second.
+# TYPE thola_interface_speed_bits_per_second gauge
+thola_interface_speed_bits_per_second{ifAlias="uplink",ifDescr="",ifIndex="1",ifName="ge-0/0/0"} 1e+09
+`
+	assert.NoError(t, testutil.CollectAndCompare(&c, strings.NewReader(expected)))
+	assert.NoError(t, c.err)
+
+	c.res = &request.ReadCPULoadResponse{}
+	testutil.CollectAndCount(&c)
+	assert.Error(t, c.err)
+}
+
+func TestCollectHardwareHealth(t *testing.T) {
+	description := "CPU"
+	temperature := 45.0
+	normal := device.HardwareHealthComponentStateNormal
+	res := &request.ReadHardwareHealthResponse{
+		HardwareHealth: device.HardwareHealthComponent{
+			Temperature: []device.HardwareHealthComponentTemperature{
+				{
+					Description: &description,
+					Temperature: &temperature,
+					State:       &normal,
+				},
+			},
+		},
+	}
+
+	module, err := GetModule("hardware_health")
+	if !assert.NoError(t, err) {
+		return
+	}
+	c := moduleCollector{module: module, res: res}
+
+	expected := `# HELP thola_hardware_health_temperature_celsius Temperature of the sensor in degrees celsius.
+# TYPE thola_hardware_health_temperature_celsius gauge
+thola_hardware_health_temperature_celsius{description="CPU"} 45
+# HELP thola_hardware_health_temperature_state State of the temperature sensor (0 = initial, 1 = normal, 2 = warning, 3 = critical, 4 = shutdown, 5 = not present, 6 = not functioning, 7 = unknown).
+# TYPE thola_hardware_health_temperature_state gauge
+thola_hardware_health_temperature_state{description="CPU"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(&c, strings.NewReader(expected)))
+	assert.NoError(t, c.err)
+}
@@ -0,0 +1,185 @@
+// Package exporter converts the responses of read requests into Prometheus metrics.
+package exporter
+
+import (
+	"fmt"
+	"github.com/inexio/thola/internal/request"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"sort"
+	"strings"
+)
+
+const namespace = "thola_"
+
+// Module reads out a component of a device and converts it into metrics.
+type Module struct {
+	name       string
+	newRequest func(base request.BaseRequest) request.Request
+	collect    func(res request.Response, m *metrics) error
+}
+
+var modules = map[string]Module{
+	"interfaces": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadInterfacesRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectInterfaces,
+	},
+	"cpu": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadCPULoadRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectCPULoad,
+	},
+	"memory": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadMemoryUsageRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectMemoryUsage,
+	},
+	"disk": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadDiskRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectDisk,
+	},
+	"hardware_health": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadHardwareHealthRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectHardwareHealth,
+	},
+	"ups": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadUPSRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectUPS,
+	},
+	"sbc": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadSBCRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectSBC,
+	},
+	"server": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadServerRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectServer,
+	},
+	"bgp": {
+		newRequest: func(base request.BaseRequest) request.Request {
+			return &request.ReadBGPRequest{ReadRequest: request.ReadRequest{BaseRequest: base}}
+		},
+		collect: collectBGP,
+	},
+}
+
+// GetModule returns the module with the given name.
+func GetModule(name string) (Module, error) {
+	module, ok := modules[strings.TrimSpace(name)]
+	if !ok {
+		return Module{}, fmt.Errorf("unknown module '%s'", name)
+	}
+	module.name = strings.TrimSpace(name)
+	return module, nil
+}
+
+// GetModuleNames returns the names of all available modules.
+func GetModuleNames() []string {
+	var names []string
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Name returns the name of the module.
+func (m Module) Name() string {
+	return m.name
+}
+
+// NewRequest returns the read request of the module for the given base request.
+func (m Module) NewRequest(base request.BaseRequest) request.Request {
+	return m.newRequest(base)
+}
+
+// Collect converts the response of the read request of the module into metrics and sends them to the channel.
+func (m Module) Collect(res request.Response, ch chan<- prometheus.Metric) error {
+	metrics := metrics{ch: ch}
+	if err := m.collect(res, &metrics); err != nil {
+		return err
+	}
+	return metrics.err
+}
+
+func unexpectedResponseError(res request.Response) error {
+	return fmt.Errorf("unexpected response type %T", res)
+}
+
+// labelPair represents a label of a metric.
+type labelPair struct {
+	name  string
+	value string
+}
+
+// metrics converts values into constant metrics and sends them to a channel.
+type metrics struct {
+	ch chan<- prometheus.Metric
+
+	// err is the first error that occurred while creating a metric
+	err error
+}
+
+// Add sends a metric with the given value and labels to the channel.
+func (m *metrics) Add(name, help string, valueType prometheus.ValueType, v float64, labels ...labelPair) {
+	names := make([]string, len(labels))
+	values := make([]string, len(labels))
+	for i, l := range labels {
+		names[i] = l.name
+		values[i] = l.value
+	}
+	metric, err := prometheus.NewConstMetric(prometheus.NewDesc(name, help, names, nil), valueType, v, values...)
+	if err != nil {
+		if m.err == nil {
+			m.err = errors.Wrapf(err, "failed to create metric '%s'", name)
+		}
+		return
+	}
+	m.ch <- metric
+}
+
+func addIfNotNilFloat(m *metrics, name, help string, valueType prometheus.ValueType, v *float64, labels ...labelPair) {
+	if v != nil {
+		m.Add(namespace+name, help, valueType, *v, labels...)
+	}
+}
+
+func addIfNotNilUint(m *metrics, name, help string, valueType prometheus.ValueType, v *uint64, labels ...labelPair) {
+	if v != nil {
+		m.Add(namespace+name, help, valueType, float64(*v), labels...)
+	}
+}
+
+func addIfNotNilInt(m *metrics, name, help string, valueType prometheus.ValueType, v *int, labels ...labelPair) {
+	if v != nil {
+		m.Add(namespace+name, help, valueType, float64(*v), labels...)
+	}
+}
+
+func newLabel(name, value string) labelPair {
+	return labelPair{
+		name:  name,
+		value: value,
+	}
+}
+
+func label(name string, value *string) labelPair {
+	var v string
+	if value != nil {
+		v = *value
+	}
+	return newLabel(name, v)
+}
@@ -0,0 +1,300 @@
+// Package extract implements the extraction of values from response bodies with JSONPath, XPath or regular expressions.
+package extract
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/PaesslerAG/gval"
+	"github.com/PaesslerAG/jsonpath"
+	"github.com/antchfx/xmlquery"
+	"github.com/antchfx/xpath"
+	"github.com/inexio/thola/internal/value"
+	"github.com/pkg/errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsonPathLanguage is the JSONPath language including filter expressions, e.g. `$.a[?(@.b == "c")]`.
+var jsonPathLanguage = gval.Full(jsonpath.PlaceholderExtension())
+
+// Expression selects parts of a document. Exactly one of the fields has to be set.
+type Expression struct {
+	// JSONPath is a JSONPath expression, e.g. "$.interfaces[*].name", which starts with "$" for the document
+	// or "@" for the current node. Paths without these prefixes are plain keys separated by dots, e.g. "stats.in",
+	// which are looked up in the current node.
+	JSONPath string `yaml:"json_path" mapstructure:"json_path"`
+	// XPath is a XPath expression, e.g. "/response/interface[@type='ethernet']/name".
+	XPath string `yaml:"xpath" mapstructure:"xpath"`
+	// Regex is a regular expression. If it contains a capturing group, the first group is returned.
+	Regex string `yaml:"regex" mapstructure:"regex"`
+}
+
+// IsEmpty returns whether no expression is set.
+func (e *Expression) IsEmpty() bool {
+	return e.JSONPath == "" && e.XPath == "" && e.Regex == ""
+}
+
+// Validate checks if exactly one expression is set and if it is valid.
+func (e *Expression) Validate() error {
+	set := 0
+	for _, s := range []string{e.JSONPath, e.XPath, e.Regex} {
+		if s != "" {
+			set++
+		}
+	}
+	if set != 1 {
+		return errors.New("exactly one of json_path, xpath and regex needs to be set")
+	}
+
+	switch {
+	case isJSONPath(e.JSONPath):
+		if _, err := parseJSONPath(e.JSONPath); err != nil {
+			return errors.Wrap(err, "invalid json_path")
+		}
+	case e.XPath != "":
+		if _, err := xpath.Compile(e.XPath); err != nil {
+			return errors.Wrap(err, "invalid xpath")
+		}
+	case e.Regex != "":
+		if _, err := regexp.Compile(e.Regex); err != nil {
+			return errors.Wrap(err, "invalid regex")
+		}
+	}
+	return nil
+}
+
+// Find returns all parts of the node that are selected by the expression.
+// If the node is not of the type the expression expects (e.g. a XML node for a JSONPath), the
+// string representation of the node is parsed.
+func (e *Expression) Find(n Node) ([]Node, error) {
+	switch {
+	case e.JSONPath != "":
+		root, err := n.asJSON()
+		if err != nil {
+			return nil, err
+		}
+		if !isJSONPath(e.JSONPath) {
+			if x, ok := lookupKeys(root, e.JSONPath); ok {
+				return []Node{{typ: jsonNode, json: x}}, nil
+			}
+			return nil, nil
+		}
+		path, err := parseJSONPath(e.JSONPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid json_path")
+		}
+		x, err := path(context.Background(), root)
+		if err != nil {
+			if isJSONPathMissingValue(err) {
+				return nil, nil
+			}
+			return nil, errors.Wrapf(err, "failed to evaluate json_path '%s'", e.JSONPath)
+		}
+		var res []Node
+		if values, ok := x.([]interface{}); ok && isJSONPathQuery(e.JSONPath) {
+			for _, v := range values {
+				res = append(res, Node{typ: jsonNode, json: v})
+			}
+		} else {
+			res = append(res, Node{typ: jsonNode, json: x})
+		}
+		return res, nil
+	case e.XPath != "":
+		path, err := xpath.Compile(e.XPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid xpath")
+		}
+		root, err := n.asXML()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(e.XPath, "/") {
+			// absolute paths are evaluated on the document, even if the node is only a part of it
+			for root.Parent != nil {
+				root = root.Parent
+			}
+		}
+		var res []Node
+		for _, x := range xmlquery.QuerySelectorAll(root, path) {
+			res = append(res, Node{typ: xmlNode, xml: x})
+		}
+		return res, nil
+	case e.Regex != "":
+		regex, err := regexp.Compile(e.Regex)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid regex")
+		}
+		var res []Node
+		for _, match := range regex.FindAllStringSubmatch(n.String(), -1) {
+			if len(match) > 1 {
+				res = append(res, NewTextNode(match[1]))
+			} else {
+				res = append(res, NewTextNode(match[0]))
+			}
+		}
+		return res, nil
+	}
+	return nil, errors.New("no expression set")
+}
+
+// FindValue returns the value of the first part of the node that is selected by the expression.
+func (e *Expression) FindValue(n Node) (value.Value, bool, error) {
+	nodes, err := e.Find(n)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(nodes) == 0 {
+		return nil, false, nil
+	}
+	return nodes[0].Value(), true, nil
+}
+
+type nodeType int
+
+const (
+	textNode nodeType = iota
+	jsonNode
+	xmlNode
+)
+
+// Node is a document or a part of it.
+type Node struct {
+	typ  nodeType
+	text string
+	json interface{}
+	xml  *xmlquery.Node
+}
+
+// NewTextNode returns a new node that consists of the given text, e.g. a HTTP response body.
+func NewTextNode(text string) Node {
+	return Node{
+		typ:  textNode,
+		text: text,
+	}
+}
+
+// String returns the string representation of the node.
+// JSON objects and arrays are returned as JSON, XML elements as their text content.
+func (n Node) String() string {
+	switch n.typ {
+	case jsonNode:
+		switch x := n.json.(type) {
+		case nil:
+			return ""
+		case string:
+			return x
+		case json.Number:
+			return x.String()
+		case bool:
+			if x {
+				return "true"
+			}
+			return "false"
+		default:
+			b, err := json.Marshal(x)
+			if err != nil {
+				return ""
+			}
+			return string(b)
+		}
+	case xmlNode:
+		return strings.TrimSpace(n.xml.InnerText())
+	}
+	return n.text
+}
+
+// Value returns the string representation of the node as value.
+func (n Node) Value() value.Value {
+	return value.New(n.String())
+}
+
+func (n Node) asJSON() (interface{}, error) {
+	if n.typ == jsonNode {
+		return n.json, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(n.String()))
+	dec.UseNumber()
+	var res interface{}
+	if err := dec.Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "failed to parse json")
+	}
+	return res, nil
+}
+
+func (n Node) asXML() (*xmlquery.Node, error) {
+	if n.typ == xmlNode {
+		return n.xml, nil
+	}
+	doc, err := xmlquery.Parse(strings.NewReader(n.String()))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse xml")
+	}
+	return doc, nil
+}
+
+// isJSONPath returns whether the path is a JSONPath expression instead of plain keys.
+func isJSONPath(path string) bool {
+	return strings.HasPrefix(path, "$") || strings.HasPrefix(path, "@")
+}
+
+// parseJSONPath parses the JSONPath expression. Paths that start with "@" are evaluated on the current node.
+func parseJSONPath(path string) (gval.Evaluable, error) {
+	if strings.HasPrefix(path, "@") {
+		path = "$" + strings.TrimPrefix(path, "@")
+	}
+	return jsonPathLanguage.NewEvaluable(path)
+}
+
+// isJSONPathMissingValue returns whether the evaluation error of a JSONPath expression only means
+// that the selected key or index does not exist in the document, which is reported as an error
+// for paths without wildcards.
+func isJSONPathMissingValue(err error) bool {
+	msg := err.Error()
+	return strings.HasPrefix(msg, "unknown key ") || strings.HasPrefix(msg, "index ") && strings.HasSuffix(msg, " out of bounds")
+}
+
+// isJSONPathQuery returns whether the JSONPath expression can select multiple values
+// (wildcards, recursive descent, filters, unions or slices), so that its result is a list of the selected values.
+// Quoted keys, e.g. $["a:b"], are not taken into account.
+func isJSONPathQuery(path string) bool {
+	var quote rune
+	var last rune
+	for _, c := range path {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case strings.ContainsRune("*?:,", c), c == '.' && last == '.':
+			return true
+		}
+		last = c
+	}
+	return false
+}
+
+// lookupKeys returns the value of the plain keys separated by dots, e.g. "stats.in".
+// Keys of arrays are their indices.
+func lookupKeys(x interface{}, path string) (interface{}, bool) {
+	for _, key := range strings.Split(path, ".") {
+		switch v := x.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if x, ok = v[key]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			x = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return x, true
+}
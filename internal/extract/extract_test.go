@@ -0,0 +1,165 @@
+package extract
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const testJSON = `{
+	"system": {"hostname": "sw1", "uptime": 1234},
+	"interfaces": [
+		{"name": "eth0", "type": "ethernet", "speed": 1000, "enabled": true},
+		{"name": "lo", "type": "loopback"},
+		{"name": "eth1", "type": "ethernet", "speed": 10000, "enabled": false}
+	]
+}`
+
+const testXML = `<?xml version="1.0" encoding="UTF-8"?>
+<response status="success">
+	<system><hostname>sw1</hostname><uptime>1234</uptime></system>
+	<interfaces>
+		<interface type="ethernet"><name>eth0</name><speed>1000</speed></interface>
+		<interface type="loopback"><name>lo</name></interface>
+		<interface type="ethernet"><name>eth1</name><speed>10000</speed></interface>
+	</interfaces>
+</response>`
+
+func findStrings(t *testing.T, e Expression, n Node) []string {
+	t.Helper()
+	if !assert.NoError(t, e.Validate()) {
+		return nil
+	}
+	nodes, err := e.Find(n)
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	res := []string{}
+	for _, node := range nodes {
+		res = append(res, node.String())
+	}
+	return res
+}
+
+func TestExpression_JSONPath(t *testing.T) {
+	doc := NewTextNode(testJSON)
+
+	cases := map[string][]string{
+		"$.system.hostname":                          {"sw1"},
+		"system.uptime":                              {"1234"},
+		`$["system"]["hostname"]`:                    {"sw1"},
+		"$.interfaces[0].name":                       {"eth0"},
+		"$.interfaces[2].name":                       {"eth1"},
+		"$.interfaces[*].name":                       {"eth0", "lo", "eth1"},
+		"$..speed":                                   {"1000", "10000"},
+		`$.interfaces[?(@.type == "ethernet")].name`: {"eth0", "eth1"},
+		`$.interfaces[?(@.type != "ethernet")].name`: {"lo"},
+		"$.interfaces[?(@.speed > 1000)].name":       {"eth1"},
+		"$.interfaces[0,2].name":                     {"eth0", "eth1"},
+		"$.interfaces[0].enabled":                    {"true"},
+		"$.system.*":                                 {"sw1", "1234"},
+		"$.interfaces[5].name":                       {},
+		"$.system":                                   {`{"hostname":"sw1","uptime":1234}`},
+		"$.interfaces":                               {`[{"enabled":true,"name":"eth0","speed":1000,"type":"ethernet"},{"name":"lo","type":"loopback"},{"enabled":false,"name":"eth1","speed":10000,"type":"ethernet"}]`},
+	}
+	for path, expected := range cases {
+		assert.Equal(t, expected, findStrings(t, Expression{JSONPath: path}, doc), path)
+	}
+
+	v, ok, err := (&Expression{JSONPath: "$.system.uptime"}).FindValue(doc)
+	if assert.NoError(t, err) && assert.True(t, ok) {
+		assert.Equal(t, "1234", v.String())
+	}
+
+	_, err = (&Expression{JSONPath: "$.a"}).Find(NewTextNode("<xml/>"))
+	assert.Error(t, err)
+}
+
+func TestExpression_JSONPathRelative(t *testing.T) {
+	items, err := (&Expression{JSONPath: "$.interfaces[*]"}).Find(NewTextNode(testJSON))
+	if !assert.NoError(t, err) || !assert.Len(t, items, 3) {
+		return
+	}
+	assert.Equal(t, []string{"lo"}, findStrings(t, Expression{JSONPath: "@.name"}, items[1]))
+	assert.Equal(t, []string{"10000"}, findStrings(t, Expression{JSONPath: "speed"}, items[2]))
+}
+
+func TestExpression_JSONPathKeys(t *testing.T) {
+	doc := NewTextNode(`{"a:b": {"c,d": "x"}, "list": [1, 2], "e": "y"}`)
+
+	cases := map[string][]string{
+		"a:b.c,d":         {"x"},
+		"list":            {"[1,2]"},
+		"list.1":          {"2"},
+		"list.2":          {},
+		"unknown":         {},
+		"e.f":             {},
+		`$["a:b"]["c,d"]`: {"x"},
+		"$.unknown":       {},
+		"$.list[5]":       {},
+	}
+	for path, expected := range cases {
+		assert.Equal(t, expected, findStrings(t, Expression{JSONPath: path}, doc), path)
+	}
+
+	_, err := (&Expression{JSONPath: "$.e.f"}).Find(doc)
+	assert.Error(t, err, "evaluation errors need to be returned")
+}
+
+func TestExpression_XPath(t *testing.T) {
+	doc := NewTextNode(testXML)
+
+	cases := map[string][]string{
+		"/response/system/hostname":                     {"sw1"},
+		"/response/@status":                             {"success"},
+		"//hostname":                                    {"sw1"},
+		"//interface/name":                              {"eth0", "lo", "eth1"},
+		"//interface[@type='ethernet']/name":            {"eth0", "eth1"},
+		"//interface[@type!='ethernet']/name":           {"lo"},
+		"//interface[speed]/name":                       {"eth0", "eth1"},
+		"//interface[name='lo']/@type":                  {"loopback"},
+		"/response/interfaces/interface[2]/name/text()": {"lo"},
+		"/response/interfaces/interface[last()]/name":   {"eth1"},
+		"//interface[@type='ethernet'][2]/speed":        {"10000"},
+		"/response/system/*":                            {"sw1", "1234"},
+		"//name[text()='eth1']/../speed":                {"10000"},
+		"/response/unknown":                             {},
+	}
+	for path, expected := range cases {
+		assert.Equal(t, expected, findStrings(t, Expression{XPath: path}, doc), path)
+	}
+
+	items, err := (&Expression{XPath: "//interface"}).Find(doc)
+	if assert.NoError(t, err) && assert.Len(t, items, 3) {
+		assert.Equal(t, []string{"eth1"}, findStrings(t, Expression{XPath: "name"}, items[2]))
+		assert.Equal(t, []string{"ethernet"}, findStrings(t, Expression{XPath: "./@type"}, items[2]))
+		assert.Equal(t, []string{"success"}, findStrings(t, Expression{XPath: "/response/@status"}, items[2]))
+	}
+}
+
+func TestExpression_Regex(t *testing.T) {
+	doc := NewTextNode("Software Version 15.2(4)E7\nUptime: 3 days\n")
+
+	assert.Equal(t, []string{"15.2(4)E7"}, findStrings(t, Expression{Regex: `Version (\S+)`}, doc))
+	assert.Equal(t, []string{"Uptime"}, findStrings(t, Expression{Regex: `Up\w+`}, doc))
+
+	items, err := (&Expression{JSONPath: "$.interfaces[*].name"}).Find(NewTextNode(testJSON))
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"0"}, findStrings(t, Expression{Regex: `eth(\d+)`}, items[0]))
+	}
+}
+
+func TestExpression_Validate(t *testing.T) {
+	invalid := []Expression{
+		{},
+		{JSONPath: "$.a", XPath: "/a"},
+		{JSONPath: "$.a[1"},
+		{JSONPath: "$.a[?(@.b ==)]"},
+		{XPath: "/a/"},
+		{XPath: "/a[b='c]"},
+		{XPath: "/a[unknown()]"},
+		{Regex: "("},
+	}
+	for _, e := range invalid {
+		assert.Error(t, e.Validate(), "%+v", e)
+	}
+}
@@ -5,18 +5,22 @@ import (
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
+	"io/fs"
 	"io/ioutil"
-	"path/filepath"
+	"path"
+	"strings"
 	"sync"
 )
 
 type mapping map[string]string
 
+// Mappings contains the mappings of all mapping files by file name.
+type Mappings map[string]mapping
+
 var mappings struct {
-	sync.Once
 	sync.Mutex
 
-	mappings map[string]mapping
+	mappings Mappings
 }
 
 func (m mapping) get(key string) (string, error) {
@@ -27,10 +31,11 @@ func (m mapping) get(key string) (string, error) {
 }
 
 func readMapping(file string) (mapping, error) {
-	f, err := config.FileSystem.Open(filepath.Join("mapping", file))
+	f, err := config.Files().Open(path.Join("mapping", file))
 	if err != nil {
 		return nil, errors.New("failed to open mappings file")
 	}
+	defer f.Close()
 	b, err := ioutil.ReadAll(f)
 	if err != nil {
 		return nil, errors.New("failed to read mappings file")
@@ -45,48 +50,70 @@ func readMapping(file string) (mapping, error) {
 	return m, nil
 }
 
-func initializeMapping(file string) error {
-	mappings.Do(func() {
-		mappings.mappings = make(map[string]mapping)
-	})
-	if mappings.mappings == nil {
-		return errors.New("Mappings were not initialized")
-	}
-
+// getMapping returns the mapping of the specified file, which is only read once until the mappings are reset.
+func getMapping(file string) (mapping, error) {
 	mappings.Lock()
 	defer mappings.Unlock()
 
-	_, ok := mappings.mappings[file]
+	if mappings.mappings == nil {
+		mappings.mappings = make(Mappings)
+	}
+	m, ok := mappings.mappings[file]
 	if !ok {
-		m, err := readMapping(file)
+		var err error
+		m, err = readMapping(file)
 		if err != nil {
-			return errors.Wrap(err, "failed to read mapping")
+			return nil, errors.Wrap(err, "failed to read mapping")
 		}
 		mappings.mappings[file] = m
 	}
+	return m, nil
+}
 
-	if mappings.mappings[file] == nil {
-		return errors.New("Mapping was not initialized")
+// ReadMappings reads all mapping files without changing the mappings that are currently used.
+// It can be used together with SetMappings to replace the mappings only if all files are valid.
+func ReadMappings() (Mappings, error) {
+	entries, err := fs.ReadDir(config.Files(), "mapping")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read mapping directory")
+	}
+	m := make(Mappings)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+		m[entry.Name()], err = readMapping(entry.Name())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read mapping '%s'", entry.Name())
+		}
 	}
-	return nil
+	return m, nil
+}
+
+// SetMappings replaces the mappings that are currently used.
+func SetMappings(m Mappings) {
+	mappings.Lock()
+	defer mappings.Unlock()
+	mappings.mappings = m
+}
+
+// ResetMappings clears all mappings that were read, so that the mapping files are read again when they are used.
+func ResetMappings() {
+	mappings.Lock()
+	defer mappings.Unlock()
+	mappings.mappings = nil
 }
 
 // GetMappedValue returns the value which the key is associated with in the specified file.
 func GetMappedValue(file, key string) (string, error) {
-	err := initializeMapping(file)
+	m, err := getMapping(file)
 	if err != nil {
 		return "", err
 	}
-
-	return mappings.mappings[file].get(key)
+	return m.get(key)
 }
 
 // GetMapping returns the mapping of the specified file.
 func GetMapping(file string) (map[string]string, error) {
-	err := initializeMapping(file)
-	if err != nil {
-		return nil, err
-	}
-
-	return mappings.mappings[file], nil
+	return getMapping(file)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 	"io/ioutil"
+	"os"
 	"path/filepath"
 	"testing"
 )
@@ -46,3 +47,37 @@ func TestMappings(t *testing.T) {
 	_, err = GetMappedValue("file does not exist", "key does not exist")
 	assert.Error(t, err, "no error returned by GetMappedValue() for non existent mapping file")
 }
+
+func TestResetMappings(t *testing.T) {
+	fileName := "ifType.yaml"
+	val, err := GetMappedValue(fileName, "1")
+	if !assert.NoError(t, err) || !assert.Equal(t, "other", val) {
+		return
+	}
+
+	dir := t.TempDir()
+	if !assert.NoError(t, os.MkdirAll(filepath.Join(dir, "mapping"), 0755)) {
+		return
+	}
+	if !assert.NoError(t, os.WriteFile(filepath.Join(dir, "mapping", fileName), []byte("1: overridden\n"), 0644)) {
+		return
+	}
+	if !assert.NoError(t, config.SetOverlayDirectory(dir)) {
+		return
+	}
+	defer func() {
+		assert.NoError(t, config.SetOverlayDirectory(""))
+		ResetMappings()
+	}()
+
+	val, err = GetMappedValue(fileName, "1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "other", val, "mappings need to be cached until they are reset")
+	}
+
+	ResetMappings()
+	val, err = GetMappedValue(fileName, "1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "overridden", val, "mapping of the overlay directory needs to be used after reset")
+	}
+}
@@ -0,0 +1,143 @@
+// Package metrics contains the runtime metrics of thola itself.
+package metrics
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var (
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "thola_api_request_duration_seconds",
+		Help:    "Duration of the requests handled by the API.",
+		Buckets: buckets,
+	}, []string{"endpoint", "device_class", "status_code"})
+	snmpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "thola_snmp_requests_total",
+		Help: "Number of SNMP packets sent, including retries.",
+	}, []string{"version"})
+	snmpRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "thola_snmp_retries_total",
+		Help: "Number of SNMP retries.",
+	}, []string{"version"})
+	snmpTimeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "thola_snmp_timeouts_total",
+		Help: "Number of SNMP requests that timed out after all retries.",
+	}, []string{"version"})
+	cacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "thola_cache_requests_total",
+		Help: "Number of cache lookups by cache type and result (hit, miss or error).",
+	}, []string{"cache", "result"})
+	ipLockWait = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "thola_ip_lock_wait_seconds",
+		Help:    "Time requests spent waiting on the IP lock.",
+		Buckets: buckets,
+	}, []string{"result"})
+)
+
+// buckets are the buckets of the duration histograms, requests to slow devices can take up to a minute.
+var buckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+// registry contains the runtime metrics of thola and the metrics of the Go runtime and the process.
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		apiRequestDuration,
+		snmpRequests,
+		snmpRetries,
+		snmpTimeouts,
+		cacheRequests,
+		ipLockWait,
+	)
+}
+
+// All results of a cache lookup.
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+// All results of waiting on the IP lock.
+const (
+	IPLockAcquired = "acquired"
+	IPLockTimeout  = "timeout"
+)
+
+// ObserveAPIRequest records the duration of a request handled by the API.
+func ObserveAPIRequest(endpoint, deviceClass string, statusCode int, duration time.Duration) {
+	apiRequestDuration.WithLabelValues(endpoint, deviceClass, strconv.Itoa(statusCode)).Observe(duration.Seconds())
+}
+
+// IncSNMPRequests increments the number of sent SNMP packets.
+func IncSNMPRequests(version string) {
+	snmpRequests.WithLabelValues(version).Inc()
+}
+
+// IncSNMPRetries increments the number of SNMP retries.
+func IncSNMPRetries(version string) {
+	snmpRetries.WithLabelValues(version).Inc()
+}
+
+// IncSNMPTimeouts increments the number of timed out SNMP requests.
+func IncSNMPTimeouts(version string) {
+	snmpTimeouts.WithLabelValues(version).Inc()
+}
+
+// IncCacheRequests increments the number of cache lookups with the given result.
+func IncCacheRequests(cache, result string) {
+	cacheRequests.WithLabelValues(cache, result).Inc()
+}
+
+// ObserveIPLockWait records the time a request waited on the IP lock.
+func ObserveIPLockWait(result string, duration time.Duration) {
+	ipLockWait.WithLabelValues(result).Observe(duration.Seconds())
+}
+
+// Handler returns a handler that serves all runtime metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+type deviceClassKey struct{}
+
+type deviceClassRecorder struct {
+	sync.Mutex
+	class string
+}
+
+// NewContextWithDeviceClassRecorder returns a new context that records the device class of the processed device.
+func NewContextWithDeviceClassRecorder(ctx context.Context) context.Context {
+	return context.WithValue(ctx, deviceClassKey{}, &deviceClassRecorder{})
+}
+
+// RecordDeviceClass records the device class in the context, if the context has a device class recorder.
+func RecordDeviceClass(ctx context.Context, class string) {
+	r, ok := ctx.Value(deviceClassKey{}).(*deviceClassRecorder)
+	if !ok {
+		return
+	}
+	r.Lock()
+	defer r.Unlock()
+	r.class = class
+}
+
+// DeviceClassFromContext returns the device class that was recorded in the context.
+func DeviceClassFromContext(ctx context.Context) (string, bool) {
+	r, ok := ctx.Value(deviceClassKey{}).(*deviceClassRecorder)
+	if !ok {
+		return "", false
+	}
+	r.Lock()
+	defer r.Unlock()
+	return r.class, r.class != ""
+}
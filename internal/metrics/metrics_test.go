@@ -0,0 +1,41 @@
+package metrics
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestDeviceClassRecorder(t *testing.T) {
+	ctx := context.Background()
+	RecordDeviceClass(ctx, "generic")
+	_, ok := DeviceClassFromContext(ctx)
+	assert.False(t, ok)
+
+	ctx = NewContextWithDeviceClassRecorder(ctx)
+	_, ok = DeviceClassFromContext(ctx)
+	assert.False(t, ok)
+
+	// the class is also visible in the parent context, as the recorder is shared
+	RecordDeviceClass(context.WithValue(ctx, struct{}{}, nil), "ios")
+	class, ok := DeviceClassFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "ios", class)
+}
+
+func TestHandler(t *testing.T) {
+	IncSNMPRequests("2c")
+	IncSNMPRequests("2c")
+	ObserveIPLockWait(IPLockAcquired, 50*time.Millisecond)
+
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `thola_snmp_requests_total{version="2c"} 2`)
+	assert.Contains(t, rec.Body.String(), `thola_ip_lock_wait_seconds_bucket{result="acquired",le="0.05"} 1`)
+	assert.Contains(t, rec.Body.String(), "go_goroutines")
+	assert.Contains(t, rec.Body.String(), "process_start_time_seconds")
+}
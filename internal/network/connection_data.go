@@ -10,6 +10,12 @@ type ConnectionData struct {
 	SNMP *SNMPConnectionData `json:"snmp" xml:"snmp" yaml:"snmp"`
 	// Data of the http connection to the device
 	HTTP *HTTPConnectionData `json:"http" xml:"http" yaml:"http"`
+	// Data of the ssh connection to the device
+	SSH *SSHConnectionData `json:"ssh" xml:"ssh" yaml:"ssh"`
+	// Data of the netconf connection to the device
+	NETCONF *NETCONFConnectionData `json:"netconf" xml:"netconf" yaml:"netconf"`
+	// Data of the gnmi connection to the device
+	GNMI *GNMIConnectionData `json:"gnmi" xml:"gnmi" yaml:"gnmi"`
 }
 
 // SNMPConnectionData
@@ -48,6 +54,11 @@ type SNMPConnectionData struct {
 	DiscoverRetries *int `json:"discoverRetries" xml:"discoverRetries" yaml:"discoverRetries"`
 	// The data required for an SNMP v3 connection.
 	V3Data SNMPv3ConnectionData `json:"v3_data" xml:"v3_data" yaml:"v3_data"`
+	// The name of a credential profile of the configured credential provider.
+	// Its communities and SNMP v3 data are used if they are not set in the request.
+	//
+	// example: core
+	CredentialProfile *string `json:"credential_profile,omitempty" xml:"credential_profile,omitempty" yaml:"credential_profile,omitempty"`
 }
 
 // SNMPv3ConnectionData
@@ -118,3 +129,101 @@ type HTTPConnectionData struct {
 	// example: password
 	AuthPassword *string `json:"auth_password" xml:"auth_password" yaml:"auth_password"`
 }
+
+// SSHConnectionData
+//
+// SSHConnectionData includes all SSH connection data for a device.
+//
+// swagger:model
+type SSHConnectionData struct {
+	// The SSH port(s) of the device.
+	//
+	// example: [22]
+	Ports []int `json:"ports" xml:"ports" yaml:"ports"`
+	// The username for the SSH login.
+	//
+	// example: username
+	Username *string `json:"username" xml:"username" yaml:"username"`
+	// The password for the SSH login.
+	//
+	// example: password
+	Password *string `json:"password" xml:"password" yaml:"password"`
+	// The path to a private key file for the SSH login.
+	//
+	// example: /home/thola/.ssh/id_rsa
+	PrivateKeyFile *string `json:"private_key_file" xml:"private_key_file" yaml:"private_key_file"`
+	// The path to a known_hosts file which is used to verify the host key of the device.
+	// Either known_hosts_file or insecure_ignore_host_key is required.
+	//
+	// example: /home/thola/.ssh/known_hosts
+	KnownHostsFile *string `json:"known_hosts_file" xml:"known_hosts_file" yaml:"known_hosts_file"`
+	// Skip the verification of the host key of the device if no known_hosts file is given.
+	// Credentials may be sent to any host that answers, so this should only be used in trusted networks.
+	//
+	// example: false
+	InsecureIgnoreHostKey *bool `json:"insecure_ignore_host_key" xml:"insecure_ignore_host_key" yaml:"insecure_ignore_host_key"`
+}
+
+// NETCONFConnectionData
+//
+// NETCONFConnectionData includes all NETCONF connection data for a device.
+// NETCONF is used for model-driven telemetry if no gNMI connection data is given.
+//
+// swagger:model
+type NETCONFConnectionData struct {
+	// The NETCONF port(s) of the device.
+	//
+	// example: [830]
+	Ports []int `json:"ports" xml:"ports" yaml:"ports"`
+	// The username for the NETCONF login.
+	//
+	// example: username
+	Username *string `json:"username" xml:"username" yaml:"username"`
+	// The password for the NETCONF login.
+	//
+	// example: password
+	Password *string `json:"password" xml:"password" yaml:"password"`
+	// The path to a private key file for the NETCONF login.
+	//
+	// example: /home/thola/.ssh/id_rsa
+	PrivateKeyFile *string `json:"private_key_file" xml:"private_key_file" yaml:"private_key_file"`
+	// The path to a known_hosts file which is used to verify the host key of the device.
+	// Either known_hosts_file or insecure_ignore_host_key is required.
+	//
+	// example: /home/thola/.ssh/known_hosts
+	KnownHostsFile *string `json:"known_hosts_file" xml:"known_hosts_file" yaml:"known_hosts_file"`
+	// Skip the verification of the host key of the device if no known_hosts file is given.
+	// Credentials may be sent to any host that answers, so this should only be used in trusted networks.
+	//
+	// example: false
+	InsecureIgnoreHostKey *bool `json:"insecure_ignore_host_key" xml:"insecure_ignore_host_key" yaml:"insecure_ignore_host_key"`
+}
+
+// GNMIConnectionData
+//
+// GNMIConnectionData includes all gNMI connection data for a device.
+// gNMI is preferred over NETCONF for model-driven telemetry if both are given.
+//
+// swagger:model
+type GNMIConnectionData struct {
+	// The gNMI port(s) of the device.
+	//
+	// example: [57400]
+	Ports []int `json:"ports" xml:"ports" yaml:"ports"`
+	// The username for the gNMI requests.
+	//
+	// example: username
+	Username *string `json:"username" xml:"username" yaml:"username"`
+	// The password for the gNMI requests.
+	//
+	// example: password
+	Password *string `json:"password" xml:"password" yaml:"password"`
+	// Use an unencrypted connection instead of TLS.
+	//
+	// example: false
+	Plaintext *bool `json:"plaintext" xml:"plaintext" yaml:"plaintext"`
+	// Skip the verification of the TLS certificate of the device.
+	//
+	// example: false
+	SkipVerify *bool `json:"skip_verify" xml:"skip_verify" yaml:"skip_verify"`
+}
@@ -7,6 +7,7 @@ type ctxKey byte
 const (
 	requestDeviceConnectionKey ctxKey = iota + 1
 	snmpGetsInsteadOfWalk
+	snmpClientKey
 )
 
 // NewContextWithDeviceConnection returns a new context with the device connection
@@ -30,3 +31,14 @@ func SNMPGetsInsteadOfWalkFromContext(ctx context.Context) (bool, bool) {
 	con, ok := ctx.Value(snmpGetsInsteadOfWalk).(bool)
 	return con, ok
 }
+
+// NewContextWithSNMPClient returns a new context with an snmp client that is used instead of connecting to the device
+func NewContextWithSNMPClient(ctx context.Context, client SNMPClient) context.Context {
+	return context.WithValue(ctx, snmpClientKey, client)
+}
+
+// SNMPClientFromContext gets the snmp client from the context
+func SNMPClientFromContext(ctx context.Context) (SNMPClient, bool) {
+	client, ok := ctx.Value(snmpClientKey).(SNMPClient)
+	return client, ok
+}
@@ -0,0 +1,307 @@
+package network
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"github.com/inexio/thola/internal/openconfig"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=GNMIClient --inpackage
+
+// GNMIClient is used to retrieve data from a device via gNMI.
+type GNMIClient interface {
+	// Get requests the data at the path and returns it as an OpenConfig data tree.
+	Get(ctx context.Context, path openconfig.Path) (*openconfig.Node, error)
+
+	UseCache(b bool)
+	HasSuccessfulCachedRequest() bool
+
+	GetPort() int
+
+	// Close closes the connection to the device.
+	Close() error
+}
+
+const (
+	gnmiDialTimeout = 10 * time.Second
+
+	// gnmiMaxMessageSize limits the size of a single response.
+	gnmiMaxMessageSize = 64 * 1024 * 1024
+)
+
+type gnmiClient struct {
+	sync.Mutex
+
+	dialOptions []grpc.DialOption
+	host        string
+	ports       []int
+	username    string
+	password    string
+
+	// conn is the connection to the port of the last successful request
+	conn *grpc.ClientConn
+	port int
+
+	useCache bool
+	cache    requestCache
+}
+
+// NewGNMIClientByConnectionData returns a new gnmi client for the given connection data.
+// The connection to the device is established when the first request is sent.
+func NewGNMIClientByConnectionData(_ context.Context, ipAddress string, data *GNMIConnectionData) (GNMIClient, error) {
+	if data == nil {
+		return nil, errors.New("gnmi connection data is nil")
+	}
+	if len(data.Ports) == 0 {
+		return nil, tholaerr.NewPreConditionError("no gnmi ports given")
+	}
+	for _, port := range data.Ports {
+		if port <= 0 {
+			return nil, errors.New("invalid gnmi port")
+		}
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithBlock(),
+		grpc.FailOnNonTempDialError(true),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(gnmiMaxMessageSize)),
+	}
+	if data.Plaintext != nil && *data.Plaintext {
+		dialOptions = append(dialOptions, grpc.WithInsecure())
+	} else {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{
+			InsecureSkipVerify: data.SkipVerify != nil && *data.SkipVerify, //nolint:gosec
+		})))
+	}
+
+	client := gnmiClient{
+		dialOptions: dialOptions,
+		host:        ipAddress,
+		ports:       data.Ports,
+		useCache:    true,
+		cache:       newRequestCache(),
+	}
+	if data.Username != nil {
+		client.username = *data.Username
+	}
+	if data.Password != nil {
+		client.password = *data.Password
+	}
+	return &client, nil
+}
+
+// Get requests the data at the path and returns it as an OpenConfig data tree.
+func (g *gnmiClient) Get(ctx context.Context, path openconfig.Path) (*openconfig.Node, error) {
+	key := path.String()
+	if g.useCache {
+		x, err := g.cache.get(key)
+		if err == nil {
+			res, ok := x.res.(*openconfig.Node)
+			if !ok {
+				return nil, errors.New("cached gnmi result is not a tree")
+			}
+			return res, x.err
+		}
+	}
+
+	logger := log.Ctx(ctx).With().Str("gnmi_path", key).Logger()
+	ctx = logger.WithContext(ctx)
+
+	tree, err := g.get(ctx, path)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("gnmi get failed")
+	} else {
+		log.Ctx(ctx).Debug().Msg("gnmi get was successful")
+	}
+
+	if g.useCache {
+		g.cache.add(key, tree, err)
+	}
+	return tree, err
+}
+
+func (g *gnmiClient) get(ctx context.Context, path openconfig.Path) (*openconfig.Node, error) {
+	request := gnmi.GetRequest{
+		Path:     []*gnmi.Path{path.Proto()},
+		Encoding: gnmi.Encoding_JSON_IETF,
+	}
+	if g.username != "" || g.password != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "username", g.username, "password", g.password)
+	}
+
+	response, err := g.getResponse(ctx, &request)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := getResponseToTree(response)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode gnmi response")
+	}
+	return tree, nil
+}
+
+// getResponse sends the request over the existing connection. If there is none yet, all ports are tried
+// and the connection to the first port that answers is kept.
+func (g *gnmiClient) getResponse(ctx context.Context, request *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+	g.Lock()
+	conn := g.conn
+	if conn != nil {
+		g.Unlock()
+		return g.call(ctx, conn, request)
+	}
+	defer g.Unlock()
+
+	var err error
+	for _, port := range g.ports {
+		conn, err = g.dial(ctx, port)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Int("port", port).Msg("gnmi connection failed")
+			continue
+		}
+		var response *gnmi.GetResponse
+		response, err = g.call(ctx, conn, request)
+		if err != nil {
+			_ = conn.Close()
+			if tholaerr.IsNetworkError(err) {
+				log.Ctx(ctx).Debug().Err(err).Int("port", port).Msg("gnmi request failed")
+				continue
+			}
+			return nil, err
+		}
+		g.conn = conn
+		g.port = port
+		return response, nil
+	}
+	return nil, errors.Wrap(err, "failed to connect to device via gnmi")
+}
+
+func (g *gnmiClient) dial(ctx context.Context, port int) (*grpc.ClientConn, error) {
+	ctx, cancel := context.WithTimeout(ctx, gnmiDialTimeout)
+	defer cancel()
+	conn, err := grpc.DialContext(ctx, net.JoinHostPort(g.host, strconv.Itoa(port)), g.dialOptions...)
+	if err != nil {
+		return nil, tholaerr.NewGNMIError(err.Error())
+	}
+	return conn, nil
+}
+
+func (g *gnmiClient) call(ctx context.Context, conn *grpc.ClientConn, request *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+	response, err := gnmi.NewGNMIClient(conn).Get(ctx, request)
+	if err != nil {
+		st := status.Convert(err)
+		if st.Code() == codes.Unavailable {
+			return nil, tholaerr.NewGNMIError(st.Message())
+		}
+		return nil, fmt.Errorf("gnmi request failed with status %s: %s", st.Code(), st.Message())
+	}
+	return response, nil
+}
+
+// UseCache configures whether the gnmi cache should be used or not.
+func (g *gnmiClient) UseCache(b bool) {
+	g.useCache = b
+}
+
+// HasSuccessfulCachedRequest returns if there was at least one successful cached request.
+func (g *gnmiClient) HasSuccessfulCachedRequest() bool {
+	return len(g.cache.getSuccessfulRequests()) > 0
+}
+
+// GetPort returns the port of the last successful request or 0 if there was no successful request yet.
+func (g *gnmiClient) GetPort() int {
+	g.Lock()
+	defer g.Unlock()
+	return g.port
+}
+
+// Close closes the connection to the device.
+func (g *gnmiClient) Close() error {
+	g.Lock()
+	defer g.Unlock()
+	if g.conn == nil {
+		return nil
+	}
+	err := g.conn.Close()
+	g.conn = nil
+	return err
+}
+
+// getResponseToTree merges all updates of all notifications of the response into one tree.
+func getResponseToTree(response *gnmi.GetResponse) (*openconfig.Node, error) {
+	tree := openconfig.NewTree()
+	for _, notification := range response.GetNotification() {
+		prefix := openconfig.PathFromProto(notification.GetPrefix())
+		for _, update := range notification.GetUpdate() {
+			path := append(append(openconfig.Path{}, prefix...), openconfig.PathFromProto(update.GetPath())...)
+			val, err := typedValueToJSON(update.GetVal())
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decode value of '%s'", path)
+			}
+			if err := tree.AddJSON(path, val); err != nil {
+				return nil, errors.Wrapf(err, "failed to add value of '%s'", path)
+			}
+		}
+	}
+	return tree, nil
+}
+
+// typedValueToJSON returns the value encoded as JSON.
+// Scalar values are returned as JSON strings, as all values are stored as strings in the tree anyway.
+func typedValueToJSON(v *gnmi.TypedValue) ([]byte, error) {
+	var s string
+	switch x := v.GetValue().(type) {
+	case *gnmi.TypedValue_JsonIetfVal:
+		return x.JsonIetfVal, nil
+	case *gnmi.TypedValue_JsonVal:
+		return x.JsonVal, nil
+	case *gnmi.TypedValue_StringVal:
+		s = x.StringVal
+	case *gnmi.TypedValue_AsciiVal:
+		s = x.AsciiVal
+	case *gnmi.TypedValue_IntVal:
+		s = strconv.FormatInt(x.IntVal, 10)
+	case *gnmi.TypedValue_UintVal:
+		s = strconv.FormatUint(x.UintVal, 10)
+	case *gnmi.TypedValue_BoolVal:
+		s = strconv.FormatBool(x.BoolVal)
+	case *gnmi.TypedValue_FloatVal:
+		s = strconv.FormatFloat(float64(x.FloatVal), 'f', -1, 32)
+	case *gnmi.TypedValue_DecimalVal:
+		s = decimal.New(x.DecimalVal.GetDigits(), -int32(x.DecimalVal.GetPrecision())).String()
+	case *gnmi.TypedValue_BytesVal:
+		return json.Marshal(x.BytesVal)
+	case *gnmi.TypedValue_LeaflistVal:
+		var elements []string
+		for _, element := range x.LeaflistVal.GetElement() {
+			b, err := typedValueToJSON(element)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, string(b))
+		}
+		return []byte("[" + strings.Join(elements, ",") + "]"), nil
+	case nil:
+		return nil, errors.New("typed value is empty")
+	default:
+		return nil, fmt.Errorf("unsupported value type %T", x)
+	}
+	return json.Marshal(s)
+}
@@ -0,0 +1,121 @@
+package network
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/openconfig"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+)
+
+type testGNMIServer struct {
+	gnmi.UnimplementedGNMIServer
+
+	get func(ctx context.Context, request *gnmi.GetRequest) (*gnmi.GetResponse, error)
+}
+
+func (s *testGNMIServer) Get(ctx context.Context, request *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+	return s.get(ctx, request)
+}
+
+func newGNMITestClient(t *testing.T, server *testGNMIServer) GNMIClient {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	s := grpc.NewServer()
+	gnmi.RegisterGNMIServer(s, server)
+	go func() { _ = s.Serve(listener) }()
+	t.Cleanup(s.Stop)
+
+	username, password, plaintext := "user", "secret", true
+	client, err := NewGNMIClientByConnectionData(context.Background(), "127.0.0.1", &GNMIConnectionData{
+		Ports:     []int{listener.Addr().(*net.TCPAddr).Port},
+		Username:  &username,
+		Password:  &password,
+		Plaintext: &plaintext,
+	})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestGNMIClient_Get(t *testing.T) {
+	path, _ := openconfig.ParsePath("/interfaces/interface[name=eth0]/state")
+	prefix, _ := openconfig.ParsePath("/interfaces")
+	relative, _ := openconfig.ParsePath("interface[name=eth0]/state")
+	counters, _ := openconfig.ParsePath("interface[name=eth0]/state/counters/in-octets")
+
+	client := newGNMITestClient(t, &testGNMIServer{get: func(ctx context.Context, request *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		assert.Equal(t, []string{"user"}, md.Get("username"))
+		assert.Equal(t, []string{"secret"}, md.Get("password"))
+		assert.Equal(t, gnmi.Encoding_JSON_IETF, request.GetEncoding())
+		if assert.Len(t, request.GetPath(), 1) {
+			assert.Equal(t, path.String(), openconfig.PathFromProto(request.GetPath()[0]).String())
+		}
+
+		return &gnmi.GetResponse{Notification: []*gnmi.Notification{{
+			Prefix: prefix.Proto(),
+			Update: []*gnmi.Update{
+				{Path: relative.Proto(), Val: &gnmi.TypedValue{Value: &gnmi.TypedValue_JsonIetfVal{JsonIetfVal: []byte(`{"openconfig-interfaces:mtu": 1500, "oper-status": "UP"}`)}}},
+				{Path: counters.Proto(), Val: &gnmi.TypedValue{Value: &gnmi.TypedValue_UintVal{UintVal: 42}}},
+			},
+		}}}, nil
+	}})
+
+	tree, err := client.Get(context.Background(), path)
+	if assert.NoError(t, err) {
+		mtu, _ := openconfig.ParsePath("/interfaces/interface[name=eth0]/state/mtu")
+		nodes := tree.Find(mtu)
+		if assert.Len(t, nodes, 1) {
+			assert.Equal(t, "1500", nodes[0].String())
+		}
+		inOctets, _ := openconfig.ParsePath("/interfaces/interface[name=eth0]/state/counters/in-octets")
+		nodes = tree.Find(inOctets)
+		if assert.Len(t, nodes, 1) {
+			assert.Equal(t, "42", nodes[0].String())
+		}
+	}
+	assert.NotZero(t, client.GetPort())
+}
+
+func TestGNMIClient_Get_error(t *testing.T) {
+	client := newGNMITestClient(t, &testGNMIServer{get: func(context.Context, *gnmi.GetRequest) (*gnmi.GetResponse, error) {
+		return nil, status.Error(codes.NotFound, "path not found")
+	}})
+
+	path, _ := openconfig.ParsePath("/unknown")
+	_, err := client.Get(context.Background(), path)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "path not found")
+	}
+}
+
+func TestTypedValueToJSON(t *testing.T) {
+	cases := map[string]*gnmi.TypedValue{
+		`"eth0"`:       {Value: &gnmi.TypedValue_StringVal{StringVal: "eth0"}},
+		`"-5"`:         {Value: &gnmi.TypedValue_IntVal{IntVal: -5}},
+		`"true"`:       {Value: &gnmi.TypedValue_BoolVal{BoolVal: true}},
+		`"12.34"`:      {Value: &gnmi.TypedValue_DecimalVal{DecimalVal: &gnmi.Decimal64{Digits: 1234, Precision: 2}}},
+		`["a","1"]`:    {Value: &gnmi.TypedValue_LeaflistVal{LeaflistVal: &gnmi.ScalarArray{Element: []*gnmi.TypedValue{{Value: &gnmi.TypedValue_StringVal{StringVal: "a"}}, {Value: &gnmi.TypedValue_UintVal{UintVal: 1}}}}}},
+		`{"mtu":1500}`: {Value: &gnmi.TypedValue_JsonVal{JsonVal: []byte(`{"mtu":1500}`)}},
+	}
+	for expected, v := range cases {
+		b, err := typedValueToJSON(v)
+		if assert.NoError(t, err, expected) {
+			assert.Equal(t, expected, string(b))
+		}
+	}
+
+	_, err := typedValueToJSON(&gnmi.TypedValue{})
+	assert.Error(t, err)
+}
@@ -130,8 +130,18 @@ func (h *HTTPClient) HasSuccessfulCachedRequest() bool {
 
 // Request sends an http request.
 func (h *HTTPClient) Request(ctx context.Context, method, path, body string, header, queryParams map[string]string) (*resty.Response, error) {
+	return h.request(ctx, h.useHTTPS, h.port, method, path, body, header, queryParams)
+}
+
+// RequestTo sends an http request like Request, but uses the given protocol and port instead of the ones set on the client.
+// The client is not changed, so different protocols and ports can be tried concurrently with the same client.
+func (h *HTTPClient) RequestTo(ctx context.Context, useHTTPS bool, port int, method, path, body string, header, queryParams map[string]string) (*resty.Response, error) {
+	return h.request(ctx, useHTTPS, &port, method, path, body, header, queryParams)
+}
+
+func (h *HTTPClient) request(ctx context.Context, useHTTPS bool, port *int, method, path, body string, header, queryParams map[string]string) (*resty.Response, error) {
 	if h.useCache && method == http.MethodGet {
-		x, err := h.cache.get(h.getRequestCacheKey(path))
+		x, err := h.cache.get(getRequestCacheKey(useHTTPS, port, path))
 		if err == nil {
 			res, ok := x.res.(*resty.Response)
 			if !ok {
@@ -163,13 +173,9 @@ func (h *HTTPClient) Request(ctx context.Context, method, path, body string, hea
 
 	var response *resty.Response
 
-	URLStr := "https"
-	if !h.useHTTPS {
-		URLStr = "http"
-	}
-	URLStr += "://" + h.host
-	if h.port != nil {
-		URLStr += ":" + strconv.Itoa(*h.port)
+	URLStr := protocolString(useHTTPS) + "://" + h.host
+	if port != nil {
+		URLStr += ":" + strconv.Itoa(*port)
 	}
 	URLStr += "/"
 	URL, err := url.Parse(URLStr)
@@ -192,7 +198,7 @@ func (h *HTTPClient) Request(ctx context.Context, method, path, body string, hea
 	}
 	// save cache
 	if h.useCache && method == http.MethodGet {
-		h.cache.add(h.getRequestCacheKey(path), response, err)
+		h.cache.add(getRequestCacheKey(useHTTPS, port, path), response, err)
 	}
 	if err != nil {
 		return nil, tholaerr.NewHTTPError(err.Error())
@@ -200,13 +206,21 @@ func (h *HTTPClient) Request(ctx context.Context, method, path, body string, hea
 	return response, nil
 }
 
-func (h *HTTPClient) getRequestCacheKey(path string) string {
-	return fmt.Sprintf("%s:%d:%s", h.GetProtocolString(), h.port, path)
+func getRequestCacheKey(useHTTPS bool, port *int, path string) string {
+	portString := "default"
+	if port != nil {
+		portString = strconv.Itoa(*port)
+	}
+	return fmt.Sprintf("%s:%s:%s", protocolString(useHTTPS), portString, path)
 }
 
 // GetProtocolString returns the protocol as a string.
 func (h *HTTPClient) GetProtocolString() string {
-	if h.useHTTPS {
+	return protocolString(h.useHTTPS)
+}
+
+func protocolString(useHTTPS bool) string {
+	if useHTTPS {
 		return "https"
 	}
 	return "http"
@@ -0,0 +1,38 @@
+package network
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestHTTPClient_RequestTo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.URL.Path))
+	}))
+	defer server.Close()
+	_, p, err := net.SplitHostPort(server.Listener.Addr().String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	port, err := strconv.Atoi(p)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	client, err := NewHTTPClient("https://127.0.0.1")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	res, err := client.RequestTo(context.Background(), false, port, http.MethodGet, "status", "", nil, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "/status", string(res.Body()))
+	}
+	assert.Equal(t, "https", client.GetProtocolString())
+	assert.Nil(t, client.port)
+}
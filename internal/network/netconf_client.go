@@ -0,0 +1,346 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"io"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=NETCONFClient --inpackage
+
+// NETCONFClient is used to retrieve data from a device via NETCONF over SSH.
+type NETCONFClient interface {
+	// Get runs a get operation with the given subtree filter and returns the content of the data element of the reply.
+	Get(ctx context.Context, filter string) (string, error)
+
+	UseCache(b bool)
+	HasSuccessfulCachedRequest() bool
+
+	GetPort() int
+
+	// Close returns the underlying connection to the connection pool.
+	Close() error
+}
+
+type netconfClient struct {
+	ssh *sshClient
+}
+
+// NewNETCONFClientByConnectionData returns a new netconf client for the given connection data.
+// The connection to the device is established when the first request is sent.
+// NETCONF connections share the ssh connection pool.
+func NewNETCONFClientByConnectionData(ctx context.Context, ipAddress string, data *NETCONFConnectionData) (NETCONFClient, error) {
+	if data == nil {
+		return nil, errors.New("netconf connection data is nil")
+	}
+	sshData := SSHConnectionData(*data)
+	client, err := newSSHClient(ctx, ipAddress, &sshData)
+	if err != nil {
+		return nil, err
+	}
+	return &netconfClient{
+		ssh: client,
+	}, nil
+}
+
+// Get runs a get operation with the given subtree filter and returns the content of the data element of the reply.
+func (n *netconfClient) Get(ctx context.Context, filter string) (string, error) {
+	key := "get|" + filter
+	if n.ssh.useCache {
+		x, err := n.ssh.cache.get(key)
+		if err == nil {
+			res, ok := x.res.(string)
+			if !ok {
+				return "", errors.New("cached netconf result is not a string")
+			}
+			return res, x.err
+		}
+	}
+
+	logger := log.Ctx(ctx).With().Str("netconf_filter", filter).Logger()
+	ctx = logger.WithContext(ctx)
+
+	data, err := n.ssh.do(ctx, func(conn *pooledSSHConnection) (string, error) {
+		return conn.netconfGet(ctx, filter)
+	})
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("netconf get failed")
+	} else {
+		log.Ctx(ctx).Debug().Msg("netconf get was successful")
+	}
+
+	if n.ssh.useCache {
+		n.ssh.cache.add(key, data, err)
+	}
+	return data, err
+}
+
+// UseCache configures whether the netconf cache should be used or not.
+func (n *netconfClient) UseCache(b bool) {
+	n.ssh.UseCache(b)
+}
+
+// HasSuccessfulCachedRequest returns if there was at least one successful cached request.
+func (n *netconfClient) HasSuccessfulCachedRequest() bool {
+	return n.ssh.HasSuccessfulCachedRequest()
+}
+
+// GetPort returns the port of the established connection or 0 if no connection was established yet.
+func (n *netconfClient) GetPort() int {
+	return n.ssh.GetPort()
+}
+
+// Close returns the connection to the pool.
+func (n *netconfClient) Close() error {
+	return n.ssh.Close()
+}
+
+// netconfGet opens a netconf session on the connection and runs a get operation.
+func (c *pooledSSHConnection) netconfGet(ctx context.Context, filter string) (string, error) {
+	session, closeSession, err := c.newSession(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer closeSession()
+
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get stdin of ssh session")
+	}
+	stdout, err := session.StdoutPipe()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get stdout of ssh session")
+	}
+	if err := session.RequestSubsystem("netconf"); err != nil {
+		return "", errors.Wrap(err, "failed to start netconf subsystem")
+	}
+
+	type result struct {
+		data string
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		s, err := newNETCONFSession(stdout, stdin)
+		if err != nil {
+			done <- result{err: err}
+			return
+		}
+		data, err := s.get(filter)
+		s.close()
+		done <- result{data: data, err: err}
+	}()
+
+	select {
+	case res := <-done:
+		return res.data, res.err
+	case <-ctx.Done():
+		_ = session.Close()
+		return "", tholaerr.NewSSHError(ctx.Err().Error())
+	}
+}
+
+const (
+	netconfBase10 = "urn:ietf:params:netconf:base:1.0"
+	netconfBase11 = "urn:ietf:params:netconf:base:1.1"
+
+	// netconfEndOfMessage is the message delimiter of the NETCONF 1.0 framing.
+	netconfEndOfMessage = "]]>]]>"
+
+	// netconfMaxMessageSize limits the size of a single reply.
+	netconfMaxMessageSize = 64 * 1024 * 1024
+)
+
+// netconfSession implements the NETCONF protocol (RFC 6241) with the framing of RFC 6242.
+type netconfSession struct {
+	r         *bufio.Reader
+	w         io.Writer
+	chunked   bool
+	messageID int
+}
+
+// newNETCONFSession exchanges the hello messages. The chunked framing of NETCONF 1.1 is used if both sides support it.
+func newNETCONFSession(r io.Reader, w io.Writer) (*netconfSession, error) {
+	s := netconfSession{
+		r: bufio.NewReader(r),
+		w: w,
+	}
+
+	hello := `<?xml version="1.0" encoding="UTF-8"?><hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` +
+		`<capability>` + netconfBase10 + `</capability><capability>` + netconfBase11 + `</capability></capabilities></hello>`
+	if err := s.writeMessage(hello); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.readMessage()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read hello message")
+	}
+	var serverHello struct {
+		Capabilities []string `xml:"capabilities>capability"`
+	}
+	if err := xml.Unmarshal(msg, &serverHello); err != nil {
+		return nil, errors.Wrap(err, "failed to parse hello message")
+	}
+	for _, capability := range serverHello.Capabilities {
+		if strings.TrimSpace(capability) == netconfBase11 {
+			s.chunked = true
+		}
+	}
+	return &s, nil
+}
+
+// get runs a get operation with the given subtree filter and returns the content of the data element of the reply.
+func (s *netconfSession) get(filter string) (string, error) {
+	reply, err := s.rpc(`<get><filter type="subtree">` + filter + `</filter></get>`)
+	if err != nil {
+		return "", err
+	}
+	if reply.Data == nil {
+		return "", errors.New("netconf reply does not contain data")
+	}
+	return reply.Data.Content, nil
+}
+
+// close closes the session. Errors are ignored, as the underlying ssh session is closed anyway.
+func (s *netconfSession) close() {
+	_, _ = s.rpc("<close-session/>")
+}
+
+type netconfReply struct {
+	Errors []struct {
+		Severity string `xml:"error-severity"`
+		Tag      string `xml:"error-tag"`
+		Message  string `xml:"error-message"`
+	} `xml:"rpc-error"`
+	Data *struct {
+		Content string `xml:",innerxml"`
+	} `xml:"data"`
+}
+
+func (s *netconfSession) rpc(operation string) (*netconfReply, error) {
+	s.messageID++
+	msg := fmt.Sprintf(`<rpc message-id="%d" xmlns="urn:ietf:params:xml:ns:netconf:base:1.0">%s</rpc>`, s.messageID, operation)
+	if err := s.writeMessage(msg); err != nil {
+		return nil, err
+	}
+
+	res, err := s.readMessage()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read rpc reply")
+	}
+	var reply netconfReply
+	if err := xml.Unmarshal(res, &reply); err != nil {
+		return nil, errors.Wrap(err, "failed to parse rpc reply")
+	}
+	for _, e := range reply.Errors {
+		if strings.TrimSpace(e.Severity) == "warning" {
+			continue
+		}
+		message := strings.TrimSpace(e.Message)
+		if message == "" {
+			message = strings.TrimSpace(e.Tag)
+		}
+		return nil, fmt.Errorf("netconf rpc failed: %s", message)
+	}
+	return &reply, nil
+}
+
+func (s *netconfSession) writeMessage(msg string) error {
+	var err error
+	if s.chunked {
+		_, err = fmt.Fprintf(s.w, "\n#%d\n%s\n##\n", len(msg), msg)
+	} else {
+		_, err = io.WriteString(s.w, msg+netconfEndOfMessage)
+	}
+	if err != nil {
+		return tholaerr.NewSSHError(fmt.Sprintf("failed to write netconf message: %s", err))
+	}
+	return nil
+}
+
+func (s *netconfSession) readMessage() ([]byte, error) {
+	var msg []byte
+	var err error
+	if s.chunked {
+		msg, err = s.readChunkedMessage()
+	} else {
+		msg, err = s.readEndOfMessageDelimited()
+	}
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, tholaerr.NewSSHError("netconf session was closed by the device")
+		}
+		return nil, err
+	}
+	return msg, nil
+}
+
+func (s *netconfSession) readEndOfMessageDelimited() ([]byte, error) {
+	var msg bytes.Buffer
+	for {
+		line, err := s.r.ReadSlice('>')
+		msg.Write(line)
+		if msg.Len() > netconfMaxMessageSize {
+			return nil, errors.New("netconf message is too large")
+		}
+		if bytes.HasSuffix(msg.Bytes(), []byte(netconfEndOfMessage)) {
+			return msg.Bytes()[:msg.Len()-len(netconfEndOfMessage)], nil
+		}
+		if err != nil && err != bufio.ErrBufferFull {
+			return nil, err
+		}
+	}
+}
+
+func (s *netconfSession) readChunkedMessage() ([]byte, error) {
+	var msg bytes.Buffer
+	for {
+		header, err := s.readChunkHeader()
+		if err != nil {
+			return nil, err
+		}
+		if header == "#" {
+			return msg.Bytes(), nil
+		}
+		size, err := strconv.Atoi(header)
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("invalid netconf chunk size '%s'", header)
+		}
+		if msg.Len()+size > netconfMaxMessageSize {
+			return nil, errors.New("netconf message is too large")
+		}
+		if _, err := io.CopyN(&msg, s.r, int64(size)); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readChunkHeader reads a chunk header ("\n#<size>\n") or the end of chunks marker ("\n##\n") and returns the part after the first "#".
+func (s *netconfSession) readChunkHeader() (string, error) {
+	for _, expected := range []byte("\n#") {
+		b, err := s.r.ReadByte()
+		if err != nil {
+			return "", err
+		}
+		if b != expected {
+			return "", fmt.Errorf("invalid netconf chunk header, expected %q but got %q", expected, b)
+		}
+	}
+	header, err := s.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	if len(header) > 11 {
+		return "", errors.New("netconf chunk header is too long")
+	}
+	return strings.TrimSuffix(header, "\n"), nil
+}
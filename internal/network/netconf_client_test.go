@@ -0,0 +1,81 @@
+package network
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"strings"
+	"testing"
+)
+
+// serveNETCONF runs a minimal netconf server on the connection which answers every get request with the given data.
+func serveNETCONF(t *testing.T, conn net.Conn, base11 bool, data string) {
+	t.Helper()
+	defer conn.Close()
+
+	capabilities := "<capability>" + netconfBase10 + "</capability>"
+	if base11 {
+		capabilities += "<capability>" + netconfBase11 + "</capability>"
+	}
+	hello := `<hello xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><capabilities>` + capabilities + `</capabilities><session-id>1</session-id></hello>`
+	// both sides send their hello message at the same time, net.Pipe is not buffered
+	go func() {
+		_, _ = io.WriteString(conn, hello+netconfEndOfMessage)
+	}()
+
+	s := netconfSession{r: bufio.NewReader(conn), w: conn}
+	msg, err := s.readEndOfMessageDelimited()
+	if !assert.NoError(t, err) || !assert.Contains(t, string(msg), netconfBase11) {
+		return
+	}
+	s.chunked = base11
+
+	for {
+		msg, err := s.readMessage()
+		if err != nil {
+			return
+		}
+		request := string(msg)
+		switch {
+		case strings.Contains(request, "<get>"):
+			assert.Contains(t, request, `<filter type="subtree"><interfaces/></filter>`)
+			err = s.writeMessage(fmt.Sprintf(`<rpc-reply message-id="1" xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><data>%s</data></rpc-reply>`, data))
+		case strings.Contains(request, "<close-session/>"):
+			_ = s.writeMessage(`<rpc-reply message-id="2" xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><ok/></rpc-reply>`)
+			return
+		default:
+			err = s.writeMessage(`<rpc-reply xmlns="urn:ietf:params:xml:ns:netconf:base:1.0"><rpc-error><error-severity>error</error-severity><error-tag>operation-not-supported</error-tag></rpc-error></rpc-reply>`)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func TestNETCONFSession_get(t *testing.T) {
+	data := `<interfaces xmlns="http://openconfig.net/yang/interfaces"><interface><name>ge-0/0/0</name></interface></interfaces>`
+
+	for _, base11 := range []bool{false, true} {
+		client, server := net.Pipe()
+		go serveNETCONF(t, server, base11, data)
+
+		s, err := newNETCONFSession(client, client)
+		if assert.NoError(t, err) {
+			assert.Equal(t, base11, s.chunked)
+
+			res, err := s.get("<interfaces/>")
+			if assert.NoError(t, err) {
+				assert.Equal(t, data, res)
+			}
+
+			_, err = s.rpc("<get-config/>")
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), "operation-not-supported")
+			}
+			s.close()
+		}
+		_ = client.Close()
+	}
+}
@@ -2,8 +2,14 @@ package network
 
 import (
 	"context"
+	"fmt"
+	"github.com/go-resty/resty/v2"
+	"github.com/inexio/thola/internal/openconfig"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/utility"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net/http"
 )
 
 // RequestDeviceConnection represents the request device connection
@@ -11,6 +17,9 @@ type RequestDeviceConnection struct {
 	RawConnectionData ConnectionData
 	HTTP              *RequestDeviceConnectionHTTP
 	SNMP              *RequestDeviceConnectionSNMP
+	SSH               *RequestDeviceConnectionSSH
+	NETCONF           *RequestDeviceConnectionNETCONF
+	GNMI              *RequestDeviceConnectionGNMI
 }
 
 // RequestDeviceConnectionHTTP represents the http request device connection
@@ -25,6 +34,24 @@ type RequestDeviceConnectionSNMP struct {
 	CommonOIDs CommonOIDs
 }
 
+// RequestDeviceConnectionSSH represents the ssh request device connection
+type RequestDeviceConnectionSSH struct {
+	SSHClient      SSHClient
+	ConnectionData *SSHConnectionData
+}
+
+// RequestDeviceConnectionNETCONF represents the netconf request device connection
+type RequestDeviceConnectionNETCONF struct {
+	NETCONFClient  NETCONFClient
+	ConnectionData *NETCONFConnectionData
+}
+
+// RequestDeviceConnectionGNMI represents the gnmi request device connection
+type RequestDeviceConnectionGNMI struct {
+	GNMIClient     GNMIClient
+	ConnectionData *GNMIConnectionData
+}
+
 // CommonOIDs represents the common oids
 type CommonOIDs struct {
 	SysObjectID    *string
@@ -66,6 +93,35 @@ func (r *RequestDeviceConnectionSNMP) GetSysObjectID(ctx context.Context) (strin
 	return *r.CommonOIDs.SysObjectID, nil
 }
 
+// Get sends a GET request for the given path to the device.
+// All HTTPS ports and then all HTTP ports are tried until one of them returns a successful response.
+// The protocol and port of the http client are not changed.
+func (r *RequestDeviceConnectionHTTP) Get(ctx context.Context, path string) (*resty.Response, error) {
+	err := errors.New("no http(s) ports available")
+	for _, useHTTPS := range []bool{true, false} {
+		protocol := protocolString(useHTTPS)
+		for _, port := range utility.IfThenElse(useHTTPS, r.ConnectionData.HTTPSPorts, r.ConnectionData.HTTPPorts).([]int) {
+			var response *resty.Response
+			response, err = r.HTTPClient.RequestTo(ctx, useHTTPS, port, http.MethodGet, path, "", nil, nil)
+			if err != nil {
+				log.Ctx(ctx).Debug().Err(err).Str("protocol", protocol).Int("port", port).Msg("http(s) request returned error")
+				if tholaerr.IsNetworkError(err) {
+					continue
+				}
+				return nil, errors.Wrap(err, "non-network error during http(s) request")
+			}
+			if response.IsError() {
+				log.Ctx(ctx).Debug().Str("protocol", protocol).Int("port", port).Int("status_code", response.StatusCode()).Msg("http(s) request returned error status code")
+				err = fmt.Errorf("http(s) request returned status code %d", response.StatusCode())
+				continue
+			}
+			log.Ctx(ctx).Debug().Str("protocol", protocol).Int("port", port).Msg("http(s) request was successful")
+			return response, nil
+		}
+	}
+	return nil, err
+}
+
 // GetIdealConnectionData returns the ideal connection data.
 func (r *RequestDeviceConnection) GetIdealConnectionData() ConnectionData {
 	connectionData := ConnectionData{}
@@ -101,6 +157,30 @@ func (r *RequestDeviceConnection) GetIdealConnectionData() ConnectionData {
 		}
 	}
 
+	if r.SSH != nil {
+		sshData := *r.SSH.ConnectionData
+		if port := r.SSH.SSHClient.GetPort(); port != 0 {
+			sshData.Ports = []int{port}
+		}
+		connectionData.SSH = &sshData
+	}
+
+	if r.NETCONF != nil {
+		netconfData := *r.NETCONF.ConnectionData
+		if port := r.NETCONF.NETCONFClient.GetPort(); port != 0 {
+			netconfData.Ports = []int{port}
+		}
+		connectionData.NETCONF = &netconfData
+	}
+
+	if r.GNMI != nil {
+		gnmiData := *r.GNMI.ConnectionData
+		if port := r.GNMI.GNMIClient.GetPort(); port != 0 {
+			gnmiData.Ports = []int{port}
+		}
+		connectionData.GNMI = &gnmiData
+	}
+
 	return connectionData
 }
 
@@ -109,4 +189,48 @@ func (r *RequestDeviceConnection) CloseConnections() {
 	if r.SNMP != nil && r.SNMP.SnmpClient != nil {
 		_ = r.SNMP.SnmpClient.Disconnect()
 	}
+	if r.SSH != nil && r.SSH.SSHClient != nil {
+		_ = r.SSH.SSHClient.Close()
+	}
+	if r.NETCONF != nil && r.NETCONF.NETCONFClient != nil {
+		_ = r.NETCONF.NETCONFClient.Close()
+	}
+	if r.GNMI != nil && r.GNMI.GNMIClient != nil {
+		_ = r.GNMI.GNMIClient.Close()
+	}
+}
+
+// HasTelemetryConnection returns true if OpenConfig data can be retrieved via gNMI or NETCONF.
+func (r *RequestDeviceConnection) HasTelemetryConnection() bool {
+	return (r.GNMI != nil && r.GNMI.GNMIClient != nil) || (r.NETCONF != nil && r.NETCONF.NETCONFClient != nil)
+}
+
+// GetOpenConfig returns a tree which contains the OpenConfig data at the path. gNMI is used if available, otherwise NETCONF.
+// The namespace of the top level container is only needed for NETCONF and only if it is not a common OpenConfig model.
+func (r *RequestDeviceConnection) GetOpenConfig(ctx context.Context, path openconfig.Path, namespace string) (*openconfig.Node, error) {
+	if r.GNMI != nil && r.GNMI.GNMIClient != nil {
+		tree, err := r.GNMI.GNMIClient.Get(ctx, path)
+		if err != nil {
+			return nil, errors.Wrap(err, "gnmi request failed")
+		}
+		return tree, nil
+	}
+
+	if r.NETCONF != nil && r.NETCONF.NETCONFClient != nil {
+		filter, err := path.SubtreeFilter(namespace)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to build netconf filter")
+		}
+		data, err := r.NETCONF.NETCONFClient.Get(ctx, filter)
+		if err != nil {
+			return nil, errors.Wrap(err, "netconf request failed")
+		}
+		tree := openconfig.NewTree()
+		if err := tree.AddXML([]byte(data)); err != nil {
+			return nil, errors.Wrap(err, "failed to parse netconf reply")
+		}
+		return tree, nil
+	}
+
+	return nil, tholaerr.NewNotFoundError("no gnmi or netconf connection available")
 }
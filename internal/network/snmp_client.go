@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/metrics"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/utility"
 	"github.com/inexio/thola/internal/value"
@@ -253,6 +254,16 @@ func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retri
 		client.ContextName = *v3Data.ContextName
 	}
 
+	err := setSNMPv3SecurityParameters(client, v3Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSNMPClientTestConnection(client)
+}
+
+// setSNMPv3SecurityParameters sets the message flags and USM security parameters of the client.
+func setSNMPv3SecurityParameters(client *gosnmp.GoSNMP, v3Data SNMPv3ConnectionData) error {
 	switch *v3Data.Level {
 	case "noAuthNoPriv":
 		client.MsgFlags = gosnmp.NoAuthNoPriv
@@ -262,7 +273,7 @@ func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retri
 	case "authNoPriv":
 		authProtocol, err := getGoSNMPV3AuthProtocol(*v3Data.AuthProtocol)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		client.MsgFlags = gosnmp.AuthNoPriv
@@ -274,12 +285,12 @@ func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retri
 	case "authPriv":
 		authProtocol, err := getGoSNMPV3AuthProtocol(*v3Data.AuthProtocol)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		privProtocol, err := getGoSNMPV3PrivProtocol(*v3Data.PrivProtocol)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		client.MsgFlags = gosnmp.AuthPriv
@@ -292,10 +303,17 @@ func NewSNMPv3Client(ctx context.Context, ipAddress string, port, timeout, retri
 		}
 	}
 
-	return newSNMPClientTestConnection(client)
+	return nil
 }
 
 func newSNMPClientTestConnection(client *gosnmp.GoSNMP) (*snmpClient, error) {
+	client.OnSent = func(g *gosnmp.GoSNMP) {
+		metrics.IncSNMPRequests(g.Version.String())
+	}
+	client.OnRetry = func(g *gosnmp.GoSNMP) {
+		metrics.IncSNMPRetries(g.Version.String())
+	}
+
 	err := client.ConnectIPv4()
 	if err != nil {
 		return nil, errors.Wrap(err, "connect ip v4 failed")
@@ -304,6 +322,7 @@ func newSNMPClientTestConnection(client *gosnmp.GoSNMP) (*snmpClient, error) {
 	oids := []string{".0.0"}
 	_, err = client.GetNext(oids)
 	if err != nil {
+		observeSNMPError(client, err)
 		return nil, tholaerr.NewSNMPError(err.Error())
 	}
 
@@ -428,6 +447,7 @@ func (s *snmpClient) SNMPGet(ctx context.Context, oid ...OID) ([]SNMPResponse, e
 		}
 		response, err := s.client.Get(batchString)
 		if err != nil {
+			observeSNMPError(s.client, err)
 			log.Ctx(ctx).Trace().Str("network_request", "snmpget").Strs("oid", batchString).Err(err).Msg("SNMP Get failed")
 			return nil, errors.Wrap(err, "error during snmpget")
 		}
@@ -483,11 +503,15 @@ func (s *snmpClient) SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, err
 	if s.client.Version != gosnmp.Version1 {
 		response, err = s.client.BulkWalkAll(oid.String())
 		if err != nil {
+			observeSNMPError(s.client, err)
 			log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", oid.String()).Err(err).Msg("snmp bulk walk failed")
 		}
 	}
 	if s.client.Version == gosnmp.Version1 || err != nil {
 		response, err = s.client.WalkAll(oid.String())
+		if err != nil {
+			observeSNMPError(s.client, err)
+		}
 	}
 	if err != nil {
 		log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", oid.String()).Err(err).Msg("snmp walk failed")
@@ -530,6 +554,13 @@ func (s *snmpClient) SNMPWalk(ctx context.Context, oid OID) ([]SNMPResponse, err
 	return res, nil
 }
 
+// observeSNMPError counts the error as timeout if the request timed out.
+func observeSNMPError(client *gosnmp.GoSNMP, err error) {
+	if errors.Is(err, context.DeadlineExceeded) || strings.Contains(err.Error(), "timeout") {
+		metrics.IncSNMPTimeouts(client.Version.String())
+	}
+}
+
 // UseCache configures whether the snmp cache should be used or not
 func (s *snmpClient) UseCache(b bool) {
 	s.useCache = b
@@ -799,6 +830,15 @@ func (s *SNMPResponse) GetSNMPType() gosnmp.Asn1BER {
 	return s.snmpType
 }
 
+// GetPDU returns the response as gosnmp PDU with the undecoded value.
+func (s *SNMPResponse) GetPDU() gosnmp.SnmpPDU {
+	return gosnmp.SnmpPDU{
+		Name:  s.oid.String(),
+		Type:  s.snmpType,
+		Value: s.value,
+	}
+}
+
 // SNMPGetConfiguration represents the configuration needed to get a value.
 type SNMPGetConfiguration struct {
 	OID          OID  `yaml:"oid" mapstructure:"oid"`
@@ -0,0 +1,48 @@
+package network
+
+import (
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+)
+
+// NewSNMPTrapListener returns a new listener for SNMP v1/v2c traps and informs.
+// If v3Data is not nil, SNMP v3 traps and informs of the given user are accepted, too.
+func NewSNMPTrapListener(v3Data *SNMPv3ConnectionData) (*gosnmp.TrapListener, error) {
+	params := &gosnmp.GoSNMP{
+		Port:      162,
+		Transport: "udp",
+		Version:   gosnmp.Version2c,
+		MaxOids:   gosnmp.MaxOids,
+		Timeout:   gosnmp.Default.Timeout,
+		Retries:   gosnmp.Default.Retries,
+	}
+
+	if v3Data != nil {
+		if v3Data.Level == nil {
+			return nil, errors.New("no SNMP v3 level provided")
+		}
+		if v3Data.User == nil {
+			return nil, errors.New("no SNMP v3 username provided")
+		}
+		if *v3Data.Level != "noAuthNoPriv" && (v3Data.AuthProtocol == nil || v3Data.AuthKey == nil) {
+			return nil, errors.New("no SNMP v3 auth protocol or key provided")
+		}
+		if *v3Data.Level == "authPriv" && (v3Data.PrivProtocol == nil || v3Data.PrivKey == nil) {
+			return nil, errors.New("no SNMP v3 priv protocol or key provided")
+		}
+
+		params.Version = gosnmp.Version3
+		params.SecurityModel = gosnmp.UserSecurityModel
+		err := setSNMPv3SecurityParameters(params, *v3Data)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid SNMP v3 data")
+		}
+		if params.SecurityParameters == nil {
+			return nil, errors.New("invalid SNMP v3 level, only 'noAuthNoPriv', 'authNoPriv' and 'authPriv' are possible")
+		}
+	}
+
+	listener := gosnmp.NewTrapListener()
+	listener.Params = params
+	return listener, nil
+}
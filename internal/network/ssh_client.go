@@ -0,0 +1,452 @@
+package network
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+//go:generate go run github.com/vektra/mockery/v2 --name=SSHClient --inpackage
+
+// SSHClient is used to run commands on a device over SSH.
+type SSHClient interface {
+	// Run runs the command on the device and returns its output.
+	Run(ctx context.Context, command string) (string, error)
+
+	UseCache(b bool)
+	HasSuccessfulCachedRequest() bool
+
+	GetPort() int
+	GetUsername() string
+
+	// Close returns the underlying connection to the connection pool.
+	Close() error
+}
+
+const (
+	sshLoginTimeout = 10 * time.Second
+
+	// sshMaxSessionsPerConnection is the amount of commands that are run in parallel on one connection.
+	// Many network devices only allow a single session per connection.
+	sshMaxSessionsPerConnection = 1
+)
+
+// sshConnectionPool is shared by all ssh clients, so that subsequent requests to the same device reuse the connection.
+var sshConnectionPool = newSSHPool(5 * time.Minute)
+
+type sshClient struct {
+	sync.Mutex
+
+	pool   *sshPool
+	conn   *pooledSSHConnection
+	config *ssh.ClientConfig
+	key    string
+	host   string
+	ports  []int
+	port   int
+
+	useCache bool
+	cache    requestCache
+}
+
+// NewSSHClientByConnectionData returns a new ssh client for the given connection data.
+// The connection to the device is established when the first command is run.
+func NewSSHClientByConnectionData(ctx context.Context, ipAddress string, data *SSHConnectionData) (SSHClient, error) {
+	return newSSHClient(ctx, ipAddress, data)
+}
+
+func newSSHClient(ctx context.Context, ipAddress string, data *SSHConnectionData) (*sshClient, error) {
+	if data == nil {
+		return nil, errors.New("ssh connection data is nil")
+	}
+	if len(data.Ports) == 0 {
+		return nil, tholaerr.NewPreConditionError("no ssh ports given")
+	}
+	for _, port := range data.Ports {
+		if port <= 0 {
+			return nil, errors.New("invalid ssh port")
+		}
+	}
+
+	config, err := newSSHClientConfig(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sshClient{
+		pool:     sshConnectionPool,
+		config:   config,
+		key:      getSSHPoolKey(ipAddress, data),
+		host:     ipAddress,
+		ports:    data.Ports,
+		useCache: true,
+		cache:    newRequestCache(),
+	}, nil
+}
+
+func newSSHClientConfig(ctx context.Context, data *SSHConnectionData) (*ssh.ClientConfig, error) {
+	if data.Username == nil || *data.Username == "" {
+		return nil, tholaerr.NewPreConditionError("no ssh username given")
+	}
+
+	var auth []ssh.AuthMethod
+	if data.PrivateKeyFile != nil && *data.PrivateKeyFile != "" {
+		key, err := os.ReadFile(*data.PrivateKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read ssh private key file")
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse ssh private key")
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if data.Password != nil && *data.Password != "" {
+		password := *data.Password
+		auth = append(auth, ssh.Password(password), ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+			// network devices often use keyboard interactive authentication which only asks for the password
+			answers := make([]string, len(questions))
+			for i := range answers {
+				answers[i] = password
+			}
+			return answers, nil
+		}))
+	}
+	if len(auth) == 0 {
+		return nil, tholaerr.NewPreConditionError("no ssh password or private key given")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	if data.KnownHostsFile != nil && *data.KnownHostsFile != "" {
+		var err error
+		hostKeyCallback, err = knownhosts.New(*data.KnownHostsFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read known hosts file")
+		}
+	} else if data.InsecureIgnoreHostKey != nil && *data.InsecureIgnoreHostKey {
+		log.Ctx(ctx).Warn().Msg("host key of the device is not verified, because insecure_ignore_host_key is set and no known_hosts file is given")
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	} else {
+		return nil, tholaerr.NewPreConditionError("no known_hosts file given, set known_hosts_file or insecure_ignore_host_key to connect without verifying the host key")
+	}
+
+	return &ssh.ClientConfig{
+		User:            *data.Username,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         sshLoginTimeout,
+	}, nil
+}
+
+// getSSHPoolKey returns the key of the connection in the connection pool. Connections are only shared if all credentials match.
+func getSSHPoolKey(ipAddress string, data *SSHConnectionData) string {
+	h := sha256.New()
+	for _, s := range []*string{data.Username, data.Password, data.PrivateKeyFile, data.KnownHostsFile} {
+		if s != nil {
+			h.Write([]byte(*s))
+		}
+		h.Write([]byte{0})
+	}
+	if data.InsecureIgnoreHostKey != nil && *data.InsecureIgnoreHostKey {
+		h.Write([]byte("insecure"))
+	}
+	return ipAddress + "|" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Run runs the command on the device and returns its combined stdout and stderr output.
+func (s *sshClient) Run(ctx context.Context, command string) (string, error) {
+	if s.useCache {
+		x, err := s.cache.get(command)
+		if err == nil {
+			res, ok := x.res.(string)
+			if !ok {
+				return "", errors.New("cached ssh result is not a string")
+			}
+			return res, x.err
+		}
+	}
+
+	output, err := s.run(ctx, command)
+
+	if s.useCache {
+		s.cache.add(command, output, err)
+	}
+	return output, err
+}
+
+func (s *sshClient) run(ctx context.Context, command string) (string, error) {
+	logger := log.Ctx(ctx).With().Str("ssh_command", command).Logger()
+	ctx = logger.WithContext(ctx)
+
+	output, err := s.do(ctx, func(conn *pooledSSHConnection) (string, error) {
+		return conn.run(ctx, command)
+	})
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("ssh command failed")
+		return "", err
+	}
+	log.Ctx(ctx).Debug().Msg("ssh command was successful")
+	return output, nil
+}
+
+// do calls f with a connection from the pool. If the pooled connection turns out to be broken, f is retried once with a new connection.
+func (s *sshClient) do(ctx context.Context, f func(conn *pooledSSHConnection) (string, error)) (string, error) {
+	conn, err := s.connection(ctx)
+	if err != nil {
+		return "", err
+	}
+	output, err := f(conn)
+	if err != nil && conn.isBroken() && ctx.Err() == nil {
+		// the pooled connection was closed by the device, retry once with a new connection
+		log.Ctx(ctx).Debug().Err(err).Msg("pooled ssh connection is broken, reconnecting")
+		conn, err = s.connection(ctx)
+		if err != nil {
+			return "", err
+		}
+		output, err = f(conn)
+	}
+	return output, err
+}
+
+// connection returns a working connection from the pool.
+func (s *sshClient) connection(ctx context.Context) (*pooledSSHConnection, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.conn != nil {
+		if !s.conn.isBroken() {
+			return s.conn, nil
+		}
+		s.pool.release(s.conn)
+		s.conn = nil
+	}
+
+	ports := s.ports
+	if s.port != 0 {
+		ports = []int{s.port}
+	}
+
+	var err error
+	for _, port := range ports {
+		var conn *pooledSSHConnection
+		conn, err = s.pool.acquire(ctx, s.key, net.JoinHostPort(s.host, strconv.Itoa(port)), s.config)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Int("port", port).Msg("failed to connect to device via ssh")
+			continue
+		}
+		s.conn = conn
+		s.port = port
+		return conn, nil
+	}
+	return nil, errors.Wrap(err, "failed to connect to device via ssh")
+}
+
+// UseCache configures whether the ssh cache should be used or not.
+func (s *sshClient) UseCache(b bool) {
+	s.useCache = b
+}
+
+// HasSuccessfulCachedRequest returns if there was at least one successful cached request.
+func (s *sshClient) HasSuccessfulCachedRequest() bool {
+	return len(s.cache.getSuccessfulRequests()) > 0
+}
+
+// GetPort returns the port of the established connection or 0 if no connection was established yet.
+func (s *sshClient) GetPort() int {
+	s.Lock()
+	defer s.Unlock()
+	return s.port
+}
+
+// GetUsername returns the username of the ssh login.
+func (s *sshClient) GetUsername() string {
+	return s.config.User
+}
+
+// Close returns the connection to the pool.
+func (s *sshClient) Close() error {
+	s.Lock()
+	defer s.Unlock()
+	if s.conn != nil {
+		s.pool.release(s.conn)
+		s.conn = nil
+	}
+	return nil
+}
+
+// sshPool holds established ssh connections, so that they can be reused by subsequent requests.
+// Connections are closed after they have not been used for idleTimeout.
+type sshPool struct {
+	sync.Mutex
+
+	idleTimeout time.Duration
+	connections map[string]*pooledSSHConnection
+}
+
+func newSSHPool(idleTimeout time.Duration) *sshPool {
+	return &sshPool{
+		idleTimeout: idleTimeout,
+		connections: make(map[string]*pooledSSHConnection),
+	}
+}
+
+type pooledSSHConnection struct {
+	client   *ssh.Client
+	key      string
+	sessions chan struct{}
+	broken   int32
+
+	// refs and lastUsed are guarded by the mutex of the pool
+	refs     int
+	lastUsed time.Time
+}
+
+func (p *sshPool) acquire(ctx context.Context, key, addr string, config *ssh.ClientConfig) (*pooledSSHConnection, error) {
+	key += "|" + addr
+
+	p.Lock()
+	p.closeIdleConnections()
+	if c, ok := p.connections[key]; ok && !c.isBroken() {
+		c.refs++
+		p.Unlock()
+		log.Ctx(ctx).Trace().Str("addr", addr).Msg("reusing pooled ssh connection")
+		return c, nil
+	}
+	p.Unlock()
+
+	client, err := dialSSH(ctx, addr, config)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	if c, ok := p.connections[key]; ok && !c.isBroken() {
+		// another request connected in the meantime
+		_ = client.Close()
+		c.refs++
+		return c, nil
+	}
+	c := &pooledSSHConnection{
+		client:   client,
+		key:      key,
+		sessions: make(chan struct{}, sshMaxSessionsPerConnection),
+		refs:     1,
+	}
+	p.connections[key] = c
+	return c, nil
+}
+
+func (p *sshPool) release(c *pooledSSHConnection) {
+	p.Lock()
+	defer p.Unlock()
+	c.refs--
+	c.lastUsed = time.Now()
+	if c.refs <= 0 && (c.isBroken() || p.connections[c.key] != c) {
+		_ = c.client.Close()
+		if p.connections[c.key] == c {
+			delete(p.connections, c.key)
+		}
+	}
+}
+
+// closeIdleConnections closes all unused connections that are broken or exceeded the idle timeout.
+// The mutex of the pool must be held by the caller.
+func (p *sshPool) closeIdleConnections() {
+	for key, c := range p.connections {
+		if c.refs <= 0 && (c.isBroken() || time.Since(c.lastUsed) > p.idleTimeout) {
+			_ = c.client.Close()
+			delete(p.connections, key)
+		}
+	}
+}
+
+func dialSSH(ctx context.Context, addr string, config *ssh.ClientConfig) (*ssh.Client, error) {
+	dialer := net.Dialer{Timeout: config.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, tholaerr.NewSSHError(err.Error())
+	}
+	_ = conn.SetDeadline(time.Now().Add(config.Timeout))
+	c, chans, reqs, err := ssh.NewClientConn(conn, addr, config)
+	if err != nil {
+		_ = conn.Close()
+		return nil, tholaerr.NewSSHError(err.Error())
+	}
+	_ = conn.SetDeadline(time.Time{})
+	return ssh.NewClient(c, chans, reqs), nil
+}
+
+func (c *pooledSSHConnection) isBroken() bool {
+	return atomic.LoadInt32(&c.broken) == 1
+}
+
+func (c *pooledSSHConnection) markBroken() {
+	atomic.StoreInt32(&c.broken, 1)
+}
+
+// newSession opens a new session on the connection. The returned function has to be called when the session is not needed anymore.
+func (c *pooledSSHConnection) newSession(ctx context.Context) (*ssh.Session, func(), error) {
+	select {
+	case c.sessions <- struct{}{}:
+	case <-ctx.Done():
+		return nil, nil, tholaerr.NewSSHError(ctx.Err().Error())
+	}
+
+	session, err := c.client.NewSession()
+	if err != nil {
+		<-c.sessions
+		c.markBroken()
+		return nil, nil, tholaerr.NewSSHError(fmt.Sprintf("failed to open ssh session: %s", err))
+	}
+	return session, func() {
+		_ = session.Close()
+		<-c.sessions
+	}, nil
+}
+
+func (c *pooledSSHConnection) run(ctx context.Context, command string) (string, error) {
+	session, closeSession, err := c.newSession(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer closeSession()
+
+	var output bytes.Buffer
+	session.Stdout = &output
+	session.Stderr = &output
+
+	done := make(chan error, 1)
+	go func() {
+		done <- session.Run(command)
+	}()
+
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		_ = session.Close()
+		return "", tholaerr.NewSSHError(ctx.Err().Error())
+	}
+	if err != nil {
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			return "", fmt.Errorf("command exited with status %d: %s", exitErr.ExitStatus(), bytes.TrimSpace(output.Bytes()))
+		}
+		c.markBroken()
+		return "", tholaerr.NewSSHError(fmt.Sprintf("failed to run ssh command: %s", err))
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,30 @@
+package network
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewSSHClientConfig_hostKey(t *testing.T) {
+	username := "thola"
+	password := "secret"
+	insecure := true
+	secure := false
+
+	_, err := newSSHClientConfig(context.Background(), &SSHConnectionData{Username: &username, Password: &password})
+	assert.True(t, tholaerr.IsPreConditionError(err), "missing host key verification must be an error")
+
+	_, err = newSSHClientConfig(context.Background(), &SSHConnectionData{Username: &username, Password: &password, InsecureIgnoreHostKey: &secure})
+	assert.True(t, tholaerr.IsPreConditionError(err), "missing host key verification must be an error")
+
+	config, err := newSSHClientConfig(context.Background(), &SSHConnectionData{Username: &username, Password: &password, InsecureIgnoreHostKey: &insecure})
+	if assert.NoError(t, err) {
+		assert.NotNil(t, config.HostKeyCallback)
+	}
+
+	knownHosts := "/nonexistent/known_hosts"
+	_, err = newSSHClientConfig(context.Background(), &SSHConnectionData{Username: &username, Password: &password, KnownHostsFile: &knownHosts, InsecureIgnoreHostKey: &insecure})
+	assert.Error(t, err, "a given known_hosts file must be used even if insecure_ignore_host_key is set")
+}
@@ -0,0 +1,213 @@
+// Package openconfig contains helpers to work with data that is modeled with OpenConfig YANG models,
+// independent of the transport (gNMI or NETCONF) which was used to retrieve it.
+package openconfig
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/pkg/errors"
+	"sort"
+	"strings"
+)
+
+// Path is a path in an OpenConfig data tree like "/interfaces/interface[name=eth0]/state".
+// The elements are gNMI path elements, their keys select entries of a list.
+type Path []*gnmi.PathElem
+
+// namespaces contains the XML namespaces of common OpenConfig top level containers, which are needed for NETCONF subtree filters.
+var namespaces = map[string]string{
+	"interfaces":        "http://openconfig.net/yang/interfaces",
+	"system":            "http://openconfig.net/yang/system",
+	"components":        "http://openconfig.net/yang/platform",
+	"network-instances": "http://openconfig.net/yang/network-instance",
+	"lldp":              "http://openconfig.net/yang/lldp",
+	"lacp":              "http://openconfig.net/yang/lacp",
+}
+
+// ParsePath parses a path. Both absolute ("/a/b") and relative ("a/b") paths are accepted, elements may contain
+// keys ("interface[name=eth0]") and module prefixes ("openconfig-interfaces:interfaces").
+func ParsePath(s string) (Path, error) {
+	p := strings.TrimPrefix(strings.TrimSpace(s), "/")
+	if p == "" {
+		return nil, errors.New("empty path")
+	}
+
+	var res Path
+	for p != "" {
+		end := findElemEnd(p)
+		if end == -1 {
+			return nil, fmt.Errorf("unterminated key in path '%s'", s)
+		}
+		elem, err := parsePathElem(p[:end])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid path '%s'", s)
+		}
+		res = append(res, elem)
+		p = p[end:]
+		if p != "" {
+			p = p[1:]
+			if p == "" {
+				return nil, fmt.Errorf("path '%s' must not end with '/'", s)
+			}
+		}
+	}
+	return res, nil
+}
+
+// findElemEnd returns the position of the first "/" that is not inside of a key, or -1 if a key is not terminated.
+func findElemEnd(p string) int {
+	inKey := false
+	for i := 0; i < len(p); i++ {
+		switch {
+		case p[i] == '\\' && inKey:
+			i++
+		case p[i] == '[':
+			inKey = true
+		case p[i] == ']':
+			inKey = false
+		case p[i] == '/' && !inKey:
+			return i
+		}
+	}
+	if inKey {
+		return -1
+	}
+	return len(p)
+}
+
+func parsePathElem(s string) (*gnmi.PathElem, error) {
+	var elem gnmi.PathElem
+	i := strings.Index(s, "[")
+	if i == -1 {
+		elem.Name = s
+	} else {
+		elem.Name = s[:i]
+		rest := s[i:]
+		for rest != "" {
+			if rest[0] != '[' {
+				return nil, fmt.Errorf("invalid key in element '%s'", s)
+			}
+			end := strings.Index(rest, "]")
+			for end > 0 && rest[end-1] == '\\' {
+				next := strings.Index(rest[end+1:], "]")
+				if next == -1 {
+					end = -1
+					break
+				}
+				end += next + 1
+			}
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated key in element '%s'", s)
+			}
+			kv := strings.SplitN(rest[1:end], "=", 2)
+			if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+				return nil, fmt.Errorf("key in element '%s' has to look like '[name=value]'", s)
+			}
+			if elem.Key == nil {
+				elem.Key = make(map[string]string)
+			}
+			elem.Key[strings.TrimSpace(kv[0])] = strings.NewReplacer(`\]`, "]", `\\`, `\`).Replace(kv[1])
+			rest = rest[end+1:]
+		}
+	}
+	elem.Name = strings.TrimSpace(elem.Name)
+	if elem.Name == "" {
+		return nil, errors.New("empty element")
+	}
+	return &elem, nil
+}
+
+// PathFromProto returns the elements of a gNMI path. Paths with the deprecated string elements are converted.
+func PathFromProto(p *gnmi.Path) Path {
+	if p == nil {
+		return nil
+	}
+	if len(p.Elem) == 0 && len(p.Element) != 0 {
+		var res Path
+		for _, name := range p.Element {
+			res = append(res, &gnmi.PathElem{Name: name})
+		}
+		return res
+	}
+	return p.Elem
+}
+
+// Proto returns the path as gNMI path.
+func (p Path) Proto() *gnmi.Path {
+	return &gnmi.Path{Elem: p}
+}
+
+// String returns the absolute string representation of the path.
+func (p Path) String() string {
+	var b strings.Builder
+	for _, elem := range p {
+		b.WriteString("/")
+		b.WriteString(elem.Name)
+		for _, k := range sortedKeys(elem.Key) {
+			b.WriteString("[" + k + "=" + strings.NewReplacer(`\`, `\\`, "]", `\]`).Replace(elem.Key[k]) + "]")
+		}
+	}
+	return b.String()
+}
+
+// sortedKeys returns the names of the keys of a path element in alphabetical order.
+func sortedKeys(key map[string]string) []string {
+	keys := make([]string, 0, len(key))
+	for k := range key {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SubtreeFilter returns a NETCONF subtree filter which selects the data at the path.
+// If namespace is empty, the namespace of the top level container is looked up in a list of common OpenConfig models.
+func (p Path) SubtreeFilter(namespace string) (string, error) {
+	if len(p) == 0 {
+		return "", errors.New("empty path")
+	}
+	if namespace == "" {
+		var ok bool
+		namespace, ok = namespaces[localName(p[0].Name)]
+		if !ok {
+			return "", fmt.Errorf("unknown namespace for '%s'", p[0].Name)
+		}
+	}
+
+	var b strings.Builder
+	for i, elem := range p {
+		name := localName(elem.Name)
+		b.WriteString("<" + name)
+		if i == 0 {
+			b.WriteString(` xmlns="`)
+			_ = xml.EscapeText(&b, []byte(namespace))
+			b.WriteString(`"`)
+		}
+		if i == len(p)-1 && len(elem.Key) == 0 {
+			b.WriteString("/>")
+			break
+		}
+		b.WriteString(">")
+		for _, k := range sortedKeys(elem.Key) {
+			b.WriteString("<" + k + ">")
+			_ = xml.EscapeText(&b, []byte(elem.Key[k]))
+			b.WriteString("</" + k + ">")
+		}
+	}
+	for i := len(p) - 1; i >= 0; i-- {
+		if i == len(p)-1 && len(p[i].Key) == 0 {
+			continue
+		}
+		b.WriteString("</" + localName(p[i].Name) + ">")
+	}
+	return b.String(), nil
+}
+
+// localName strips the module prefix of a name.
+func localName(name string) string {
+	if i := strings.LastIndex(name, ":"); i != -1 {
+		return name[i+1:]
+	}
+	return name
+}
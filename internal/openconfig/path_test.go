@@ -0,0 +1,68 @@
+package openconfig
+
+import (
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParsePath(t *testing.T) {
+	p, err := ParsePath("/interfaces/interface[name=et-0/0/0]/state/counters")
+	if assert.NoError(t, err) {
+		assert.Equal(t, Path{
+			{Name: "interfaces"},
+			{Name: "interface", Key: map[string]string{"name": "et-0/0/0"}},
+			{Name: "state"},
+			{Name: "counters"},
+		}, p)
+		assert.Equal(t, "/interfaces/interface[name=et-0/0/0]/state/counters", p.String())
+	}
+
+	p, err = ParsePath(`network-instances/network-instance[name=a\]b]/protocols/protocol[identifier=BGP][name=bgp]`)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]string{"name": "a]b"}, p[1].Key)
+		assert.Equal(t, map[string]string{"identifier": "BGP", "name": "bgp"}, p[3].Key)
+		assert.Equal(t, `/network-instances/network-instance[name=a\]b]/protocols/protocol[identifier=BGP][name=bgp]`, p.String())
+	}
+
+	for _, invalid := range []string{"", "/", "/a/", "/a[name=b", "/a[name]", "/a/[name=b]"} {
+		_, err = ParsePath(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestPath_SubtreeFilter(t *testing.T) {
+	p, err := ParsePath("/interfaces/interface")
+	if assert.NoError(t, err) {
+		filter, err := p.SubtreeFilter("")
+		if assert.NoError(t, err) {
+			assert.Equal(t, `<interfaces xmlns="http://openconfig.net/yang/interfaces"><interface/></interfaces>`, filter)
+		}
+	}
+
+	p, err = ParsePath("/openconfig-interfaces:interfaces/interface[name=a&b]")
+	if assert.NoError(t, err) {
+		filter, err := p.SubtreeFilter("urn:test")
+		if assert.NoError(t, err) {
+			assert.Equal(t, `<interfaces xmlns="urn:test"><interface><name>a&amp;b</name></interface></interfaces>`, filter)
+		}
+	}
+
+	p, err = ParsePath("/unknown")
+	if assert.NoError(t, err) {
+		_, err = p.SubtreeFilter("")
+		assert.Error(t, err)
+	}
+}
+
+func TestPathFromProto(t *testing.T) {
+	p, err := ParsePath("/interfaces/interface[name=eth0]/state")
+	if assert.NoError(t, err) {
+		assert.Equal(t, p, PathFromProto(p.Proto()))
+	}
+
+	p = PathFromProto(&gnmi.Path{Element: []string{"interfaces", "interface"}})
+	assert.Equal(t, "/interfaces/interface", p.String())
+
+	assert.Nil(t, PathFromProto(nil))
+}
@@ -0,0 +1,254 @@
+package openconfig
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"github.com/openconfig/gnmi/proto/gnmi"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+)
+
+// Node is a node of an OpenConfig data tree. Containers and list entries have children, leafs have a value.
+// Entries of lists and leaf-lists are represented as multiple children with the same name.
+type Node struct {
+	Name     string
+	Value    *string
+	Children []*Node
+}
+
+// NewTree returns an empty tree.
+func NewTree() *Node {
+	return &Node{}
+}
+
+// IsLeaf returns true if the node holds a value.
+func (n *Node) IsLeaf() bool {
+	return n.Value != nil
+}
+
+// String returns the value of a leaf or an empty string for containers.
+func (n *Node) String() string {
+	if n.Value == nil {
+		return ""
+	}
+	return *n.Value
+}
+
+// Find returns all nodes that match the path relative to the node.
+func (n *Node) Find(path Path) []*Node {
+	nodes := []*Node{n}
+	for _, elem := range path {
+		var next []*Node
+		for _, node := range nodes {
+			for _, child := range node.Children {
+				if child.matches(elem) {
+					next = append(next, child)
+				}
+			}
+		}
+		nodes = next
+	}
+	return nodes
+}
+
+func (n *Node) matches(elem *gnmi.PathElem) bool {
+	if elem.Name != "*" && n.Name != localName(elem.Name) {
+		return false
+	}
+	for k, v := range elem.Key {
+		if v == "*" {
+			continue
+		}
+		matched := false
+		for _, child := range n.Children {
+			if child.Name == k && child.String() == v {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// ensure returns the child of the node that matches the element and creates it if it does not exist.
+func (n *Node) ensure(elem *gnmi.PathElem) *Node {
+	for _, child := range n.Children {
+		if child.matches(elem) {
+			return child
+		}
+	}
+	child := &Node{Name: localName(elem.Name)}
+	for _, k := range sortedKeys(elem.Key) {
+		v := elem.Key[k]
+		child.Children = append(child.Children, &Node{Name: k, Value: &v})
+	}
+	n.Children = append(n.Children, child)
+	return child
+}
+
+// AddJSON adds a JSON encoded value (RFC 7951 or plain JSON) at the path to the tree.
+func (n *Node) AddJSON(path Path, data []byte) error {
+	if len(path) == 0 {
+		return n.addJSON(data)
+	}
+
+	parent := n
+	for _, elem := range path[:len(path)-1] {
+		parent = parent.ensure(elem)
+	}
+	last := path[len(path)-1]
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return errors.Wrap(err, "failed to decode json value")
+	}
+
+	// a list can be returned as an array of entries if the path does not select a single entry
+	if entries, ok := v.([]interface{}); ok && len(last.Key) == 0 {
+		for _, entry := range entries {
+			if _, isObject := entry.(map[string]interface{}); !isObject {
+				// leaf-list
+				parent.addValue(localName(last.Name), entry)
+				continue
+			}
+			child := &Node{Name: localName(last.Name)}
+			child.addMembers(entry.(map[string]interface{}))
+			parent.Children = append(parent.Children, child)
+		}
+		return nil
+	}
+	if members, ok := v.(map[string]interface{}); ok {
+		parent.ensure(last).addMembers(members)
+		return nil
+	}
+	if len(last.Key) != 0 {
+		return fmt.Errorf("value of list entry '%s' is not an object", last.Name)
+	}
+	parent.removeChildren(localName(last.Name))
+	parent.addValue(localName(last.Name), v)
+	return nil
+}
+
+func (n *Node) addJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var members map[string]interface{}
+	if err := dec.Decode(&members); err != nil {
+		return errors.Wrap(err, "failed to decode json object")
+	}
+	n.addMembers(members)
+	return nil
+}
+
+func (n *Node) addMembers(members map[string]interface{}) {
+	for name, v := range members {
+		name = localName(name)
+		switch x := v.(type) {
+		case map[string]interface{}:
+			var child *Node
+			for _, c := range n.Children {
+				if c.Name == name && !c.IsLeaf() {
+					child = c
+					break
+				}
+			}
+			if child == nil {
+				child = &Node{Name: name}
+				n.Children = append(n.Children, child)
+			}
+			child.addMembers(x)
+		case []interface{}:
+			for _, entry := range x {
+				if m, ok := entry.(map[string]interface{}); ok {
+					child := &Node{Name: name}
+					child.addMembers(m)
+					n.Children = append(n.Children, child)
+				} else {
+					n.addValue(name, entry)
+				}
+			}
+		default:
+			n.removeChildren(name)
+			n.addValue(name, v)
+		}
+	}
+}
+
+func (n *Node) addValue(name string, v interface{}) {
+	var s string
+	switch x := v.(type) {
+	case nil:
+	case string:
+		s = x
+	case json.Number:
+		s = x.String()
+	default:
+		s = fmt.Sprint(x)
+	}
+	n.Children = append(n.Children, &Node{Name: name, Value: &s})
+}
+
+func (n *Node) removeChildren(name string) {
+	children := n.Children[:0]
+	for _, child := range n.Children {
+		if child.Name != name {
+			children = append(children, child)
+		}
+	}
+	n.Children = children
+}
+
+// AddXML adds XML encoded data (e.g. the content of the data element of a NETCONF reply) to the tree.
+// Namespaces are ignored, elements without child elements are treated as leafs.
+func (n *Node) AddXML(data []byte) error {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+
+	type element struct {
+		node *Node
+		text strings.Builder
+	}
+	stack := []*element{{node: n}}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "failed to parse xml")
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child := &Node{Name: t.Name.Local}
+			parent := stack[len(stack)-1].node
+			parent.Children = append(parent.Children, child)
+			stack = append(stack, &element{node: child})
+		case xml.CharData:
+			if len(stack) > 1 {
+				stack[len(stack)-1].text.Write(t)
+			}
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return errors.New("failed to parse xml: unexpected end element")
+			}
+			e := stack[len(stack)-1]
+			if len(e.node.Children) == 0 {
+				s := strings.TrimSpace(e.text.String())
+				e.node.Value = &s
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if len(stack) != 1 {
+		return errors.New("failed to parse xml: unexpected end of input")
+	}
+	return nil
+}
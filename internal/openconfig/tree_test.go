@@ -0,0 +1,73 @@
+package openconfig
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func leafValues(nodes []*Node) []string {
+	var res []string
+	for _, n := range nodes {
+		res = append(res, n.String())
+	}
+	return res
+}
+
+func TestNode_AddJSON(t *testing.T) {
+	tree := NewTree()
+	p, _ := ParsePath("/interfaces/interface")
+	err := tree.AddJSON(p, []byte(`[
+		{"name": "eth0", "openconfig-interfaces:state": {"mtu": 1500, "enabled": true}},
+		{"name": "eth1", "state": {"mtu": 9000}}
+	]`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	p, _ = ParsePath("/interfaces/interface[name=eth1]/state/mtu")
+	assert.Equal(t, []string{"9000"}, leafValues(tree.Find(p)))
+
+	p, _ = ParsePath("/interfaces/interface[name=*]/state/mtu")
+	assert.Equal(t, []string{"1500", "9000"}, leafValues(tree.Find(p)))
+
+	p, _ = ParsePath("/interfaces/interface[name=eth0]/state/enabled")
+	assert.Equal(t, []string{"true"}, leafValues(tree.Find(p)))
+
+	// a single entry is merged into the existing entry
+	p, _ = ParsePath("/interfaces/interface[name=eth0]/state")
+	if assert.NoError(t, tree.AddJSON(p, []byte(`{"mtu": 1400}`))) {
+		p, _ = ParsePath("/interfaces/interface/state/mtu")
+		assert.Equal(t, []string{"1400", "9000"}, leafValues(tree.Find(p)))
+	}
+
+	// scalar values at leaf paths
+	p, _ = ParsePath("/system/memory/state/physical")
+	if assert.NoError(t, tree.AddJSON(p, []byte(`"17179869184"`))) {
+		assert.Equal(t, []string{"17179869184"}, leafValues(tree.Find(p)))
+	}
+}
+
+func TestNode_AddXML(t *testing.T) {
+	tree := NewTree()
+	err := tree.AddXML([]byte(`<interfaces xmlns="http://openconfig.net/yang/interfaces">
+  <interface>
+    <name>ge-0/0/0</name>
+    <state><mtu>1514</mtu><type xmlns:ianaift="urn:ietf:params:xml:ns:yang:iana-if-type">ianaift:ethernetCsmacd</type></state>
+  </interface>
+  <interface>
+    <name>lo0</name>
+    <state><mtu>65535</mtu></state>
+  </interface>
+</interfaces>`))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	p, _ := ParsePath("/interfaces/interface[name=lo0]/state/mtu")
+	assert.Equal(t, []string{"65535"}, leafValues(tree.Find(p)))
+
+	p, _ = ParsePath("/interfaces/interface/state/type")
+	assert.Equal(t, []string{"ianaift:ethernetCsmacd"}, leafValues(tree.Find(p)))
+
+	assert.Error(t, NewTree().AddXML([]byte(`<interfaces><interface>`)))
+}
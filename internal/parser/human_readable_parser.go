@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"time"
 )
 
 func toHumanReadable(value reflect.Value, insertion int) string {
@@ -12,6 +13,11 @@ func toHumanReadable(value reflect.Value, insertion int) string {
 
 	switch kind {
 	case reflect.Struct:
+		if value.CanInterface() {
+			if t, ok := value.Interface().(time.Time); ok {
+				return t.Format(time.RFC3339)
+			}
+		}
 		var output string
 		for i := 0; i < value.NumField(); i++ {
 			if value.Type().Field(i).Tag.Get("human_readable") == "-" {
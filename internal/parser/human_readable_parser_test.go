@@ -3,6 +3,7 @@ package parser
 import (
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 type TestStruct struct {
@@ -23,6 +24,12 @@ func TestToHumanReadableFloat(t *testing.T) {
 	assert.Equal(t, "0.1", string(output))
 }
 
+func TestToHumanReadableTime(t *testing.T) {
+	output, err := ToHumanReadable(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-03-04T05:06:07Z", string(output))
+}
+
 func TestToHumanReadablePointer1(t *testing.T) {
 	var i uint64 = 2
 	output, err := ToHumanReadable(&i)
@@ -81,11 +81,17 @@ func ToCSV(i interface{}) ([]byte, error) {
 	if p, ok := i.(csvParser); ok {
 		return p.ToCSV()
 	}
+	// single values, e.g. errors, are marshalled as one row
+	if v := reflect.ValueOf(i); v.Kind() == reflect.Struct {
+		rows := reflect.MakeSlice(reflect.SliceOf(v.Type()), 1, 1)
+		rows.Index(0).Set(v)
+		i = rows.Interface()
+	}
 	response, err := gocsv.MarshalBytes(i)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to marshal to csv")
 	}
-	return response, nil
+	return bytes.TrimSuffix(response, []byte("\n")), nil
 }
 
 //ToHumanReadable parses the object to a human readable format.
@@ -69,6 +69,15 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 	if configData.HTTP == nil {
 		configData.HTTP = &network.HTTPConnectionData{}
 	}
+	if configData.SSH == nil {
+		configData.SSH = &network.SSHConnectionData{}
+	}
+	if configData.NETCONF == nil {
+		configData.NETCONF = &network.NETCONFConnectionData{}
+	}
+	if configData.GNMI == nil {
+		configData.GNMI = &network.GNMIConnectionData{}
+	}
 
 	db, err := database.GetDB(ctx)
 	if err != nil {
@@ -88,6 +97,15 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 	if cacheData.HTTP == nil {
 		cacheData.HTTP = &network.HTTPConnectionData{}
 	}
+	if cacheData.SSH == nil {
+		cacheData.SSH = &network.SSHConnectionData{}
+	}
+	if cacheData.NETCONF == nil {
+		cacheData.NETCONF = &network.NETCONFConnectionData{}
+	}
+	if cacheData.GNMI == nil {
+		cacheData.GNMI = &network.GNMIConnectionData{}
+	}
 
 	mergedData := network.ConnectionData{
 		SNMP: &network.SNMPConnectionData{
@@ -113,6 +131,34 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 			AuthUsername: utility.IfThenElse(cacheData.HTTP.AuthUsername != nil, cacheData.HTTP.AuthUsername, configData.HTTP.AuthUsername).(*string),
 			AuthPassword: utility.IfThenElse(cacheData.HTTP.AuthPassword != nil, cacheData.HTTP.AuthPassword, configData.HTTP.AuthPassword).(*string),
 		},
+		SSH: &network.SSHConnectionData{
+			Ports:                 utility.SliceUniqueInt(append(cacheData.SSH.Ports, configData.SSH.Ports...)),
+			Username:              utility.IfThenElse(cacheData.SSH.Username != nil, cacheData.SSH.Username, configData.SSH.Username).(*string),
+			Password:              utility.IfThenElse(cacheData.SSH.Password != nil, cacheData.SSH.Password, configData.SSH.Password).(*string),
+			PrivateKeyFile:        utility.IfThenElse(cacheData.SSH.PrivateKeyFile != nil, cacheData.SSH.PrivateKeyFile, configData.SSH.PrivateKeyFile).(*string),
+			KnownHostsFile:        utility.IfThenElse(cacheData.SSH.KnownHostsFile != nil, cacheData.SSH.KnownHostsFile, configData.SSH.KnownHostsFile).(*string),
+			InsecureIgnoreHostKey: utility.IfThenElse(cacheData.SSH.InsecureIgnoreHostKey != nil, cacheData.SSH.InsecureIgnoreHostKey, configData.SSH.InsecureIgnoreHostKey).(*bool),
+		},
+		NETCONF: &network.NETCONFConnectionData{
+			Ports:                 utility.SliceUniqueInt(append(cacheData.NETCONF.Ports, configData.NETCONF.Ports...)),
+			Username:              utility.IfThenElse(cacheData.NETCONF.Username != nil, cacheData.NETCONF.Username, configData.NETCONF.Username).(*string),
+			Password:              utility.IfThenElse(cacheData.NETCONF.Password != nil, cacheData.NETCONF.Password, configData.NETCONF.Password).(*string),
+			PrivateKeyFile:        utility.IfThenElse(cacheData.NETCONF.PrivateKeyFile != nil, cacheData.NETCONF.PrivateKeyFile, configData.NETCONF.PrivateKeyFile).(*string),
+			KnownHostsFile:        utility.IfThenElse(cacheData.NETCONF.KnownHostsFile != nil, cacheData.NETCONF.KnownHostsFile, configData.NETCONF.KnownHostsFile).(*string),
+			InsecureIgnoreHostKey: utility.IfThenElse(cacheData.NETCONF.InsecureIgnoreHostKey != nil, cacheData.NETCONF.InsecureIgnoreHostKey, configData.NETCONF.InsecureIgnoreHostKey).(*bool),
+		},
+		GNMI: &network.GNMIConnectionData{
+			Ports:      utility.SliceUniqueInt(append(cacheData.GNMI.Ports, configData.GNMI.Ports...)),
+			Username:   utility.IfThenElse(cacheData.GNMI.Username != nil, cacheData.GNMI.Username, configData.GNMI.Username).(*string),
+			Password:   utility.IfThenElse(cacheData.GNMI.Password != nil, cacheData.GNMI.Password, configData.GNMI.Password).(*string),
+			Plaintext:  utility.IfThenElse(cacheData.GNMI.Plaintext != nil, cacheData.GNMI.Plaintext, configData.GNMI.Plaintext).(*bool),
+			SkipVerify: utility.IfThenElse(cacheData.GNMI.SkipVerify != nil, cacheData.GNMI.SkipVerify, configData.GNMI.SkipVerify).(*bool),
+		},
+	}
+
+	err = r.applyCredentialProfile(ctx, configData.SNMP.CredentialProfile)
+	if err != nil {
+		return err
 	}
 
 	if r.DeviceData.ConnectionData.SNMP == nil {
@@ -251,6 +297,101 @@ func (r *BaseRequest) validate(ctx context.Context) error {
 		r.DeviceData.ConnectionData.HTTP.AuthPassword = mergedData.HTTP.AuthPassword
 	}
 
+	if r.DeviceData.ConnectionData.SSH == nil {
+		r.DeviceData.ConnectionData.SSH = mergedData.SSH
+	}
+
+	if len(r.DeviceData.ConnectionData.SSH.Ports) == 0 {
+		r.DeviceData.ConnectionData.SSH.Ports = mergedData.SSH.Ports
+	}
+	for _, port := range r.DeviceData.ConnectionData.SSH.Ports {
+		if port <= 0 {
+			return errors.New("invalid SSH port")
+		}
+	}
+
+	if r.DeviceData.ConnectionData.SSH.Username == nil {
+		r.DeviceData.ConnectionData.SSH.Username = mergedData.SSH.Username
+	}
+
+	if r.DeviceData.ConnectionData.SSH.Password == nil {
+		r.DeviceData.ConnectionData.SSH.Password = mergedData.SSH.Password
+	}
+
+	if r.DeviceData.ConnectionData.SSH.PrivateKeyFile == nil {
+		r.DeviceData.ConnectionData.SSH.PrivateKeyFile = mergedData.SSH.PrivateKeyFile
+	}
+
+	if r.DeviceData.ConnectionData.SSH.KnownHostsFile == nil {
+		r.DeviceData.ConnectionData.SSH.KnownHostsFile = mergedData.SSH.KnownHostsFile
+	}
+
+	if r.DeviceData.ConnectionData.SSH.InsecureIgnoreHostKey == nil {
+		r.DeviceData.ConnectionData.SSH.InsecureIgnoreHostKey = mergedData.SSH.InsecureIgnoreHostKey
+	}
+
+	if r.DeviceData.ConnectionData.NETCONF == nil {
+		r.DeviceData.ConnectionData.NETCONF = mergedData.NETCONF
+	}
+
+	if len(r.DeviceData.ConnectionData.NETCONF.Ports) == 0 {
+		r.DeviceData.ConnectionData.NETCONF.Ports = mergedData.NETCONF.Ports
+	}
+	for _, port := range r.DeviceData.ConnectionData.NETCONF.Ports {
+		if port <= 0 {
+			return errors.New("invalid NETCONF port")
+		}
+	}
+
+	if r.DeviceData.ConnectionData.NETCONF.Username == nil {
+		r.DeviceData.ConnectionData.NETCONF.Username = mergedData.NETCONF.Username
+	}
+
+	if r.DeviceData.ConnectionData.NETCONF.Password == nil {
+		r.DeviceData.ConnectionData.NETCONF.Password = mergedData.NETCONF.Password
+	}
+
+	if r.DeviceData.ConnectionData.NETCONF.PrivateKeyFile == nil {
+		r.DeviceData.ConnectionData.NETCONF.PrivateKeyFile = mergedData.NETCONF.PrivateKeyFile
+	}
+
+	if r.DeviceData.ConnectionData.NETCONF.KnownHostsFile == nil {
+		r.DeviceData.ConnectionData.NETCONF.KnownHostsFile = mergedData.NETCONF.KnownHostsFile
+	}
+
+	if r.DeviceData.ConnectionData.NETCONF.InsecureIgnoreHostKey == nil {
+		r.DeviceData.ConnectionData.NETCONF.InsecureIgnoreHostKey = mergedData.NETCONF.InsecureIgnoreHostKey
+	}
+
+	if r.DeviceData.ConnectionData.GNMI == nil {
+		r.DeviceData.ConnectionData.GNMI = mergedData.GNMI
+	}
+
+	if len(r.DeviceData.ConnectionData.GNMI.Ports) == 0 {
+		r.DeviceData.ConnectionData.GNMI.Ports = mergedData.GNMI.Ports
+	}
+	for _, port := range r.DeviceData.ConnectionData.GNMI.Ports {
+		if port <= 0 {
+			return errors.New("invalid gNMI port")
+		}
+	}
+
+	if r.DeviceData.ConnectionData.GNMI.Username == nil {
+		r.DeviceData.ConnectionData.GNMI.Username = mergedData.GNMI.Username
+	}
+
+	if r.DeviceData.ConnectionData.GNMI.Password == nil {
+		r.DeviceData.ConnectionData.GNMI.Password = mergedData.GNMI.Password
+	}
+
+	if r.DeviceData.ConnectionData.GNMI.Plaintext == nil {
+		r.DeviceData.ConnectionData.GNMI.Plaintext = mergedData.GNMI.Plaintext
+	}
+
+	if r.DeviceData.ConnectionData.GNMI.SkipVerify == nil {
+		r.DeviceData.ConnectionData.GNMI.SkipVerify = mergedData.GNMI.SkipVerify
+	}
+
 	if r.Timeout == nil {
 		timeout := viper.GetInt("request.timeout")
 		r.Timeout = &timeout
@@ -278,8 +419,21 @@ func getConfigConnectionData() network.ConnectionData {
 	v3AuthProto := viper.GetString("device.snmp-v3-auth-proto")
 	v3PrivKey := viper.GetString("device.snmp-v3-priv-key")
 	v3PrivProto := viper.GetString("device.snmp-v3-priv-proto")
+	credentialProfile := viper.GetString("device.snmp-credential-profile")
 	authUsername := viper.GetString("device.http-username")
 	authPassword := viper.GetString("device.http-password")
+	sshUsername := viper.GetString("device.ssh-username")
+	sshPassword := viper.GetString("device.ssh-password")
+	sshPrivateKeyFile := viper.GetString("device.ssh-private-key")
+	sshKnownHostsFile := viper.GetString("device.ssh-known-hosts")
+	netconfUsername := viper.GetString("device.netconf-username")
+	netconfPassword := viper.GetString("device.netconf-password")
+	netconfPrivateKeyFile := viper.GetString("device.netconf-private-key")
+	netconfKnownHostsFile := viper.GetString("device.netconf-known-hosts")
+	gnmiUsername := viper.GetString("device.gnmi-username")
+	gnmiPassword := viper.GetString("device.gnmi-password")
+	gnmiPlaintext := viper.GetBool("device.gnmi-plaintext")
+	gnmiSkipVerify := viper.GetBool("device.gnmi-skip-verify")
 	return network.ConnectionData{
 		SNMP: &network.SNMPConnectionData{
 			Communities:              viper.GetStringSlice("device.snmp-communities"),
@@ -297,6 +451,7 @@ func getConfigConnectionData() network.ConnectionData {
 				PrivKey:      &v3PrivKey,
 				PrivProtocol: &v3PrivProto,
 			},
+			CredentialProfile: &credentialProfile,
 		},
 		HTTP: &network.HTTPConnectionData{
 			HTTPPorts:    viper.GetIntSlice("device.http-ports"),
@@ -304,6 +459,27 @@ func getConfigConnectionData() network.ConnectionData {
 			AuthUsername: &authUsername,
 			AuthPassword: &authPassword,
 		},
+		SSH: &network.SSHConnectionData{
+			Ports:          viper.GetIntSlice("device.ssh-ports"),
+			Username:       &sshUsername,
+			Password:       &sshPassword,
+			PrivateKeyFile: &sshPrivateKeyFile,
+			KnownHostsFile: &sshKnownHostsFile,
+		},
+		NETCONF: &network.NETCONFConnectionData{
+			Ports:          viper.GetIntSlice("device.netconf-ports"),
+			Username:       &netconfUsername,
+			Password:       &netconfPassword,
+			PrivateKeyFile: &netconfPrivateKeyFile,
+			KnownHostsFile: &netconfKnownHostsFile,
+		},
+		GNMI: &network.GNMIConnectionData{
+			Ports:      viper.GetIntSlice("device.gnmi-ports"),
+			Username:   &gnmiUsername,
+			Password:   &gnmiPassword,
+			Plaintext:  &gnmiPlaintext,
+			SkipVerify: &gnmiSkipVerify,
+		},
 	}
 }
 
@@ -332,6 +508,39 @@ func (r *BaseRequest) setupConnection(ctx context.Context) (*network.RequestDevi
 			createdData = true
 		}
 	}
+
+	if r.DeviceData.ConnectionData.SSH != nil && len(r.DeviceData.ConnectionData.SSH.Ports) != 0 {
+		sshCon, err := r.setupSSHConnection(ctx)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to setup ssh connection data")
+		} else {
+			log.Ctx(ctx).Debug().Msg("successfully setup ssh connection data")
+			con.SSH = sshCon
+			createdData = true
+		}
+	}
+
+	if r.DeviceData.ConnectionData.NETCONF != nil && len(r.DeviceData.ConnectionData.NETCONF.Ports) != 0 {
+		netconfCon, err := r.setupNETCONFConnection(ctx)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to setup netconf connection data")
+		} else {
+			log.Ctx(ctx).Debug().Msg("successfully setup netconf connection data")
+			con.NETCONF = netconfCon
+			createdData = true
+		}
+	}
+
+	if r.DeviceData.ConnectionData.GNMI != nil && len(r.DeviceData.ConnectionData.GNMI.Ports) != 0 {
+		gnmiCon, err := r.setupGNMIConnection(ctx)
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msg("failed to setup gnmi connection data")
+		} else {
+			log.Ctx(ctx).Debug().Msg("successfully setup gnmi connection data")
+			con.GNMI = gnmiCon
+			createdData = true
+		}
+	}
 	if !createdData {
 		return nil, errors.New("cannot create any connection to the device")
 	}
@@ -343,12 +552,16 @@ func (r *BaseRequest) setupSNMPConnection(ctx context.Context) (*network.Request
 		return nil, errors.New("no SNMP connection data available")
 	}
 
+	var con network.RequestDeviceConnectionSNMP
+	if snmpClient, ok := network.SNMPClientFromContext(ctx); ok {
+		con.SnmpClient = snmpClient
+		return &con, nil
+	}
+
 	snmpClient, err := network.NewSNMPClientByConnectionData(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.SNMP)
 	if err != nil {
 		return nil, errors.Wrap(err, "error during NewSNMPClientByConnectionData")
 	}
-
-	var con network.RequestDeviceConnectionSNMP
 	con.SnmpClient = snmpClient
 
 	return &con, nil
@@ -392,6 +605,54 @@ func (r *BaseRequest) setupHTTPConnection() (*network.RequestDeviceConnectionHTT
 	return con, nil
 }
 
+func (r *BaseRequest) setupSSHConnection(ctx context.Context) (*network.RequestDeviceConnectionSSH, error) {
+	if r.DeviceData.ConnectionData.SSH == nil {
+		return nil, errors.New("no SSH connection data available")
+	}
+
+	sshClient, err := network.NewSSHClientByConnectionData(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.SSH)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create ssh client")
+	}
+
+	return &network.RequestDeviceConnectionSSH{
+		SSHClient:      sshClient,
+		ConnectionData: r.DeviceData.ConnectionData.SSH,
+	}, nil
+}
+
+func (r *BaseRequest) setupNETCONFConnection(ctx context.Context) (*network.RequestDeviceConnectionNETCONF, error) {
+	if r.DeviceData.ConnectionData.NETCONF == nil {
+		return nil, errors.New("no NETCONF connection data available")
+	}
+
+	netconfClient, err := network.NewNETCONFClientByConnectionData(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.NETCONF)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create netconf client")
+	}
+
+	return &network.RequestDeviceConnectionNETCONF{
+		NETCONFClient:  netconfClient,
+		ConnectionData: r.DeviceData.ConnectionData.NETCONF,
+	}, nil
+}
+
+func (r *BaseRequest) setupGNMIConnection(ctx context.Context) (*network.RequestDeviceConnectionGNMI, error) {
+	if r.DeviceData.ConnectionData.GNMI == nil {
+		return nil, errors.New("no gNMI connection data available")
+	}
+
+	gnmiClient, err := network.NewGNMIClientByConnectionData(ctx, r.DeviceData.IPAddress, r.DeviceData.ConnectionData.GNMI)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gnmi client")
+	}
+
+	return &network.RequestDeviceConnectionGNMI{
+		GNMIClient:     gnmiClient,
+		ConnectionData: r.DeviceData.ConnectionData.GNMI,
+	}, nil
+}
+
 // BaseResponse
 //
 // BaseResponse defines attributes every response has.
@@ -0,0 +1,195 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+	"sort"
+)
+
+// BatchRequest
+//
+// BatchRequest is the request struct for the batch request.
+// It contains a list of requests of mixed types which are processed concurrently.
+//
+// swagger:model
+type BatchRequest struct {
+	// Number of requests that are processed at the same time (0 => default)
+	Workers  int                `yaml:"workers" json:"workers" xml:"workers"`
+	Requests []BatchRequestItem `yaml:"requests" json:"requests" xml:"requests"`
+}
+
+// BatchRequestItem
+//
+// BatchRequestItem is a single request inside a batch request.
+//
+// swagger:model
+type BatchRequestItem struct {
+	// Optional ID to assign the response to the request
+	ID string `yaml:"id" json:"id" xml:"id"`
+	// Type of the request, equal to the path of the API endpoint
+	//
+	// example: read/interfaces
+	Type string `yaml:"type" json:"type" xml:"type"`
+	// The request, in the same format as for the API endpoint
+	Request Request `yaml:"request" json:"request" xml:"request"`
+}
+
+// BatchResponseItem
+//
+// BatchResponseItem is the response for a single request of a batch request.
+//
+// swagger:model
+type BatchResponseItem struct {
+	ID       string   `yaml:"id" json:"id" xml:"id"`
+	Type     string   `yaml:"type" json:"type" xml:"type"`
+	Response Response `yaml:"response,omitempty" json:"response,omitempty" xml:"response,omitempty"`
+	Error    string   `yaml:"error,omitempty" json:"error,omitempty" xml:"error,omitempty"`
+}
+
+var batchRequestTypes = map[string]func() Request{
+	"identify":                  func() Request { return &IdentifyRequest{} },
+	"check/identify":            func() Request { return &CheckIdentifyRequest{} },
+	"check/snmp":                func() Request { return &CheckSNMPRequest{} },
+	"check/interface-metrics":   func() Request { return &CheckInterfaceMetricsRequest{} },
+	"check/ups":                 func() Request { return &CheckUPSRequest{} },
+	"check/memory-usage":        func() Request { return &CheckMemoryUsageRequest{} },
+	"check/cpu-load":            func() Request { return &CheckCPULoadRequest{} },
+	"check/sbc":                 func() Request { return &CheckSBCRequest{} },
+	"check/server":              func() Request { return &CheckServerRequest{} },
+	"check/disk":                func() Request { return &CheckDiskRequest{} },
+	"check/hardware-health":     func() Request { return &CheckHardwareHealthRequest{} },
+	"check/high-availability":   func() Request { return &CheckHighAvailabilityRequest{} },
+	"check/bgp":                 func() Request { return &CheckBGPRequest{} },
+	"check/routing-protocols":   func() Request { return &CheckRoutingProtocolsRequest{} },
+	"read/interfaces":           func() Request { return &ReadInterfacesRequest{} },
+	"read/count-interfaces":     func() Request { return &ReadCountInterfacesRequest{} },
+	"read/cpu-load":             func() Request { return &ReadCPULoadRequest{} },
+	"read/memory-usage":         func() Request { return &ReadMemoryUsageRequest{} },
+	"read/ups":                  func() Request { return &ReadUPSRequest{} },
+	"read/sbc":                  func() Request { return &ReadSBCRequest{} },
+	"read/server":               func() Request { return &ReadServerRequest{} },
+	"read/disk":                 func() Request { return &ReadDiskRequest{} },
+	"read/hardware-health":      func() Request { return &ReadHardwareHealthRequest{} },
+	"read/high-availability":    func() Request { return &ReadHighAvailabilityRequest{} },
+	"read/inventory":            func() Request { return &ReadInventoryRequest{} },
+	"read/neighbors":            func() Request { return &ReadNeighborsRequest{} },
+	"read/bgp":                  func() Request { return &ReadBGPRequest{} },
+	"read/routing-protocols":    func() Request { return &ReadRoutingProtocolsRequest{} },
+	"read/available-components": func() Request { return &ReadAvailableComponentsRequest{} },
+}
+
+// NewRequestByType returns a new empty request for the given type.
+// The type is equal to the path of the corresponding API endpoint, e.g. "read/interfaces".
+func NewRequestByType(requestType string) (Request, error) {
+	newRequest, ok := batchRequestTypes[requestType]
+	if !ok {
+		return nil, fmt.Errorf("unknown request type '%s'", requestType)
+	}
+	return newRequest(), nil
+}
+
+// GetRequestTypes returns all request types that can be created by NewRequestByType.
+func GetRequestTypes() []string {
+	var types []string
+	for t := range batchRequestTypes {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// UnmarshalJSON decodes the request of the item into the request struct that belongs to the given type.
+func (b *BatchRequestItem) UnmarshalJSON(data []byte) error {
+	var item struct {
+		ID      string          `json:"id"`
+		Type    string          `json:"type"`
+		Request json.RawMessage `json:"request"`
+	}
+	if err := json.Unmarshal(data, &item); err != nil {
+		return err
+	}
+
+	r, err := NewRequestByType(item.Type)
+	if err != nil {
+		return err
+	}
+	if item.Request != nil {
+		if err = json.Unmarshal(item.Request, r); err != nil {
+			return errors.Wrapf(err, "failed to decode request of type '%s'", item.Type)
+		}
+	}
+
+	b.ID = item.ID
+	b.Type = item.Type
+	b.Request = r
+	return nil
+}
+
+// GetDeviceData returns the device data of the request of the batch item.
+func (b *BatchRequestItem) GetDeviceData() *DeviceData {
+	if r, ok := b.Request.(interface{ GetDeviceData() *DeviceData }); ok {
+		return r.GetDeviceData()
+	}
+	return nil
+}
+
+// ParseBatchRequest parses a batch request from either json or yaml.
+func ParseBatchRequest(data []byte) (BatchRequest, error) {
+	// json is a subset of yaml, so the input is decoded as yaml first and then converted to json,
+	// because the request structs only have json tags for all fields
+	var raw interface{}
+	err := yaml.Unmarshal(data, &raw)
+	if err != nil {
+		return BatchRequest{}, errors.Wrap(err, "failed to parse batch request")
+	}
+	converted, err := convertYAMLToJSONCompatible(raw)
+	if err != nil {
+		return BatchRequest{}, errors.Wrap(err, "failed to parse batch request")
+	}
+	j, err := json.Marshal(converted)
+	if err != nil {
+		return BatchRequest{}, errors.Wrap(err, "failed to parse batch request")
+	}
+
+	var b BatchRequest
+	err = json.Unmarshal(j, &b)
+	if err != nil {
+		return BatchRequest{}, errors.Wrap(err, "failed to parse batch request")
+	}
+	if len(b.Requests) == 0 {
+		return BatchRequest{}, errors.New("batch request contains no requests")
+	}
+	return b, nil
+}
+
+func convertYAMLToJSONCompatible(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			k, ok := key.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported non-string key '%v'", key)
+			}
+			converted, err := convertYAMLToJSONCompatible(value)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = converted
+		}
+		return m, nil
+	case []interface{}:
+		for i, value := range v {
+			converted, err := convertYAMLToJSONCompatible(value)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = converted
+		}
+		return v, nil
+	default:
+		return v, nil
+	}
+}
@@ -0,0 +1,54 @@
+package request
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseBatchRequest(t *testing.T) {
+	yamlBatch := `
+workers: 5
+requests:
+  - id: router1
+    type: read/interfaces
+    request:
+      device_data:
+        ip_address: 192.0.2.1
+        connection_data:
+          snmp:
+            communities: ["public"]
+  - type: check/bgp
+    request:
+      device_data:
+        ip_address: 192.0.2.2
+      prefixes_received_thresholds:
+        warningMin: 10
+`
+	jsonBatch := `{"requests": [{"id": "router1", "type": "read/interfaces", "request": {"device_data": {"ip_address": "192.0.2.1", "connection_data": {"snmp": {"communities": ["public"]}}}}}, {"type": "check/bgp", "request": {"device_data": {"ip_address": "192.0.2.2"}, "prefixes_received_thresholds": {"warningMin": 10}}}]}`
+
+	for _, input := range []string{yamlBatch, jsonBatch} {
+		b, err := ParseBatchRequest([]byte(input))
+		if !assert.NoError(t, err) || !assert.Len(t, b.Requests, 2) {
+			return
+		}
+
+		assert.Equal(t, "router1", b.Requests[0].ID)
+		if r, ok := b.Requests[0].Request.(*ReadInterfacesRequest); assert.True(t, ok) {
+			assert.Equal(t, "192.0.2.1", r.DeviceData.IPAddress)
+			if assert.NotNil(t, r.DeviceData.ConnectionData.SNMP) {
+				assert.Equal(t, []string{"public"}, r.DeviceData.ConnectionData.SNMP.Communities)
+			}
+		}
+
+		if r, ok := b.Requests[1].Request.(*CheckBGPRequest); assert.True(t, ok) {
+			assert.Equal(t, "192.0.2.2", b.Requests[1].GetDeviceData().IPAddress)
+			assert.True(t, r.PrefixesReceivedThresholds.HasWarning())
+		}
+	}
+
+	_, err := ParseBatchRequest([]byte(`requests: [{type: read/unknown}]`))
+	assert.Error(t, err)
+
+	_, err = ParseBatchRequest([]byte(`requests: []`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/pkg/errors"
+)
+
+// CheckBGPRequest
+//
+// CheckBGPRequest is the request struct for the check bgp request.
+//
+// swagger:model
+type CheckBGPRequest struct {
+	CheckDeviceRequest
+	PrefixesReceivedThresholds monitoringplugin.Thresholds `yaml:"prefixes_received_thresholds" json:"prefixes_received_thresholds" xml:"prefixes_received_thresholds"`
+	PrefixesAcceptedThresholds monitoringplugin.Thresholds `yaml:"prefixes_accepted_thresholds" json:"prefixes_accepted_thresholds" xml:"prefixes_accepted_thresholds"`
+}
+
+func (r *CheckBGPRequest) validate(ctx context.Context) error {
+	if err := r.PrefixesReceivedThresholds.Validate(); err != nil {
+		return errors.Wrap(err, "prefixes received thresholds are invalid")
+	}
+	if err := r.PrefixesAcceptedThresholds.Validate(); err != nil {
+		return errors.Wrap(err, "prefixes accepted thresholds are invalid")
+	}
+	return r.CheckDeviceRequest.validate(ctx)
+}
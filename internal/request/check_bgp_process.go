@@ -0,0 +1,74 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+)
+
+func (r *CheckBGPRequest) process(ctx context.Context) (Response, error) {
+	r.init()
+
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
+		return &CheckResponse{r.mon.GetInfo()}, nil
+	}
+
+	res, err := com.GetBGPComponent(ctx)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading bgp information", true) {
+		return &CheckResponse{r.mon.GetInfo()}, nil
+	}
+
+	if r.mon.UpdateStatusOnError(r.checkBGPPeers(res.Peers), monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+		r.mon.PrintPerformanceData(false)
+	}
+
+	return &CheckResponse{r.mon.GetInfo()}, nil
+}
+
+func (r *CheckBGPRequest) checkBGPPeers(peers []device.BGPComponentPeer) error {
+	var established int
+	for _, peer := range peers {
+		// administratively stopped peers are expected to be down
+		if peer.AdminStatus != nil && *peer.AdminStatus == device.BGPPeerAdminStatusStop {
+			continue
+		}
+
+		label := "unknown"
+		if peer.PeerAddress != nil {
+			label = *peer.PeerAddress
+		}
+
+		if peer.State != nil {
+			if *peer.State == device.BGPPeerStateEstablished {
+				established++
+			} else {
+				r.mon.UpdateStatus(monitoringplugin.CRITICAL, fmt.Sprintf("bgp peer %s is in state %s", label, *peer.State))
+			}
+		}
+
+		if peer.PrefixesReceived != nil {
+			err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("prefixes_received", *peer.PrefixesReceived).
+				SetLabel(label).
+				SetThresholds(r.PrefixesReceivedThresholds))
+			if err != nil {
+				return err
+			}
+		}
+
+		if peer.PrefixesAccepted != nil {
+			err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("prefixes_accepted", *peer.PrefixesAccepted).
+				SetLabel(label).
+				SetThresholds(r.PrefixesAcceptedThresholds))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("established_peers", established))
+}
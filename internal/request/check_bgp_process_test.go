@@ -0,0 +1,56 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckBGPRequest_checkBGPPeers(t *testing.T) {
+	peer1 := "192.0.2.1"
+	peer2 := "192.0.2.2"
+	peer3 := "192.0.2.3"
+	established := device.BGPPeerStateEstablished
+	active := device.BGPPeerStateActive
+	idle := device.BGPPeerStateIdle
+	start := device.BGPPeerAdminStatusStart
+	stop := device.BGPPeerAdminStatusStop
+	prefixes := uint64(10)
+	peers := []device.BGPComponentPeer{
+		{
+			PeerAddress:      &peer1,
+			State:            &established,
+			AdminStatus:      &start,
+			PrefixesReceived: &prefixes,
+			PrefixesAccepted: &prefixes,
+		},
+		{
+			PeerAddress: &peer2,
+			State:       &active,
+			AdminStatus: &start,
+		},
+		{
+			PeerAddress: &peer3,
+			State:       &idle,
+			AdminStatus: &stop,
+		},
+	}
+
+	r := CheckBGPRequest{
+		PrefixesAcceptedThresholds: monitoringplugin.NewThresholds(100, nil, nil, nil),
+	}
+	r.init()
+
+	if !assert.NoError(t, r.checkBGPPeers(peers)) {
+		return
+	}
+	assert.Equal(t, monitoringplugin.CRITICAL, r.mon.GetStatusCode())
+	assert.Equal(t, []monitoringplugin.OutputMessage{
+		{Status: monitoringplugin.CRITICAL, Message: "bgp peer 192.0.2.2 is in state active"},
+		{Status: monitoringplugin.WARNING, Message: "prefixes_accepted (192.0.2.1) is outside of WARNING threshold"},
+	}, r.mon.GetInfo().Messages)
+}
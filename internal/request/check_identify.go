@@ -1,6 +1,7 @@
 package request
 
 import (
+	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/device"
 )
 
@@ -19,6 +20,9 @@ type CheckIdentifyRequest struct {
 	ModelSeriesDiffWarning  bool `yaml:"model_series_diff_warning" json:"model_series_diff_warning" xml:"model_series_diff_warning"`
 	OsVersionDiffWarning    bool `yaml:"os_version_diff_warning" json:"os_version_diff_warning" xml:"os_version_diff_warning"`
 	SerialNumberDiffWarning bool `yaml:"serial_number_diff_warning" json:"serial_number_diff_warning" xml:"serial_number_diff_warning"`
+
+	// Warn if identify properties of the device changed since the last check, e.g. after a firmware upgrade
+	DetectChanges bool `yaml:"detect_changes" json:"detect_changes" xml:"detect_changes"`
 }
 
 // CheckIdentifyResponse
@@ -30,6 +34,8 @@ type CheckIdentifyResponse struct {
 	CheckResponse
 	IdentifyResult     *device.Device                       `yaml:"identify_result" json:"identify_result" xml:"identify_result"`
 	FailedExpectations map[string]IdentifyExpectationResult `yaml:"failed_expectations" json:"failed_expectations" xml:"failed_expectations"`
+	// The changes of identify properties since the last check, if changes are detected
+	Changes []database.DeviceChange `yaml:"changes,omitempty" json:"changes,omitempty" xml:"changes>change,omitempty"`
 }
 
 // IdentifyExpectationResult is a response struct for the check identify request.
@@ -7,16 +7,20 @@ import (
 	"context"
 	"fmt"
 	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/inexio/thola/internal/utility"
 	"github.com/pkg/errors"
+	"time"
 )
 
 func (r *CheckIdentifyRequest) process(ctx context.Context) (Response, error) {
 	r.init()
 	failedExpectations := make(map[string]IdentifyExpectationResult)
+	start := time.Now()
 
-	identifyRequest := IdentifyRequest{r.BaseRequest}
+	identifyRequest := IdentifyRequest{BaseRequest: r.BaseRequest}
 	response, err := identifyRequest.process(ctx)
 	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while processing identify request", true) {
 		return &CheckIdentifyResponse{
@@ -126,19 +130,67 @@ func (r *CheckIdentifyRequest) process(ctx context.Context) (Response, error) {
 		}
 	}
 
+	var changes []database.DeviceChange
+	if r.DetectChanges {
+		changes, err = r.detectChanges(ctx, start)
+		r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while detecting changes", true)
+	}
+
 	return &CheckIdentifyResponse{
 		CheckResponse:      CheckResponse{r.mon.GetInfo()},
 		IdentifyResult:     &identifyResponse.Device,
 		FailedExpectations: failedExpectations,
+		Changes:            changes,
 	}, nil
 }
 
+// detectChanges warns about every change of the device record since the last check.
+// The identification of the check is already part of the device record.
+func (r *CheckIdentifyRequest) detectChanges(ctx context.Context, start time.Time) ([]database.DeviceChange, error) {
+	db, err := database.GetDB(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get DB")
+	}
+
+	record, err := db.GetDeviceRecord(ctx, r.DeviceData.IPAddress)
+	if err != nil {
+		if tholaerr.IsNotFoundError(err) {
+			// devices are not recorded if no database is used
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "failed to get device record")
+	}
+
+	// on the first check, only changes of the identification of the check are reported
+	since := start
+	if record.ChangesCheckedAt != nil {
+		since = *record.ChangesCheckedAt
+	}
+
+	var changes []database.DeviceChange
+	for _, change := range record.Changes {
+		if !change.Time.After(since) {
+			continue
+		}
+		r.mon.UpdateStatus(monitoringplugin.WARNING, fmt.Sprintf("%s changed from \"%s\" to \"%s\" at %s", change.Property, change.Old, change.New, change.Time.Format(time.RFC3339)))
+		changes = append(changes, change)
+	}
+
+	now := time.Now()
+	record.ChangesCheckedAt = &now
+	err = db.SetDeviceRecord(ctx, r.DeviceData.IPAddress, record)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save device record")
+	}
+	return changes, nil
+}
+
 func (r *CheckIdentifyRequest) validate(ctx context.Context) error {
 	err := r.BaseRequest.validate(ctx)
 	if err != nil {
 		return errors.Wrap(err, "base request is not valid")
 	}
-	if r.Expectations == (device.Device{}) {
+	if r.Expectations == (device.Device{}) && !r.DetectChanges {
 		return errors.New("no expectations given")
 	}
 	return nil
@@ -2,6 +2,10 @@ package request
 
 import (
 	"context"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/pkg/errors"
+	"regexp"
+	"strings"
 )
 
 // CheckInterfaceMetricsRequest
@@ -11,6 +15,16 @@ import (
 // swagger:model
 type CheckInterfaceMetricsRequest struct {
 	PrintInterfaces bool `yaml:"print_interfaces" json:"print_interfaces" xml:"print_interfaces"`
+
+	// If set, the interface counters are stored in the database and rates are calculated based on the previous check.
+	CalculateRates bool `yaml:"calculate_rates" json:"calculate_rates" xml:"calculate_rates"`
+
+	// Thresholds for the utilization of the interfaces in percent. Only available if rates are calculated.
+	UtilizationThresholds monitoringplugin.Thresholds `yaml:"utilization_thresholds" json:"utilization_thresholds" xml:"utilization_thresholds"`
+
+	// Threshold rules which are applied to all interfaces matching the filters of the rule.
+	InterfaceRules []InterfaceThresholdRule `yaml:"interface_rules" json:"interface_rules" xml:"interface_rules"`
+
 	InterfaceOptions
 	CheckDeviceRequest
 }
@@ -19,5 +33,84 @@ func (r *CheckInterfaceMetricsRequest) validate(ctx context.Context) error {
 	if err := r.InterfaceOptions.validate(); err != nil {
 		return err
 	}
+	if err := r.UtilizationThresholds.Validate(); err != nil {
+		return err
+	}
+	if !r.UtilizationThresholds.IsEmpty() && !r.CalculateRates {
+		return errors.New("utilization thresholds can only be used if rates are calculated")
+	}
+	for i := range r.InterfaceRules {
+		if err := r.InterfaceRules[i].validate(); err != nil {
+			return errors.Wrapf(err, "interface rule %d is invalid", i)
+		}
+		if !r.InterfaceRules[i].ErrorRateThresholds.IsEmpty() && !r.CalculateRates {
+			return errors.New("error rate thresholds can only be used if rates are calculated")
+		}
+	}
 	return r.CheckDeviceRequest.validate(ctx)
 }
+
+// InterfaceThresholdRule
+//
+// InterfaceThresholdRule defines thresholds for all interfaces that match the filters of the rule.
+// A rule matches an interface if every non-empty filter has at least one matching regex.
+//
+// swagger:model
+type InterfaceThresholdRule struct {
+	// Regular expressions for the ifType of the interfaces.
+	IfTypeFilter []string `yaml:"ifType_filter" json:"ifType_filter" xml:"ifType_filter"`
+	ifTypeFilter []*regexp.Regexp
+	// Regular expressions for the ifName of the interfaces.
+	IfNameFilter []string `yaml:"ifName_filter" json:"ifName_filter" xml:"ifName_filter"`
+	ifNameFilter []*regexp.Regexp
+	// Regular expressions for the ifDescr of the interfaces. They are applied after 'ifDescr_regex' was applied.
+	IfDescrFilter []string `yaml:"ifDescr_filter" json:"ifDescr_filter" xml:"ifDescr_filter"`
+	ifDescrFilter []*regexp.Regexp
+
+	// Status which is returned if an interface is admin up but not oper up ('warning' or 'critical').
+	OperStatusMismatch string `yaml:"oper_status_mismatch" json:"oper_status_mismatch" xml:"oper_status_mismatch"`
+
+	ErrorCounterThresholds monitoringplugin.Thresholds `yaml:"error_counter_thresholds" json:"error_counter_thresholds" xml:"error_counter_thresholds"`
+	ErrorRateThresholds    monitoringplugin.Thresholds `yaml:"error_rate_thresholds" json:"error_rate_thresholds" xml:"error_rate_thresholds"`
+	RXPowerThresholds      monitoringplugin.Thresholds `yaml:"rx_power_thresholds" json:"rx_power_thresholds" xml:"rx_power_thresholds"`
+	TXPowerThresholds      monitoringplugin.Thresholds `yaml:"tx_power_thresholds" json:"tx_power_thresholds" xml:"tx_power_thresholds"`
+	LevelInThresholds      monitoringplugin.Thresholds `yaml:"level_in_thresholds" json:"level_in_thresholds" xml:"level_in_thresholds"`
+	LevelOutThresholds     monitoringplugin.Thresholds `yaml:"level_out_thresholds" json:"level_out_thresholds" xml:"level_out_thresholds"`
+}
+
+func (r *InterfaceThresholdRule) validate() error {
+	var err error
+	if r.ifTypeFilter, err = compileRegexes(r.IfTypeFilter); err != nil {
+		return errors.Wrap(err, "compiling ifType filter failed")
+	}
+	if r.ifNameFilter, err = compileRegexes(r.IfNameFilter); err != nil {
+		return errors.Wrap(err, "compiling ifName filter failed")
+	}
+	if r.ifDescrFilter, err = compileRegexes(r.IfDescrFilter); err != nil {
+		return errors.Wrap(err, "compiling ifDescr filter failed")
+	}
+
+	if r.OperStatusMismatch != "" && !strings.EqualFold(r.OperStatusMismatch, "warning") && !strings.EqualFold(r.OperStatusMismatch, "critical") {
+		return errors.New("oper status mismatch needs to be 'warning' or 'critical'")
+	}
+
+	for _, thresholds := range []monitoringplugin.Thresholds{r.ErrorCounterThresholds, r.ErrorRateThresholds, r.RXPowerThresholds,
+		r.TXPowerThresholds, r.LevelInThresholds, r.LevelOutThresholds} {
+		if err := thresholds.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func compileRegexes(expressions []string) ([]*regexp.Regexp, error) {
+	var res []*regexp.Regexp
+	for _, expression := range expressions {
+		regex, err := regexp.Compile(expression)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, regex)
+	}
+	return res, nil
+}
@@ -7,11 +7,17 @@ import (
 	"context"
 	"fmt"
 	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/device"
 	"github.com/inexio/thola/internal/deviceclass/groupproperty"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/parser"
+	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"math"
+	"regexp"
+	"time"
 )
 
 type interfaceCheckOutput struct {
@@ -57,6 +63,21 @@ func (r *CheckInterfaceMetricsRequest) process(ctx context.Context) (Response, e
 		return &CheckResponse{r.mon.GetInfo()}, nil
 	}
 
+	var rates map[uint64]interfaceRates
+	if r.CalculateRates {
+		rates, err = r.addInterfaceRatePerformanceData(ctx, interfaces)
+		if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while calculating interface rates", true) {
+			r.mon.PrintPerformanceData(false)
+			return &CheckResponse{r.mon.GetInfo()}, nil
+		}
+	}
+
+	err = r.checkInterfaceRules(interfaces, rates)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while checking interface rules", true) {
+		r.mon.PrintPerformanceData(false)
+		return &CheckResponse{r.mon.GetInfo()}, nil
+	}
+
 	if r.PrintInterfaces {
 		var interfaceOutput []interfaceCheckOutput
 		for _, interf := range interfaces {
@@ -120,11 +141,19 @@ func (r *CheckInterfaceMetricsRequest) getFilter() []groupproperty.Filter {
 	}
 
 	if !r.PrintInterfaces {
-		valueFilter = append(valueFilter,
-			groupproperty.GetValueFilter([]string{"ifType"}),
-			groupproperty.GetValueFilter([]string{"ifName"}),
-			groupproperty.GetValueFilter([]string{"ifAlias"}),
-		)
+		// ifType and ifName are still needed if they are used to select interfaces for threshold rules
+		var ifTypeNeeded, ifNameNeeded bool
+		for _, rule := range r.InterfaceRules {
+			ifTypeNeeded = ifTypeNeeded || len(rule.IfTypeFilter) > 0
+			ifNameNeeded = ifNameNeeded || len(rule.IfNameFilter) > 0
+		}
+		if !ifTypeNeeded {
+			valueFilter = append(valueFilter, groupproperty.GetValueFilter([]string{"ifType"}))
+		}
+		if !ifNameNeeded {
+			valueFilter = append(valueFilter, groupproperty.GetValueFilter([]string{"ifName"}))
+		}
+		valueFilter = append(valueFilter, groupproperty.GetValueFilter([]string{"ifAlias"}))
 	}
 
 	return append(r.InterfaceOptions.getFilter(), valueFilter...)
@@ -234,7 +263,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//traffic_counter_in
-		if counter := checkHCCounter(i.IfHCInOctets, i.IfInOctets); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCInOctets, i.IfInOctets); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("traffic_counter_in", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -242,7 +271,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//traffic_counter_out
-		if counter := checkHCCounter(i.IfHCOutOctets, i.IfOutOctets); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCOutOctets, i.IfOutOctets); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("traffic_counter_out", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -250,7 +279,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//packet_counter_unicast_in
-		if counter := checkHCCounter(i.IfHCInUcastPkts, i.IfInUcastPkts); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCInUcastPkts, i.IfInUcastPkts); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_counter_unicast_in", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -258,7 +287,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//packet_counter_unicast_out
-		if counter := checkHCCounter(i.IfHCOutUcastPkts, i.IfOutUcastPkts); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCOutUcastPkts, i.IfOutUcastPkts); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_counter_unicast_out", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -266,7 +295,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//packet_counter_multicast_in
-		if counter := checkHCCounter(i.IfHCInMulticastPkts, i.IfInMulticastPkts); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCInMulticastPkts, i.IfInMulticastPkts); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_counter_multicast_in", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -274,7 +303,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//packet_counter_multicast_out
-		if counter := checkHCCounter(i.IfHCOutMulticastPkts, i.IfOutMulticastPkts); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCOutMulticastPkts, i.IfOutMulticastPkts); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_counter_multicast_out", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -282,7 +311,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//packet_counter_broadcast_in
-		if counter := checkHCCounter(i.IfHCInBroadcastPkts, i.IfInBroadcastPkts); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCInBroadcastPkts, i.IfInBroadcastPkts); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_counter_broadcast_in", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -290,7 +319,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 		}
 
 		//packet_counter_broadcast_out
-		if counter := checkHCCounter(i.IfHCOutBroadcastPkts, i.IfOutBroadcastPkts); counter != nil {
+		if counter := device.PreferHCCounter(i.IfHCOutBroadcastPkts, i.IfOutBroadcastPkts); counter != nil {
 			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_counter_broadcast_out", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 			if err != nil {
 				return err
@@ -315,14 +344,14 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 
 		//ethernet like interface metrics
 		if i.EthernetLike != nil {
-			if counter := checkHCCounter(i.EthernetLike.Dot3HCStatsAlignmentErrors, i.EthernetLike.Dot3StatsAlignmentErrors); counter != nil {
+			if counter := device.PreferHCCounter(i.EthernetLike.Dot3HCStatsAlignmentErrors, i.EthernetLike.Dot3StatsAlignmentErrors); counter != nil {
 				err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_counter_alignment_errors", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 				if err != nil {
 					return err
 				}
 			}
 
-			if counter := checkHCCounter(i.EthernetLike.Dot3HCStatsFCSErrors, i.EthernetLike.Dot3StatsFCSErrors); counter != nil {
+			if counter := device.PreferHCCounter(i.EthernetLike.Dot3HCStatsFCSErrors, i.EthernetLike.Dot3StatsFCSErrors); counter != nil {
 				err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_counter_fcs_errors", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 				if err != nil {
 					return err
@@ -371,7 +400,7 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 				}
 			}
 
-			if counter := checkHCCounter(i.EthernetLike.Dot3HCStatsInternalMacTransmitErrors, i.EthernetLike.Dot3StatsInternalMacTransmitErrors); counter != nil {
+			if counter := device.PreferHCCounter(i.EthernetLike.Dot3HCStatsInternalMacTransmitErrors, i.EthernetLike.Dot3StatsInternalMacTransmitErrors); counter != nil {
 				err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_counter_internal_mac_transmit_errors", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 				if err != nil {
 					return err
@@ -385,14 +414,14 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 				}
 			}
 
-			if counter := checkHCCounter(i.EthernetLike.Dot3HCStatsFrameTooLongs, i.EthernetLike.Dot3StatsFrameTooLongs); counter != nil {
+			if counter := device.PreferHCCounter(i.EthernetLike.Dot3HCStatsFrameTooLongs, i.EthernetLike.Dot3StatsFrameTooLongs); counter != nil {
 				err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_counter_frame_too_longs", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 				if err != nil {
 					return err
 				}
 			}
 
-			if counter := checkHCCounter(i.EthernetLike.Dot3HCStatsInternalMacReceiveErrors, i.EthernetLike.Dot3StatsInternalMacReceiveErrors); counter != nil {
+			if counter := device.PreferHCCounter(i.EthernetLike.Dot3HCStatsInternalMacReceiveErrors, i.EthernetLike.Dot3StatsInternalMacReceiveErrors); counter != nil {
 				err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_counter_internal_mac_receive_errors", *counter).SetUnit("c").SetLabel(*i.IfDescr))
 				if err != nil {
 					return err
@@ -606,11 +635,457 @@ func addCheckInterfacePerformanceData(interfaces []device.Interface, r *monitori
 	return nil
 }
 
-func checkHCCounter(hcCounter *uint64, counter *uint64) *uint64 {
-	if hcCounter != nil && (*hcCounter != 0 || counter == nil) {
-		return hcCounter
+// addInterfaceRatePerformanceData calculates the interface rates and returns them mapped by the ifIndex of the interfaces.
+func (r *CheckInterfaceMetricsRequest) addInterfaceRatePerformanceData(ctx context.Context, interfaces []device.Interface) (map[uint64]interfaceRates, error) {
+	db, err := database.GetDB(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get DB")
+	}
+
+	current := getInterfaceCounterSnapshot(ctx, interfaces)
+
+	previous, err := db.GetInterfaceCounters(ctx, r.DeviceData.IPAddress)
+	if err != nil && !tholaerr.IsNotFoundError(err) {
+		return nil, errors.Wrap(err, "failed to get previous interface counters")
+	}
+	previousFound := err == nil
+
+	// sysUpTime only decreases if the device was restarted, which also resets all interface counters
+	restarted := previousFound && previous.SysUpTime != nil && current.SysUpTime != nil && *current.SysUpTime < *previous.SysUpTime
+
+	// counters of interfaces that were not read (e.g. because of interface filters) are kept for other checks
+	if previousFound && !restarted {
+		for ifIndex, counters := range previous.Interfaces {
+			if _, ok := current.Interfaces[ifIndex]; !ok {
+				current.Interfaces[ifIndex] = counters
+			}
+		}
+	}
+
+	err = db.SetInterfaceCounters(ctx, r.DeviceData.IPAddress, current)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save interface counters to cache")
+	}
+
+	if !previousFound {
+		r.mon.UpdateStatus(monitoringplugin.OK, "no previous interface counters found, rates will be available with the next check")
+		return nil, nil
+	}
+	if restarted {
+		r.mon.UpdateStatus(monitoringplugin.OK, "device was restarted since the previous check, rates will be available with the next check")
+		return nil, nil
+	}
+
+	rates := make(map[uint64]interfaceRates)
+	for ifIndex, currentCounters := range current.Interfaces {
+		if previousCounters, ok := previous.Interfaces[ifIndex]; ok {
+			rates[ifIndex] = getInterfaceRates(previousCounters, currentCounters)
+		}
+	}
+
+	return rates, addInterfaceRatePerformanceData(interfaces, rates, r.UtilizationThresholds, r.mon)
+}
+
+// getInterfaceCounterSnapshot returns a snapshot of all interface counters that are used for rate calculation.
+func getInterfaceCounterSnapshot(ctx context.Context, interfaces []device.Interface) database.InterfaceCounterSnapshot {
+	snapshot := database.InterfaceCounterSnapshot{
+		Interfaces: make(map[uint64]database.InterfaceCounters),
+	}
+
+	if con, ok := network.DeviceConnectionFromContext(ctx); ok && con.SNMP != nil {
+		res, err := con.SNMP.SnmpClient.SNMPGet(ctx, ".1.3.6.1.2.1.1.3.0")
+		if err == nil && len(res) == 1 {
+			val, err := res[0].GetValue()
+			if err == nil {
+				sysUpTime, err := val.UInt64()
+				if err == nil {
+					snapshot.SysUpTime = &sysUpTime
+				}
+			}
+		}
+		if snapshot.SysUpTime == nil {
+			log.Ctx(ctx).Debug().Msg("failed to read sysUpTime, restarts of the device cannot be detected")
+		}
+	}
+
+	now := time.Now()
+	for _, interf := range interfaces {
+		if interf.IfIndex == nil {
+			continue
+		}
+		counters := make(map[string]database.InterfaceCounter)
+		addInterfaceCounter(counters, "octets_in", interf.IfHCInOctets, interf.IfInOctets)
+		addInterfaceCounter(counters, "octets_out", interf.IfHCOutOctets, interf.IfOutOctets)
+		addInterfaceCounter(counters, "unicast_pkts_in", interf.IfHCInUcastPkts, interf.IfInUcastPkts)
+		addInterfaceCounter(counters, "unicast_pkts_out", interf.IfHCOutUcastPkts, interf.IfOutUcastPkts)
+		addInterfaceCounter(counters, "multicast_pkts_in", interf.IfHCInMulticastPkts, interf.IfInMulticastPkts)
+		addInterfaceCounter(counters, "multicast_pkts_out", interf.IfHCOutMulticastPkts, interf.IfOutMulticastPkts)
+		addInterfaceCounter(counters, "broadcast_pkts_in", interf.IfHCInBroadcastPkts, interf.IfInBroadcastPkts)
+		addInterfaceCounter(counters, "broadcast_pkts_out", interf.IfHCOutBroadcastPkts, interf.IfOutBroadcastPkts)
+		addInterfaceCounter(counters, "errors_in", nil, interf.IfInErrors)
+		addInterfaceCounter(counters, "errors_out", nil, interf.IfOutErrors)
+		addInterfaceCounter(counters, "discards_in", nil, interf.IfInDiscards)
+		addInterfaceCounter(counters, "discards_out", nil, interf.IfOutDiscards)
+		snapshot.Interfaces[*interf.IfIndex] = database.InterfaceCounters{
+			Time:     now,
+			Counters: counters,
+		}
+	}
+
+	return snapshot
+}
+
+func addInterfaceCounter(counters map[string]database.InterfaceCounter, name string, hcCounter, counter *uint64) {
+	c := device.PreferHCCounter(hcCounter, counter)
+	if c == nil {
+		return
+	}
+	counters[name] = database.InterfaceCounter{
+		Value:        *c,
+		HighCapacity: c == hcCounter,
+	}
+}
+
+type interfaceRates struct {
+	trafficIn   *float64
+	trafficOut  *float64
+	packetsIn   *float64
+	packetsOut  *float64
+	errorsIn    *float64
+	errorsOut   *float64
+	discardsIn  *float64
+	discardsOut *float64
+}
+
+// getInterfaceRates returns the rates per second between two counter snapshots of an interface.
+// Traffic rates are returned in bit/s.
+func getInterfaceRates(previous, current database.InterfaceCounters) interfaceRates {
+	var rates interfaceRates
+
+	interval := current.Time.Sub(previous.Time).Seconds()
+	if interval <= 0 {
+		return rates
+	}
+
+	rate := func(name string) *float64 {
+		prev, ok := previous.Counters[name]
+		if !ok {
+			return nil
+		}
+		cur, ok := current.Counters[name]
+		if !ok {
+			return nil
+		}
+		delta, ok := getCounterDelta(prev, cur)
+		if !ok {
+			return nil
+		}
+		res := float64(delta) / interval
+		return &res
+	}
+
+	if octets := rate("octets_in"); octets != nil {
+		bits := *octets * 8
+		rates.trafficIn = &bits
+	}
+	if octets := rate("octets_out"); octets != nil {
+		bits := *octets * 8
+		rates.trafficOut = &bits
 	}
-	return counter
+	rates.packetsIn = sumRates(rate("unicast_pkts_in"), rate("multicast_pkts_in"), rate("broadcast_pkts_in"))
+	rates.packetsOut = sumRates(rate("unicast_pkts_out"), rate("multicast_pkts_out"), rate("broadcast_pkts_out"))
+	rates.errorsIn = rate("errors_in")
+	rates.errorsOut = rate("errors_out")
+	rates.discardsIn = rate("discards_in")
+	rates.discardsOut = rate("discards_out")
+
+	return rates
+}
+
+func addInterfaceRatePerformanceData(interfaces []device.Interface, rates map[uint64]interfaceRates, utilizationThresholds monitoringplugin.Thresholds, r *monitoringplugin.Response) error {
+	for _, i := range interfaces {
+		if i.IfIndex == nil {
+			continue
+		}
+		rate, ok := rates[*i.IfIndex]
+		if !ok {
+			continue
+		}
+
+		//traffic_rate_in
+		if rate.trafficIn != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("traffic_rate_in", math.Round(*rate.trafficIn)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+
+			//interface_utilization_in
+			if speed := getUtilizationSpeed(i, i.MaxSpeedIn); speed != nil {
+				err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("interface_utilization_in", roundUtilization(*rate.trafficIn/float64(*speed)*100)).
+					SetUnit("%").
+					SetLabel(*i.IfDescr).
+					SetThresholds(utilizationThresholds))
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		//traffic_rate_out
+		if rate.trafficOut != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("traffic_rate_out", math.Round(*rate.trafficOut)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+
+			//interface_utilization_out
+			if speed := getUtilizationSpeed(i, i.MaxSpeedOut); speed != nil {
+				err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("interface_utilization_out", roundUtilization(*rate.trafficOut/float64(*speed)*100)).
+					SetUnit("%").
+					SetLabel(*i.IfDescr).
+					SetThresholds(utilizationThresholds))
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		//packet_rate_in
+		if rate.packetsIn != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_rate_in", roundRate(*rate.packetsIn)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+		}
+
+		//packet_rate_out
+		if rate.packetsOut != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_rate_out", roundRate(*rate.packetsOut)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+		}
+
+		//error_rate_in
+		if rate.errorsIn != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_rate_in", roundRate(*rate.errorsIn)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+		}
+
+		//error_rate_out
+		if rate.errorsOut != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("error_rate_out", roundRate(*rate.errorsOut)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+		}
+
+		//packet_rate_discard_in
+		if rate.discardsIn != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_rate_discard_in", roundRate(*rate.discardsIn)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+		}
+
+		//packet_rate_discard_out
+		if rate.discardsOut != nil {
+			err := r.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("packet_rate_discard_out", roundRate(*rate.discardsOut)).SetLabel(*i.IfDescr))
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *CheckInterfaceMetricsRequest) checkInterfaceRules(interfaces []device.Interface, rates map[uint64]interfaceRates) error {
+	for _, rule := range r.InterfaceRules {
+		for _, i := range interfaces {
+			if !rule.matches(i) {
+				continue
+			}
+			var rate interfaceRates
+			if i.IfIndex != nil {
+				rate = rates[*i.IfIndex]
+			}
+			err := rule.check(i, rate, r.mon)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *InterfaceThresholdRule) matches(interf device.Interface) bool {
+	return matchesAnyRegex(r.ifTypeFilter, interf.IfType) &&
+		matchesAnyRegex(r.ifNameFilter, interf.IfName) &&
+		matchesAnyRegex(r.ifDescrFilter, interf.IfDescr)
+}
+
+// matchesAnyRegex returns true if no regexes are given or the value matches at least one of them.
+func matchesAnyRegex(regexes []*regexp.Regexp, value *string) bool {
+	if len(regexes) == 0 {
+		return true
+	}
+	if value == nil {
+		return false
+	}
+	for _, regex := range regexes {
+		if regex.MatchString(*value) {
+			return true
+		}
+	}
+	return false
+}
+
+type interfaceThresholdCheck struct {
+	thresholds monitoringplugin.Thresholds
+	metric     string
+	label      string
+	value      *float64
+}
+
+func (r *InterfaceThresholdRule) check(i device.Interface, rate interfaceRates, mon *monitoringplugin.Response) error {
+	if r.OperStatusMismatch != "" && i.IfAdminStatus != nil && i.IfOperStatus != nil &&
+		*i.IfAdminStatus == device.StatusUp && *i.IfOperStatus != device.StatusUp {
+		mon.UpdateStatus(monitoringplugin.String2StatusCode(r.OperStatusMismatch), fmt.Sprintf("interface %s is admin up, but oper %s", *i.IfDescr, *i.IfOperStatus))
+	}
+
+	checks := []interfaceThresholdCheck{
+		{r.ErrorCounterThresholds, "error_counter_in", *i.IfDescr, uint64ToFloat64(i.IfInErrors)},
+		{r.ErrorCounterThresholds, "error_counter_out", *i.IfDescr, uint64ToFloat64(i.IfOutErrors)},
+		{r.ErrorRateThresholds, "error_rate_in", *i.IfDescr, rate.errorsIn},
+		{r.ErrorRateThresholds, "error_rate_out", *i.IfDescr, rate.errorsOut},
+	}
+
+	if i.Radio != nil {
+		checks = append(checks,
+			interfaceThresholdCheck{r.LevelInThresholds, "interface_level_in", *i.IfDescr, i.Radio.LevelIn},
+			interfaceThresholdCheck{r.LevelOutThresholds, "interface_level_out", *i.IfDescr, i.Radio.LevelOut},
+		)
+		for _, channel := range i.Radio.Channels {
+			if channel.Channel != nil {
+				checks = append(checks,
+					interfaceThresholdCheck{r.LevelInThresholds, "level_in", *i.IfDescr + "_" + *channel.Channel, channel.LevelIn},
+					interfaceThresholdCheck{r.LevelOutThresholds, "level_out", *i.IfDescr + "_" + *channel.Channel, channel.LevelOut},
+				)
+			}
+		}
+	}
+
+	if i.DWDM != nil {
+		checks = append(checks,
+			interfaceThresholdCheck{r.RXPowerThresholds, "rx_power", *i.IfDescr, i.DWDM.RXPower},
+			interfaceThresholdCheck{r.TXPowerThresholds, "tx_power", *i.IfDescr, i.DWDM.TXPower},
+		)
+		for _, channel := range i.DWDM.Channels {
+			if channel.Channel != nil {
+				checks = append(checks,
+					interfaceThresholdCheck{r.RXPowerThresholds, "rx_power", *i.IfDescr + "_" + *channel.Channel, channel.RXPower},
+					interfaceThresholdCheck{r.TXPowerThresholds, "tx_power", *i.IfDescr + "_" + *channel.Channel, channel.TXPower},
+				)
+			}
+		}
+	}
+
+	if i.OpticalAmplifier != nil {
+		checks = append(checks,
+			interfaceThresholdCheck{r.RXPowerThresholds, "rx_power", *i.IfDescr, i.OpticalAmplifier.RXPower},
+			interfaceThresholdCheck{r.TXPowerThresholds, "tx_power", *i.IfDescr, i.OpticalAmplifier.TXPower},
+		)
+	}
+
+	if i.OpticalTransponder != nil {
+		checks = append(checks,
+			interfaceThresholdCheck{r.RXPowerThresholds, "rx_power", *i.IfDescr, i.OpticalTransponder.RXPower},
+			interfaceThresholdCheck{r.TXPowerThresholds, "tx_power", *i.IfDescr, i.OpticalTransponder.TXPower},
+		)
+	}
+
+	if i.OpticalOPM != nil {
+		checks = append(checks, interfaceThresholdCheck{r.RXPowerThresholds, "rx_power", *i.IfDescr, i.OpticalOPM.RXPower})
+		for _, channel := range i.OpticalOPM.Channels {
+			if channel.Channel != nil {
+				checks = append(checks, interfaceThresholdCheck{r.RXPowerThresholds, "rx_power", *i.IfDescr + "_" + *channel.Channel, channel.RXPower})
+			}
+		}
+	}
+
+	for _, c := range checks {
+		if c.value == nil || c.thresholds.IsEmpty() {
+			continue
+		}
+		err := mon.CheckThresholds(c.thresholds, *c.value, c.metric+" ("+c.label+")")
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func uint64ToFloat64(i *uint64) *float64 {
+	if i == nil {
+		return nil
+	}
+	f := float64(*i)
+	return &f
+}
+
+// getCounterDelta returns the difference between two values of the same counter.
+// 32-bit counters are expected to wrap, a decreasing 64-bit counter is considered as reset.
+func getCounterDelta(previous, current database.InterfaceCounter) (uint64, bool) {
+	if previous.HighCapacity != current.HighCapacity {
+		return 0, false
+	}
+	if current.Value >= previous.Value {
+		return current.Value - previous.Value, true
+	}
+	if current.HighCapacity || previous.Value > math.MaxUint32 {
+		return 0, false
+	}
+	return math.MaxUint32 - previous.Value + current.Value + 1, true
+}
+
+func sumRates(rates ...*float64) *float64 {
+	var sum *float64
+	for _, rate := range rates {
+		if rate == nil {
+			continue
+		}
+		if sum == nil {
+			sum = new(float64)
+		}
+		*sum += *rate
+	}
+	return sum
+}
+
+func roundRate(rate float64) float64 {
+	return math.Round(rate*1000) / 1000
+}
+
+func roundUtilization(utilization float64) float64 {
+	return math.Round(utilization*100) / 100
+}
+
+// getUtilizationSpeed returns the speed of an interface in bit/s which is used to calculate the utilization.
+// ifSpeed can hold at most 2^32-1, so ifHighSpeed is used for faster interfaces.
+func getUtilizationSpeed(interf device.Interface, maxSpeed *uint64) *uint64 {
+	if maxSpeed != nil && *maxSpeed != 0 {
+		return maxSpeed
+	}
+	if interf.IfHighSpeed != nil && *interf.IfHighSpeed != 0 && (interf.IfSpeed == nil || *interf.IfSpeed == math.MaxUint32) {
+		speed := *interf.IfHighSpeed * 1000000
+		return &speed
+	}
+	if interf.IfSpeed != nil && *interf.IfSpeed != 0 {
+		return interf.IfSpeed
+	}
+	return nil
 }
 
 func getMaxSpeedIn(interf device.Interface) *uint64 {
@@ -0,0 +1,144 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetCounterDelta(t *testing.T) {
+	delta, ok := getCounterDelta(database.InterfaceCounter{Value: 100}, database.InterfaceCounter{Value: 150})
+	if assert.True(t, ok) {
+		assert.Equal(t, uint64(50), delta)
+	}
+
+	// 32-bit counter wrapped
+	delta, ok = getCounterDelta(database.InterfaceCounter{Value: math.MaxUint32 - 9}, database.InterfaceCounter{Value: 10})
+	if assert.True(t, ok) {
+		assert.Equal(t, uint64(20), delta)
+	}
+
+	// 64-bit counter was reset
+	_, ok = getCounterDelta(database.InterfaceCounter{Value: 100, HighCapacity: true}, database.InterfaceCounter{Value: 10, HighCapacity: true})
+	assert.False(t, ok)
+
+	// counter type changed
+	_, ok = getCounterDelta(database.InterfaceCounter{Value: 100}, database.InterfaceCounter{Value: 150, HighCapacity: true})
+	assert.False(t, ok)
+}
+
+func TestAddInterfaceRatePerformanceData(t *testing.T) {
+	ifIndex := uint64(1)
+	ifDescr := "Ethernet 1"
+	ifSpeed := uint64(math.MaxUint32)
+	ifHighSpeed := uint64(10000)
+	interfaces := []device.Interface{
+		{
+			IfIndex:     &ifIndex,
+			IfDescr:     &ifDescr,
+			IfSpeed:     &ifSpeed,
+			IfHighSpeed: &ifHighSpeed,
+		},
+	}
+
+	now := time.Now()
+	previous := database.InterfaceCounters{
+		Time: now.Add(-10 * time.Second),
+		Counters: map[string]database.InterfaceCounter{
+			"octets_in":       {Value: 0, HighCapacity: true},
+			"unicast_pkts_in": {Value: math.MaxUint32 - 99},
+			"errors_in":       {Value: 5},
+		},
+	}
+	current := database.InterfaceCounters{
+		Time: now,
+		Counters: map[string]database.InterfaceCounter{
+			"octets_in":       {Value: 10000000000, HighCapacity: true},
+			"unicast_pkts_in": {Value: 900},
+			"errors_in":       {Value: 15},
+		},
+	}
+	rates := map[uint64]interfaceRates{
+		1: getInterfaceRates(previous, current),
+	}
+
+	mon := monitoringplugin.NewResponse("checked")
+	err := addInterfaceRatePerformanceData(interfaces, rates, monitoringplugin.NewThresholds(0, 70, 0, 90), mon)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	values := make(map[string]interface{})
+	for _, point := range mon.GetInfo().PerformanceData {
+		values[point.Metric] = point.Value
+	}
+	assert.Equal(t, map[string]interface{}{
+		"traffic_rate_in":          8000000000.0,
+		"interface_utilization_in": 80.0,
+		"packet_rate_in":           100.0,
+		"error_rate_in":            1.0,
+	}, values)
+	assert.Equal(t, monitoringplugin.WARNING, mon.GetStatusCode())
+}
+
+func TestCheckInterfaceMetricsRequest_checkInterfaceRules(t *testing.T) {
+	up := device.StatusUp
+	down := device.StatusDown
+	ethernet := "ethernetCsmacd"
+	dwdm := "opticalChannel"
+	ifDescr1 := "Ethernet 1"
+	ifDescr2 := "Optical 1"
+	rxPower := -25.0
+	interfaces := []device.Interface{
+		{
+			IfDescr:       &ifDescr1,
+			IfType:        &ethernet,
+			IfAdminStatus: &up,
+			IfOperStatus:  &down,
+		},
+		{
+			IfDescr:       &ifDescr2,
+			IfType:        &dwdm,
+			IfAdminStatus: &up,
+			IfOperStatus:  &up,
+			DWDM: &device.DWDMInterface{
+				RXPower: &rxPower,
+			},
+		},
+	}
+
+	r := CheckInterfaceMetricsRequest{
+		InterfaceRules: []InterfaceThresholdRule{
+			{
+				IfTypeFilter:       []string{"^ethernetCsmacd$"},
+				OperStatusMismatch: "warning",
+			},
+			{
+				IfDescrFilter:     []string{"^Optical"},
+				RXPowerThresholds: monitoringplugin.NewThresholds(-20, nil, -30, nil),
+			},
+		},
+	}
+	for i := range r.InterfaceRules {
+		if !assert.NoError(t, r.InterfaceRules[i].validate()) {
+			return
+		}
+	}
+	r.init()
+
+	err := r.checkInterfaceRules(interfaces, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, monitoringplugin.WARNING, r.mon.GetStatusCode())
+		assert.Equal(t, []monitoringplugin.OutputMessage{
+			{Status: monitoringplugin.WARNING, Message: "interface Ethernet 1 is admin up, but oper down"},
+			{Status: monitoringplugin.WARNING, Message: "rx_power (Optical 1) is outside of WARNING threshold"},
+		}, r.mon.GetInfo().Messages)
+	}
+}
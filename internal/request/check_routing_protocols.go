@@ -0,0 +1,27 @@
+package request
+
+import (
+	"context"
+	"errors"
+)
+
+// CheckRoutingProtocolsRequest
+//
+// CheckRoutingProtocolsRequest is the request struct for the check routing-protocols request.
+//
+// swagger:model
+type CheckRoutingProtocolsRequest struct {
+	CheckDeviceRequest
+	ExpectedOSPFNeighbors   *int `yaml:"expected_ospf_neighbors" json:"expected_ospf_neighbors" xml:"expected_ospf_neighbors"`
+	ExpectedISISAdjacencies *int `yaml:"expected_isis_adjacencies" json:"expected_isis_adjacencies" xml:"expected_isis_adjacencies"`
+}
+
+func (r *CheckRoutingProtocolsRequest) validate(ctx context.Context) error {
+	if r.ExpectedOSPFNeighbors != nil && *r.ExpectedOSPFNeighbors < 0 {
+		return errors.New("expected ospf neighbors must not be negative")
+	}
+	if r.ExpectedISISAdjacencies != nil && *r.ExpectedISISAdjacencies < 0 {
+		return errors.New("expected is-is adjacencies must not be negative")
+	}
+	return r.CheckDeviceRequest.validate(ctx)
+}
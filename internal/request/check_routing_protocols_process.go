@@ -0,0 +1,91 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"fmt"
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+)
+
+func (r *CheckRoutingProtocolsRequest) process(ctx context.Context) (Response, error) {
+	r.init()
+
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while getting communicator", true) {
+		return &CheckResponse{r.mon.GetInfo()}, nil
+	}
+
+	res, err := com.GetRoutingProtocolsComponent(ctx)
+	if r.mon.UpdateStatusOnError(err, monitoringplugin.UNKNOWN, "error while reading routing protocols information", true) {
+		return &CheckResponse{r.mon.GetInfo()}, nil
+	}
+
+	if r.mon.UpdateStatusOnError(r.checkRoutingProtocols(res), monitoringplugin.UNKNOWN, "error while adding performance data point", true) {
+		r.mon.PrintPerformanceData(false)
+	}
+
+	return &CheckResponse{r.mon.GetInfo()}, nil
+}
+
+func (r *CheckRoutingProtocolsRequest) checkRoutingProtocols(res device.RoutingProtocolsComponent) error {
+	var ospfFull int
+	for _, neighbor := range res.OSPFNeighbors {
+		if neighbor.State == nil {
+			continue
+		}
+		if *neighbor.State == device.OSPFNeighborStateFull {
+			ospfFull++
+			continue
+		}
+		label := "unknown"
+		if neighbor.NeighborAddress != nil {
+			label = *neighbor.NeighborAddress
+		}
+		r.mon.UpdateStatus(monitoringplugin.WARNING, fmt.Sprintf("ospf neighbor %s is in state %s", label, *neighbor.State))
+	}
+
+	var isisUp int
+	for _, adjacency := range res.ISISAdjacencies {
+		if adjacency.State == nil {
+			continue
+		}
+		if *adjacency.State == device.ISISAdjacencyStateUp {
+			isisUp++
+			continue
+		}
+		label := "unknown"
+		if adjacency.NeighborSystemID != nil {
+			label = *adjacency.NeighborSystemID
+		}
+		r.mon.UpdateStatus(monitoringplugin.WARNING, fmt.Sprintf("is-is adjacency %s is in state %s", label, *adjacency.State))
+	}
+
+	if r.ExpectedOSPFNeighbors != nil && *r.ExpectedOSPFNeighbors != ospfFull {
+		r.mon.UpdateStatus(monitoringplugin.CRITICAL, fmt.Sprintf("%d ospf neighbors are full (expected: %d)", ospfFull, *r.ExpectedOSPFNeighbors))
+	}
+	if r.ExpectedISISAdjacencies != nil && *r.ExpectedISISAdjacencies != isisUp {
+		r.mon.UpdateStatus(monitoringplugin.CRITICAL, fmt.Sprintf("%d is-is adjacencies are up (expected: %d)", isisUp, *r.ExpectedISISAdjacencies))
+	}
+
+	if res.OSPFNeighbors != nil {
+		if err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("ospf_neighbors", len(res.OSPFNeighbors))); err != nil {
+			return err
+		}
+		if err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("ospf_neighbors_full", ospfFull)); err != nil {
+			return err
+		}
+	}
+	if res.ISISAdjacencies != nil {
+		if err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("isis_adjacencies", len(res.ISISAdjacencies))); err != nil {
+			return err
+		}
+		if err := r.mon.AddPerformanceDataPoint(monitoringplugin.NewPerformanceDataPoint("isis_adjacencies_up", isisUp)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"github.com/inexio/go-monitoringplugin"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCheckRoutingProtocolsRequest_checkRoutingProtocols(t *testing.T) {
+	neighbor1 := "192.0.2.1"
+	neighbor2 := "192.0.2.2"
+	systemID := "1921.6800.1001"
+	full := device.OSPFNeighborStateFull
+	exchange := device.OSPFNeighborStateExchange
+	up := device.ISISAdjacencyStateUp
+	res := device.RoutingProtocolsComponent{
+		OSPFNeighbors: []device.RoutingProtocolsComponentOSPFNeighbor{
+			{
+				NeighborAddress: &neighbor1,
+				State:           &full,
+			},
+			{
+				NeighborAddress: &neighbor2,
+				State:           &exchange,
+			},
+		},
+		ISISAdjacencies: []device.RoutingProtocolsComponentISISAdjacency{
+			{
+				NeighborSystemID: &systemID,
+				State:            &up,
+			},
+		},
+	}
+
+	expectedOSPF := 2
+	expectedISIS := 1
+	r := CheckRoutingProtocolsRequest{
+		ExpectedOSPFNeighbors:   &expectedOSPF,
+		ExpectedISISAdjacencies: &expectedISIS,
+	}
+	r.init()
+
+	if !assert.NoError(t, r.checkRoutingProtocols(res)) {
+		return
+	}
+	assert.Equal(t, monitoringplugin.CRITICAL, r.mon.GetStatusCode())
+	assert.Equal(t, []monitoringplugin.OutputMessage{
+		{Status: monitoringplugin.CRITICAL, Message: "1 ospf neighbors are full (expected: 2)"},
+		{Status: monitoringplugin.WARNING, Message: "ospf neighbor 192.0.2.2 is in state exchange"},
+	}, r.mon.GetInfo().Messages)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/inexio/go-monitoringplugin"
 	"github.com/inexio/thola/doc"
+	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/network"
 	"github.com/inexio/thola/internal/parser"
 	"github.com/inexio/thola/internal/tholaerr"
@@ -128,6 +129,14 @@ func (r *CheckHighAvailabilityRequest) process(ctx context.Context) (Response, e
 	return checkProcess(ctx, r, "check/high-availability"), nil
 }
 
+func (r *CheckBGPRequest) process(ctx context.Context) (Response, error) {
+	return checkProcess(ctx, r, "check/bgp"), nil
+}
+
+func (r *CheckRoutingProtocolsRequest) process(ctx context.Context) (Response, error) {
+	return checkProcess(ctx, r, "check/routing-protocols"), nil
+}
+
 func (r *ReadInterfacesRequest) process(ctx context.Context) (Response, error) {
 	apiFormat := viper.GetString("target-api-format")
 	responseBody, err := sendToAPI(ctx, r, "read/interfaces", apiFormat)
@@ -240,6 +249,34 @@ func (r *ReadDiskRequest) process(ctx context.Context) (Response, error) {
 	return &res, nil
 }
 
+func (r *ReadInventoryRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/inventory", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadInventoryResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadNeighborsRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/neighbors", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadNeighborsResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
 func (r *ReadHardwareHealthRequest) process(ctx context.Context) (Response, error) {
 	apiFormat := viper.GetString("target-api-format")
 	responseBody, err := sendToAPI(ctx, r, "read/hardware-health", apiFormat)
@@ -301,7 +338,75 @@ func checkProcess(ctx context.Context, r Request, apiPath string) Response {
 	return &res
 }
 
+func (r *ListDevicesRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	queryParams := map[string]string{
+		"vendor": r.Vendor,
+		"model":  r.Model,
+		"class":  r.Class,
+	}
+	path := "devices"
+	if r.IPAddress != "" {
+		path += "/" + r.IPAddress
+	}
+	responseBody, err := callAPI(ctx, "GET", path, "", queryParams, apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ListDevicesResponse
+	if r.IPAddress != "" {
+		var record database.DeviceRecord
+		err = parser.ToStruct(responseBody, apiFormat, &record)
+		if err == nil && r.matches(record) {
+			res.Devices = append(res.Devices, record)
+		}
+	} else {
+		err = parser.ToStruct(responseBody, apiFormat, &res)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *DeviceHistoryRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := callAPI(ctx, "GET", "devices/"+r.IPAddress+"/history", "", nil, apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res DeviceHistoryResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *DiscoverRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "discover", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res DiscoverResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
 func sendToAPI(ctx context.Context, request Request, path, format string) ([]byte, error) {
+	b, err := parser.Parse(request, format)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse request to format '%s'", format)
+	}
+	return callAPI(ctx, "POST", path, string(b), nil, format)
+}
+
+// callAPI sends a request to the thola api and returns the body of the response.
+func callAPI(ctx context.Context, method, path, body string, queryParams map[string]string, format string) ([]byte, error) {
 	apiUserName := viper.GetString("target-api-username")
 	apiPassword := viper.GetString("target-api-password")
 
@@ -323,18 +428,13 @@ func sendToAPI(ctx context.Context, request Request, path, format string) ([]byt
 		return nil, errors.Wrap(err, "error during set format of http client")
 	}
 
-	b, err := parser.Parse(request, format)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to parse request to format '%s'", format)
-	}
-
 	header := map[string]string{"User-Agent": "Thola Client " + doc.Version}
 	rid, ok := RequestIDFromContext(ctx)
 	if ok {
 		header["X-Request-ID"] = rid
 	}
 
-	restyResponse, err := client.Request(ctx, "POST", path, string(b), header, nil)
+	restyResponse, err := client.Request(ctx, method, path, body, header, queryParams)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to send request to api")
 	}
@@ -358,3 +458,31 @@ func sendToAPI(ctx context.Context, request Request, path, format string) ([]byt
 
 	return restyResponse.Body(), nil
 }
+
+func (r *ReadBGPRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/bgp", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadBGPResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
+
+func (r *ReadRoutingProtocolsRequest) process(ctx context.Context) (Response, error) {
+	apiFormat := viper.GetString("target-api-format")
+	responseBody, err := sendToAPI(ctx, r, "read/routing-protocols", apiFormat)
+	if err != nil {
+		return nil, err
+	}
+	var res ReadRoutingProtocolsResponse
+	err = parser.ToStruct(responseBody, apiFormat, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse api response body to thola response")
+	}
+	return &res, nil
+}
@@ -0,0 +1,55 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/credential"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+)
+
+// applyCredentialProfile sets the communities and SNMP v3 data of the credential profile of the request,
+// or of the configured credential profile if the request has none, if they are not set in the request.
+func (r *BaseRequest) applyCredentialProfile(ctx context.Context, configProfile *string) error {
+	profile := configProfile
+	if r.DeviceData.ConnectionData.SNMP != nil && r.DeviceData.ConnectionData.SNMP.CredentialProfile != nil {
+		profile = r.DeviceData.ConnectionData.SNMP.CredentialProfile
+	}
+	if profile == nil || *profile == "" {
+		return nil
+	}
+
+	credentials, err := credential.GetSNMPCredentials(ctx, *profile)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get credentials of credential profile '%s'", *profile)
+	}
+
+	if r.DeviceData.ConnectionData.SNMP == nil {
+		r.DeviceData.ConnectionData.SNMP = &network.SNMPConnectionData{}
+	}
+	snmp := r.DeviceData.ConnectionData.SNMP
+	if len(snmp.Communities) == 0 {
+		snmp.Communities = credentials.Communities
+	}
+	if snmp.V3Data.Level == nil {
+		snmp.V3Data.Level = credentials.V3Data.Level
+	}
+	if snmp.V3Data.ContextName == nil {
+		snmp.V3Data.ContextName = credentials.V3Data.ContextName
+	}
+	if snmp.V3Data.User == nil {
+		snmp.V3Data.User = credentials.V3Data.User
+	}
+	if snmp.V3Data.AuthKey == nil {
+		snmp.V3Data.AuthKey = credentials.V3Data.AuthKey
+	}
+	if snmp.V3Data.AuthProtocol == nil {
+		snmp.V3Data.AuthProtocol = credentials.V3Data.AuthProtocol
+	}
+	if snmp.V3Data.PrivKey == nil {
+		snmp.V3Data.PrivKey = credentials.V3Data.PrivKey
+	}
+	if snmp.V3Data.PrivProtocol == nil {
+		snmp.V3Data.PrivProtocol = credentials.V3Data.PrivProtocol
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+)
+
+// DeviceHistoryRequest
+//
+// DeviceHistoryRequest is the request struct for the device history request.
+// It returns the changes of the identify properties of a device in the device registry.
+//
+// swagger:model
+type DeviceHistoryRequest struct {
+	// The IP address of the device
+	IPAddress string `yaml:"ip_address" json:"ip_address" xml:"ip_address"`
+	Timeout   *int   `yaml:"timeout" json:"timeout" xml:"timeout"`
+}
+
+// DeviceHistoryResponse
+//
+// DeviceHistoryResponse is the response struct for the device history request.
+//
+// swagger:model
+type DeviceHistoryResponse struct {
+	// The IP address of the device
+	IPAddress string `yaml:"ip_address" json:"ip_address" xml:"ip_address"`
+	// The changes of the identify properties, oldest first
+	Changes []database.DeviceChange `yaml:"changes" json:"changes" xml:"changes>change"`
+	BaseResponse
+}
+
+func (r *DeviceHistoryRequest) setupConnection(_ context.Context) (*network.RequestDeviceConnection, error) {
+	return &network.RequestDeviceConnection{}, nil
+}
+
+func (r *DeviceHistoryRequest) getTimeout() *int {
+	return r.Timeout
+}
+
+func (r *DeviceHistoryRequest) validate(_ context.Context) error {
+	if r.IPAddress == "" {
+		return errors.New("no ip address given")
+	}
+	return nil
+}
+
+// HandlePreProcessError handles an error that occurred before the request was processed.
+func (r *DeviceHistoryRequest) HandlePreProcessError(err error) (Response, error) {
+	return nil, err
+}
+
+// GetDeviceData returns the device data of the request.
+func (r *DeviceHistoryRequest) GetDeviceData() *DeviceData {
+	return nil
+}
@@ -0,0 +1,31 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/database"
+	"github.com/pkg/errors"
+)
+
+func (r *DeviceHistoryRequest) process(ctx context.Context) (Response, error) {
+	db, err := database.GetDB(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get DB")
+	}
+
+	record, err := db.GetDeviceRecord(ctx, r.IPAddress)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get device record")
+	}
+
+	res := DeviceHistoryResponse{
+		IPAddress: record.IPAddress,
+		Changes:   record.Changes,
+	}
+	if res.Changes == nil {
+		res.Changes = []database.DeviceChange{}
+	}
+	return &res, nil
+}
@@ -0,0 +1,191 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"github.com/gocarina/gocsv"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+	"net"
+	"strconv"
+)
+
+// maxDiscoverHostBits is the maximum number of host bits of a network that can be discovered.
+const maxDiscoverHostBits = 16
+
+const defaultDiscoverWorkers = 32
+
+// DiscoverRequest
+//
+// DiscoverRequest is the request struct for the discover request.
+// It identifies every device of a network that answers with the given connection data.
+//
+// swagger:model
+type DiscoverRequest struct {
+	// The network to discover in CIDR notation, at most 65536 addresses
+	//
+	// example: 192.168.178.0/24
+	CIDR string `yaml:"cidr" json:"cidr" xml:"cidr"`
+	// The connection data that is used for every address
+	ConnectionData network.ConnectionData `yaml:"connection_data" json:"connection_data" xml:"connection_data"`
+	// The number of addresses that are discovered at the same time (default: 32)
+	Workers int  `yaml:"workers" json:"workers" xml:"workers"`
+	Timeout *int `yaml:"timeout" json:"timeout" xml:"timeout"`
+
+	ipLock IPLock
+}
+
+// IPLock acquires the lock of an IP address, so that no other request communicates with the device at the same time.
+// It returns the function that releases the lock.
+type IPLock func(ctx context.Context, ip string) (func(), error)
+
+// SetIPLock sets the lock that is acquired for every address while it is discovered.
+func (r *DiscoverRequest) SetIPLock(lock IPLock) {
+	r.ipLock = lock
+}
+
+// DiscoverResponse
+//
+// DiscoverResponse is the response struct for the discover request.
+//
+// swagger:model
+type DiscoverResponse struct {
+	// The discovered devices sorted by their IP address
+	Devices []DiscoveredDevice `yaml:"devices" json:"devices" xml:"devices>device"`
+	BaseResponse
+}
+
+// DiscoveredDevice
+//
+// DiscoveredDevice is a device that was found by the discover request.
+//
+// swagger:model
+type DiscoveredDevice struct {
+	// The IP address of the device
+	//
+	// example: 192.168.178.1
+	IPAddress     string `yaml:"ip_address" json:"ip_address" xml:"ip_address"`
+	device.Device `yaml:",inline"`
+	// The connection data that worked for the device
+	ConnectionData network.ConnectionData `yaml:"connection_data" json:"connection_data" xml:"connection_data"`
+}
+
+// discoveredDeviceCSV is a row of the CSV output of the discover request.
+type discoveredDeviceCSV struct {
+	IPAddress     string `csv:"ip_address"`
+	Class         string `csv:"class"`
+	Vendor        string `csv:"vendor"`
+	Model         string `csv:"model"`
+	ModelSeries   string `csv:"model_series"`
+	SerialNumber  string `csv:"serial_number"`
+	OSVersion     string `csv:"os_version"`
+	SNMPVersion   string `csv:"snmp_version"`
+	SNMPPort      string `csv:"snmp_port"`
+	SNMPCommunity string `csv:"snmp_community"`
+	SNMPv3User    string `csv:"snmp_v3_user"`
+	SNMPv3Level   string `csv:"snmp_v3_level"`
+}
+
+// ToCSV returns the discovered devices as CSV with one row per device.
+func (r *DiscoverResponse) ToCSV() ([]byte, error) {
+	rows := make([]discoveredDeviceCSV, 0, len(r.Devices))
+	for _, d := range r.Devices {
+		row := discoveredDeviceCSV{
+			IPAddress:    d.IPAddress,
+			Class:        d.Class,
+			Vendor:       stringOrEmpty(d.Properties.Vendor),
+			Model:        stringOrEmpty(d.Properties.Model),
+			ModelSeries:  stringOrEmpty(d.Properties.ModelSeries),
+			SerialNumber: stringOrEmpty(d.Properties.SerialNumber),
+			OSVersion:    stringOrEmpty(d.Properties.OSVersion),
+		}
+		// the connection data that worked contains exactly one version, port and community
+		if snmp := d.ConnectionData.SNMP; snmp != nil && len(snmp.Versions) == 1 && len(snmp.Ports) == 1 {
+			row.SNMPVersion = snmp.Versions[0]
+			row.SNMPPort = strconv.Itoa(snmp.Ports[0])
+			if row.SNMPVersion == "3" {
+				row.SNMPv3User = stringOrEmpty(snmp.V3Data.User)
+				row.SNMPv3Level = stringOrEmpty(snmp.V3Data.Level)
+			} else if len(snmp.Communities) == 1 {
+				row.SNMPCommunity = snmp.Communities[0]
+			}
+		}
+		rows = append(rows, row)
+	}
+	b, err := gocsv.MarshalBytes(rows)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal discovered devices to csv")
+	}
+	return bytes.TrimSuffix(b, []byte("\n")), nil
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func (r *DiscoverRequest) setupConnection(_ context.Context) (*network.RequestDeviceConnection, error) {
+	return &network.RequestDeviceConnection{}, nil
+}
+
+func (r *DiscoverRequest) getTimeout() *int {
+	return r.Timeout
+}
+
+func (r *DiscoverRequest) validate(_ context.Context) error {
+	if _, err := getDiscoverAddresses(r.CIDR); err != nil {
+		return err
+	}
+	if r.Workers < 0 {
+		return errors.New("invalid number of workers")
+	}
+	return nil
+}
+
+// HandlePreProcessError handles an error that occurred before the request was processed.
+func (r *DiscoverRequest) HandlePreProcessError(err error) (Response, error) {
+	return nil, err
+}
+
+// GetDeviceData returns the device data of the request.
+func (r *DiscoverRequest) GetDeviceData() *DeviceData {
+	return nil
+}
+
+// getDiscoverAddresses returns the addresses of the network in CIDR notation.
+// The network and broadcast address of IPv4 networks with more than two addresses are left out.
+func getDiscoverAddresses(cidr string) ([]string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid network")
+	}
+	ones, bits := ipNet.Mask.Size()
+	if bits-ones > maxDiscoverHostBits {
+		return nil, errors.Errorf("network is too large, at most %d addresses can be discovered", 1<<maxDiscoverHostBits)
+	}
+
+	var addresses []string
+	for ip := ipNet.IP; ipNet.Contains(ip); ip = nextIP(ip) {
+		addresses = append(addresses, ip.String())
+	}
+	if ipNet.IP.To4() != nil && len(addresses) > 2 {
+		addresses = addresses[1 : len(addresses)-1]
+	}
+	return addresses, nil
+}
+
+// nextIP returns the IP address following the given one.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
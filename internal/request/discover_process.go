@@ -0,0 +1,163 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/inexio/thola/internal/network"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net"
+	"sort"
+	"sync"
+)
+
+func (r *DiscoverRequest) process(ctx context.Context) (Response, error) {
+	addresses, err := getDiscoverAddresses(r.CIDR)
+	if err != nil {
+		return nil, err
+	}
+
+	// the connection data is validated once, so that invalid connection data is not reported for every address
+	base := BaseRequest{DeviceData: DeviceData{IPAddress: addresses[0], ConnectionData: copyConnectionData(r.ConnectionData)}}
+	if err := base.validate(ctx); err != nil {
+		return nil, errors.Wrap(err, "invalid connection data")
+	}
+
+	workers := r.Workers
+	if workers == 0 {
+		workers = defaultDiscoverWorkers
+	}
+	if workers > len(addresses) {
+		workers = len(addresses)
+	}
+	log.Ctx(ctx).Debug().Int("addresses", len(addresses)).Int("workers", workers).Msg("starting discovery")
+
+	in := make(chan string)
+	out := make(chan *DiscoveredDevice)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for ip := range in {
+				out <- r.discoverAddress(ctx, ip)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(in)
+		for _, ip := range addresses {
+			select {
+			case in <- ip:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	res := DiscoverResponse{
+		Devices: []DiscoveredDevice{},
+	}
+	for d := range out {
+		if d != nil {
+			res.Devices = append(res.Devices, *d)
+		}
+	}
+	sort.Slice(res.Devices, func(i, j int) bool {
+		return bytes.Compare(net.ParseIP(res.Devices[i].IPAddress), net.ParseIP(res.Devices[j].IPAddress)) < 0
+	})
+	return &res, nil
+}
+
+// discoverAddress identifies the device with the given IP address the same way as an identify request,
+// so it is stored in the cache and the device registry. It returns nil if no device was found.
+func (r *DiscoverRequest) discoverAddress(ctx context.Context, ip string) (discovered *DiscoveredDevice) {
+	logger := log.Ctx(ctx).With().Str("ip_address", ip).Logger()
+	ctx = logger.WithContext(ctx)
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Ctx(ctx).Error().Msg("thola paniced while discovering the device: " + fmt.Sprint(rec))
+			discovered = nil
+		}
+	}()
+
+	identifyRequest := IdentifyRequest{
+		BaseRequest: BaseRequest{
+			DeviceData: DeviceData{
+				IPAddress:      ip,
+				ConnectionData: copyConnectionData(r.ConnectionData),
+			},
+		},
+	}
+	if err := identifyRequest.validate(ctx); err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("invalid request for the address")
+		return nil
+	}
+
+	if r.ipLock != nil {
+		unlock, err := r.ipLock(ctx, ip)
+		if err != nil {
+			log.Ctx(ctx).Error().Err(err).Msg("failed to lock the address")
+			return nil
+		}
+		defer unlock()
+	}
+
+	con, err := identifyRequest.setupConnection(ctx)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("no device found")
+		return nil
+	}
+	defer con.CloseConnections()
+	ctx = network.NewContextWithDeviceConnection(ctx, con)
+
+	res, err := identifyRequest.process(ctx)
+	if err != nil {
+		log.Ctx(ctx).Error().Err(err).Msg("failed to identify the device")
+		return nil
+	}
+	log.Ctx(ctx).Debug().Msg("device found")
+
+	return &DiscoveredDevice{
+		IPAddress:      ip,
+		Device:         res.(*IdentifyResponse).Device,
+		ConnectionData: con.GetIdealConnectionData(),
+	}
+}
+
+// copyConnectionData returns a copy of the connection data, which can be changed by the validation of a request.
+func copyConnectionData(data network.ConnectionData) network.ConnectionData {
+	if data.SNMP != nil {
+		snmp := *data.SNMP
+		data.SNMP = &snmp
+	}
+	if data.HTTP != nil {
+		http := *data.HTTP
+		data.HTTP = &http
+	}
+	if data.SSH != nil {
+		ssh := *data.SSH
+		data.SSH = &ssh
+	}
+	if data.NETCONF != nil {
+		netconf := *data.NETCONF
+		data.NETCONF = &netconf
+	}
+	if data.GNMI != nil {
+		gnmi := *data.GNMI
+		data.GNMI = &gnmi
+	}
+	return data
+}
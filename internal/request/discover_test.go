@@ -0,0 +1,86 @@
+package request
+
+import (
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetDiscoverAddresses(t *testing.T) {
+	addresses, err := getDiscoverAddresses("10.0.0.5/30")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"10.0.0.5", "10.0.0.6"}, addresses)
+	}
+
+	addresses, err = getDiscoverAddresses("10.0.0.255/31")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"10.0.0.254", "10.0.0.255"}, addresses)
+	}
+
+	addresses, err = getDiscoverAddresses("10.0.0.0/23")
+	if assert.NoError(t, err) && assert.Len(t, addresses, 510) {
+		assert.Equal(t, "10.0.0.1", addresses[0])
+		assert.Equal(t, "10.0.1.0", addresses[255])
+		assert.Equal(t, "10.0.1.254", addresses[509])
+	}
+
+	addresses, err = getDiscoverAddresses("2001:db8::/127")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"2001:db8::", "2001:db8::1"}, addresses)
+	}
+
+	_, err = getDiscoverAddresses("10.0.0.0/15")
+	assert.Error(t, err)
+
+	_, err = getDiscoverAddresses("10.0.0.1")
+	assert.Error(t, err)
+}
+
+func TestDiscoverResponse_ToCSV(t *testing.T) {
+	vendor := "Cisco"
+	user := "monitoring"
+	res := DiscoverResponse{
+		Devices: []DiscoveredDevice{
+			{
+				IPAddress: "10.0.0.1",
+				Device: device.Device{
+					Class: "ios",
+					Properties: device.Properties{
+						Vendor: &vendor,
+					},
+				},
+				ConnectionData: network.ConnectionData{
+					SNMP: &network.SNMPConnectionData{
+						Communities: []string{"public"},
+						Versions:    []string{"2c"},
+						Ports:       []int{161},
+					},
+				},
+			},
+			{
+				IPAddress: "10.0.0.2",
+				Device: device.Device{
+					Class: "generic",
+				},
+				ConnectionData: network.ConnectionData{
+					SNMP: &network.SNMPConnectionData{
+						Communities: []string{""},
+						Versions:    []string{"3"},
+						Ports:       []int{161},
+						V3Data: network.SNMPv3ConnectionData{
+							User: &user,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	b, err := res.ToCSV()
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ip_address,class,vendor,model,model_series,serial_number,os_version,snmp_version,snmp_port,snmp_community,snmp_v3_user,snmp_v3_level\n"+
+			"10.0.0.1,ios,Cisco,,,,,2c,161,public,,\n"+
+			"10.0.0.2,generic,,,,,,3,161,,monitoring,", string(b))
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/inexio/thola/internal/communicator/create"
 	"github.com/inexio/thola/internal/database"
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/metrics"
 	"github.com/inexio/thola/internal/tholaerr"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -52,6 +53,7 @@ func GetCommunicator(ctx context.Context, baseRequest BaseRequest) (communicator
 		}
 		deviceProperties = res.(*IdentifyResponse).Device
 	}
+	metrics.RecordDeviceClass(ctx, deviceProperties.Class)
 	ctx = device.NewContextWithDeviceProperties(ctx, deviceProperties)
 
 	com, err := create.GetNetworkDeviceCommunicator(ctx, deviceProperties.Class)
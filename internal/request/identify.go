@@ -2,6 +2,8 @@ package request
 
 import (
 	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/explain"
+	"github.com/inexio/thola/internal/parser"
 )
 
 // IdentifyRequest
@@ -11,6 +13,10 @@ import (
 // swagger:model
 type IdentifyRequest struct {
 	BaseRequest
+	// Explain how the device class was identified
+	Explain bool `json:"explain" xml:"explain"`
+	// Trace how the properties were read
+	Trace bool `json:"trace" xml:"trace"`
 }
 
 // IdentifyResponse
@@ -20,5 +26,30 @@ type IdentifyRequest struct {
 // swagger:model
 type IdentifyResponse struct {
 	device.Device `yaml:",inline"`
-	BaseResponse  `yaml:",inline"`
+	// Every device class and condition that was checked while identifying the device class, only set if requested
+	Explanation *explain.DeviceClass `json:"explanation,omitempty" xml:"explanation,omitempty" yaml:"explanation,omitempty"`
+	// Every property reader and operator that was used to read the properties, only set if requested
+	Trace        *explain.Trace `json:"trace,omitempty" xml:"trace,omitempty" yaml:"trace,omitempty"`
+	BaseResponse `yaml:",inline"`
+}
+
+func (r *IdentifyRequest) traceEnabled() bool {
+	return r.Trace
+}
+
+func (r *IdentifyResponse) setTrace(trace *explain.Trace) {
+	r.Trace = trace
+}
+
+// ToHumanReadable returns the response with the explanation as a tree.
+func (r *IdentifyResponse) ToHumanReadable() ([]byte, error) {
+	b, err := parser.ToHumanReadable(struct {
+		device.Device
+		Trace *explain.Trace
+		BaseResponse
+	}{r.Device, r.Trace, r.BaseResponse})
+	if err != nil || r.Explanation == nil {
+		return b, err
+	}
+	return append(b, []byte("\nExplanation:\n"+r.Explanation.String())...), nil
 }
@@ -7,6 +7,8 @@ import (
 	"context"
 	"github.com/inexio/thola/internal/communicator/create"
 	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/explain"
+	"github.com/inexio/thola/internal/metrics"
 	"github.com/inexio/thola/internal/network"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog/log"
@@ -19,6 +21,7 @@ func (r *IdentifyRequest) process(ctx context.Context) (Response, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "identify failed")
 	}
+	metrics.RecordDeviceClass(ctx, response.Class)
 
 	db, err := database.GetDB(ctx)
 	if err != nil {
@@ -35,15 +38,30 @@ func (r *IdentifyRequest) process(ctx context.Context) (Response, error) {
 		return nil, errors.Wrap(err, "failed to save device info to cache")
 	}
 
-	err = db.SetConnectionData(ctx, r.DeviceData.IPAddress, con.GetIdealConnectionData())
+	connectionData := con.GetIdealConnectionData()
+	err = db.SetConnectionData(ctx, r.DeviceData.IPAddress, connectionData)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to save connection data to cache")
 	}
 
+	err = database.UpdateDeviceRecord(ctx, db, r.DeviceData.IPAddress, response.Device, connectionData)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save device to device registry")
+	}
+
 	return response, nil
 }
 
 func (r *IdentifyRequest) identify(ctx context.Context) (*IdentifyResponse, error) {
+	var explanation *explain.DeviceClass
+	if r.Explain {
+		explanation = &explain.DeviceClass{
+			Name:    "generic",
+			Matched: true,
+		}
+		ctx = explain.NewContextWithExplanation(ctx, explanation)
+	}
+
 	com, err := create.IdentifyNetworkDeviceCommunicator(ctx)
 	if err != nil {
 		return nil, err
@@ -51,6 +69,10 @@ func (r *IdentifyRequest) identify(ctx context.Context) (*IdentifyResponse, erro
 
 	var response IdentifyResponse
 	response.Class = com.GetIdentifier()
+	if response.Class == "generic" {
+		explanation.SetNote("no device class matched, device class is used")
+	}
+	response.Explanation = explanation
 
 	response.Properties, err = com.GetIdentifyProperties(ctx)
 	if err != nil {
@@ -0,0 +1,187 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/explain"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestIdentifyRequest_snmpsim(t *testing.T) {
+	viper.Set("db.no-cache", true)
+
+	agent := snmpsim.NewAgent()
+	if !assert.NoError(t, agent.LoadDir("../../test/testdata/devices")) {
+		return
+	}
+	addr, stop, err := agent.Start("127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer stop()
+
+	parallelRequests, timeout, retries := 1, 2, 0
+	req := IdentifyRequest{
+		BaseRequest: BaseRequest{
+			DeviceData: DeviceData{
+				IPAddress: addr.IP.String(),
+				ConnectionData: network.ConnectionData{
+					SNMP: &network.SNMPConnectionData{
+						Communities: []string{"ios/7206VXR/public"},
+						Versions:    []string{"2c"},
+						Ports:       []int{addr.Port},
+
+						DiscoverParallelRequests: &parallelRequests,
+						DiscoverTimeout:          &timeout,
+						DiscoverRetries:          &retries,
+					},
+				},
+			},
+		},
+	}
+
+	res, err := ProcessRequest(context.Background(), &req)
+	if assert.NoError(t, err) {
+		identify, ok := res.(*IdentifyResponse)
+		if assert.True(t, ok) {
+			assert.Equal(t, "ios", identify.Class)
+			if assert.NotNil(t, identify.Properties.Model) {
+				assert.Equal(t, "7206VXR", *identify.Properties.Model)
+			}
+		}
+	}
+}
+
+func TestIdentifyRequest_explain(t *testing.T) {
+	viper.Set("db.no-cache", true)
+
+	agent := snmpsim.NewAgent()
+	if !assert.NoError(t, agent.LoadDir("../../test/testdata/devices")) {
+		return
+	}
+	addr, stop, err := agent.Start("127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer stop()
+
+	parallelRequests, timeout, retries := 1, 2, 0
+	req := IdentifyRequest{
+		BaseRequest: BaseRequest{
+			DeviceData: DeviceData{
+				IPAddress: addr.IP.String(),
+				ConnectionData: network.ConnectionData{
+					SNMP: &network.SNMPConnectionData{
+						Communities: []string{"ios/7206VXR/public"},
+						Versions:    []string{"2c"},
+						Ports:       []int{addr.Port},
+
+						DiscoverParallelRequests: &parallelRequests,
+						DiscoverTimeout:          &timeout,
+						DiscoverRetries:          &retries,
+					},
+				},
+			},
+		},
+		Explain: true,
+	}
+
+	res, err := ProcessRequest(context.Background(), &req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	identify, ok := res.(*IdentifyResponse)
+	if !assert.True(t, ok) || !assert.NotNil(t, identify.Explanation) {
+		return
+	}
+	assert.Equal(t, "generic", identify.Explanation.Name)
+
+	var ios *explain.DeviceClass
+	for _, deviceClass := range identify.Explanation.SubDeviceClasses {
+		if deviceClass.Name == "ios" {
+			ios = deviceClass
+		} else {
+			assert.False(t, deviceClass.Matched, "only the identified device class can match on the first level")
+		}
+		assert.NotNil(t, deviceClass.Condition, "conditions of device class %s need to be explained", deviceClass.Name)
+	}
+	if assert.NotNil(t, ios, "identified device class needs to be explained") {
+		assert.True(t, ios.Matched)
+		assert.Equal(t, "device class is used", ios.Note)
+		if assert.NotNil(t, ios.Condition) {
+			assert.True(t, ios.Condition.Matched)
+		}
+	}
+
+	b, err := identify.ToHumanReadable()
+	if assert.NoError(t, err) {
+		assert.Contains(t, string(b), "\nExplanation:\ngeneric: matched\n")
+	}
+}
+
+func TestIdentifyRequest_trace(t *testing.T) {
+	viper.Set("db.no-cache", true)
+
+	agent := snmpsim.NewAgent()
+	if !assert.NoError(t, agent.LoadDir("../../test/testdata/devices")) {
+		return
+	}
+	addr, stop, err := agent.Start("127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer stop()
+
+	parallelRequests, timeout, retries := 1, 2, 0
+	req := IdentifyRequest{
+		BaseRequest: BaseRequest{
+			DeviceData: DeviceData{
+				IPAddress: addr.IP.String(),
+				ConnectionData: network.ConnectionData{
+					SNMP: &network.SNMPConnectionData{
+						Communities: []string{"ios/7206VXR/public"},
+						Versions:    []string{"2c"},
+						Ports:       []int{addr.Port},
+
+						DiscoverParallelRequests: &parallelRequests,
+						DiscoverTimeout:          &timeout,
+						DiscoverRetries:          &retries,
+					},
+				},
+			},
+		},
+		Trace: true,
+	}
+
+	res, err := ProcessRequest(context.Background(), &req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	identify, ok := res.(*IdentifyResponse)
+	if !assert.True(t, ok) || !assert.NotNil(t, identify.Trace) {
+		return
+	}
+	assert.Nil(t, identify.Explanation, "device classes are only explained in explain mode")
+
+	var model *explain.Property
+	for _, property := range identify.Trace.Properties {
+		if property.Name == "Model" {
+			model = property
+		}
+	}
+	if assert.NotNil(t, model, "model needs to be traced") && assert.Len(t, model.Readers, 1) {
+		reader := model.Readers[0]
+		assert.Equal(t, "snmpget", reader.Detection)
+		if assert.NotNil(t, reader.RawValue) && assert.NotNil(t, reader.Value) {
+			assert.Equal(t, "CISCO7206VXR", *reader.RawValue)
+			assert.Equal(t, "7206VXR", *reader.Value)
+		}
+		assert.NotEmpty(t, reader.Operators)
+	}
+}
@@ -0,0 +1,71 @@
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/network"
+	"strings"
+)
+
+// ListDevicesRequest
+//
+// ListDevicesRequest is the request struct for the list devices request.
+// It lists the devices of the device registry, which contains every device that was identified.
+//
+// swagger:model
+type ListDevicesRequest struct {
+	// Only list the device with this IP address
+	IPAddress string `yaml:"ip_address" json:"ip_address" xml:"ip_address"`
+	// Only list devices of this vendor (case insensitive)
+	//
+	// example: cisco
+	Vendor string `yaml:"vendor" json:"vendor" xml:"vendor"`
+	// Only list devices of this model (case insensitive)
+	Model string `yaml:"model" json:"model" xml:"model"`
+	// Only list devices of this device class
+	//
+	// example: ios
+	Class   string `yaml:"class" json:"class" xml:"class"`
+	Timeout *int   `yaml:"timeout" json:"timeout" xml:"timeout"`
+}
+
+// ListDevicesResponse
+//
+// ListDevicesResponse is the response struct for the list devices request.
+//
+// swagger:model
+type ListDevicesResponse struct {
+	// The devices sorted by their IP address
+	Devices []database.DeviceRecord `yaml:"devices" json:"devices" xml:"devices>device"`
+	BaseResponse
+}
+
+func (r *ListDevicesRequest) setupConnection(_ context.Context) (*network.RequestDeviceConnection, error) {
+	return &network.RequestDeviceConnection{}, nil
+}
+
+func (r *ListDevicesRequest) getTimeout() *int {
+	return r.Timeout
+}
+
+func (r *ListDevicesRequest) validate(_ context.Context) error {
+	return nil
+}
+
+// HandlePreProcessError handles an error that occurred before the request was processed.
+func (r *ListDevicesRequest) HandlePreProcessError(err error) (Response, error) {
+	return nil, err
+}
+
+// GetDeviceData returns the device data of the request.
+func (r *ListDevicesRequest) GetDeviceData() *DeviceData {
+	return nil
+}
+
+// matches returns if the device record matches the filters of the request.
+func (r *ListDevicesRequest) matches(record database.DeviceRecord) bool {
+	return (r.IPAddress == "" || r.IPAddress == record.IPAddress) &&
+		(r.Vendor == "" || record.Properties.Vendor != nil && strings.EqualFold(r.Vendor, *record.Properties.Vendor)) &&
+		(r.Model == "" || record.Properties.Model != nil && strings.EqualFold(r.Model, *record.Properties.Model)) &&
+		(r.Class == "" || r.Class == record.Class)
+}
@@ -0,0 +1,36 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/database"
+	"github.com/pkg/errors"
+	"sort"
+)
+
+func (r *ListDevicesRequest) process(ctx context.Context) (Response, error) {
+	db, err := database.GetDB(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get DB")
+	}
+
+	records, err := db.GetDeviceRecords(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get device records")
+	}
+
+	res := ListDevicesResponse{
+		Devices: []database.DeviceRecord{},
+	}
+	for _, record := range records {
+		if r.matches(record) {
+			res.Devices = append(res.Devices, record)
+		}
+	}
+	sort.Slice(res.Devices, func(i, j int) bool {
+		return res.Devices[i].IPAddress < res.Devices[j].IPAddress
+	})
+	return &res, nil
+}
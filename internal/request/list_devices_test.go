@@ -0,0 +1,29 @@
+package request
+
+import (
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/device"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListDevicesRequest_matches(t *testing.T) {
+	vendor := "Cisco"
+	record := database.DeviceRecord{
+		IPAddress: "10.0.0.1",
+		Device: device.Device{
+			Class: "ios",
+			Properties: device.Properties{
+				Vendor: &vendor,
+			},
+		},
+	}
+
+	assert.True(t, (&ListDevicesRequest{}).matches(record))
+	assert.True(t, (&ListDevicesRequest{Vendor: "cisco", Class: "ios"}).matches(record))
+	assert.True(t, (&ListDevicesRequest{IPAddress: "10.0.0.1"}).matches(record))
+	assert.False(t, (&ListDevicesRequest{IPAddress: "10.0.0.2"}).matches(record))
+	assert.False(t, (&ListDevicesRequest{Vendor: "juniper"}).matches(record))
+	assert.False(t, (&ListDevicesRequest{Model: "7206VXR"}).matches(record))
+	assert.False(t, (&ListDevicesRequest{Class: "junos"}).matches(record))
+}
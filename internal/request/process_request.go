@@ -6,6 +6,7 @@ package request
 import (
 	"context"
 	"fmt"
+	"github.com/inexio/thola/internal/explain"
 	"github.com/inexio/thola/internal/network"
 	"github.com/pkg/errors"
 	"strconv"
@@ -17,6 +18,16 @@ type response struct {
 	err error
 }
 
+// tracedRequest is a request which can trace how the properties of its response were read.
+type tracedRequest interface {
+	traceEnabled() bool
+}
+
+// tracedResponse is a response which can contain the trace of its request.
+type tracedResponse interface {
+	setTrace(*explain.Trace)
+}
+
 // ProcessRequest is called by every request Thola receives
 func ProcessRequest(ctx context.Context, request Request) (Response, error) {
 	ctx, cancel := CheckForTimeout(ctx, request)
@@ -68,7 +79,15 @@ func processRequest(ctx context.Context, request Request, responseChan chan resp
 	}
 	defer con.CloseConnections()
 	ctx = network.NewContextWithDeviceConnection(ctx, con)
+	var trace *explain.Trace
+	if r, ok := request.(tracedRequest); ok && r.traceEnabled() {
+		trace = &explain.Trace{}
+		ctx = explain.NewContextWithTrace(ctx, trace)
+	}
 	res, err := request.process(ctx)
+	if r, ok := res.(tracedResponse); ok && trace != nil {
+		r.setTrace(trace)
+	}
 	responseChan <- response{
 		res: res,
 		err: err,
@@ -1,5 +1,7 @@
 package request
 
+import "github.com/inexio/thola/internal/explain"
+
 // ReadRequest
 //
 // ReadRequest is the response struct that is for read requests.
@@ -7,6 +9,8 @@ package request
 // swagger:model
 type ReadRequest struct {
 	BaseRequest
+	// Trace how the properties were read
+	Trace bool `json:"trace" xml:"trace"`
 }
 
 // ReadResponse
@@ -16,4 +20,70 @@ type ReadRequest struct {
 // swagger:model
 type ReadResponse struct {
 	BaseResponse
+	// Every property reader and operator that was used to read the properties, only set if requested
+	Trace *explain.Trace `yaml:"trace,omitempty" json:"trace,omitempty" xml:"trace,omitempty"`
+}
+
+func (r *ReadRequest) traceEnabled() bool {
+	return r.Trace
+}
+
+func (r *ReadResponse) setTrace(trace *explain.Trace) {
+	r.Trace = trace
+}
+
+// ComponentReadRequest is a read request which can be used if the device has the component.
+type ComponentReadRequest struct {
+	// Name is the name of the request like in the CLI, e.g. "read cpu-load".
+	Name string
+	// Component is the component which is needed by the request.
+	Component string
+	// New returns a new request.
+	New func(ReadRequest) Request
+}
+
+// ComponentReadRequests contains the read requests of all components.
+var ComponentReadRequests = []ComponentReadRequest{
+	{"read interfaces", "interfaces", func(r ReadRequest) Request {
+		return &ReadInterfacesRequest{ReadRequest: r}
+	}},
+	{"read count-interfaces", "interfaces", func(r ReadRequest) Request {
+		return &ReadCountInterfacesRequest{ReadRequest: r}
+	}},
+	{"read cpu-load", "cpu", func(r ReadRequest) Request {
+		return &ReadCPULoadRequest{ReadRequest: r}
+	}},
+	{"read memory-usage", "memory", func(r ReadRequest) Request {
+		return &ReadMemoryUsageRequest{ReadRequest: r}
+	}},
+	{"read disk", "disk", func(r ReadRequest) Request {
+		return &ReadDiskRequest{ReadRequest: r}
+	}},
+	{"read ups", "ups", func(r ReadRequest) Request {
+		return &ReadUPSRequest{ReadRequest: r}
+	}},
+	{"read sbc", "sbc", func(r ReadRequest) Request {
+		return &ReadSBCRequest{ReadRequest: r}
+	}},
+	{"read server", "server", func(r ReadRequest) Request {
+		return &ReadServerRequest{ReadRequest: r}
+	}},
+	{"read hardware-health", "hardware_health", func(r ReadRequest) Request {
+		return &ReadHardwareHealthRequest{ReadRequest: r}
+	}},
+	{"read high-availability", "high_availability", func(r ReadRequest) Request {
+		return &ReadHighAvailabilityRequest{ReadRequest: r}
+	}},
+	{"read inventory", "inventory", func(r ReadRequest) Request {
+		return &ReadInventoryRequest{ReadRequest: r}
+	}},
+	{"read neighbors", "neighbors", func(r ReadRequest) Request {
+		return &ReadNeighborsRequest{ReadRequest: r}
+	}},
+	{"read bgp", "bgp", func(r ReadRequest) Request {
+		return &ReadBGPRequest{ReadRequest: r}
+	}},
+	{"read routing-protocols", "routing_protocols", func(r ReadRequest) Request {
+		return &ReadRoutingProtocolsRequest{ReadRequest: r}
+	}},
 }
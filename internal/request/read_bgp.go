@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadBGPRequest
+//
+// ReadBGPRequest is the request struct for the read bgp request.
+//
+// swagger:model
+type ReadBGPRequest struct {
+	ReadRequest
+}
+
+// ReadBGPResponse
+//
+// ReadBGPResponse is the response struct for the read bgp response.
+//
+// swagger:model
+type ReadBGPResponse struct {
+	BGP device.BGPComponent `yaml:"bgp" json:"bgp" xml:"bgp"`
+	ReadResponse
+}
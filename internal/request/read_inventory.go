@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadInventoryRequest
+//
+// ReadInventoryRequest is the request struct for the read inventory request.
+//
+// swagger:model
+type ReadInventoryRequest struct {
+	ReadRequest
+}
+
+// ReadInventoryResponse
+//
+// ReadInventoryResponse is the response struct for the read inventory response.
+//
+// swagger:model
+type ReadInventoryResponse struct {
+	Inventory device.InventoryComponent `yaml:"inventory" json:"inventory" xml:"inventory"`
+	ReadResponse
+}
@@ -0,0 +1,22 @@
+package request
+
+import "github.com/inexio/thola/internal/device"
+
+// ReadRoutingProtocolsRequest
+//
+// ReadRoutingProtocolsRequest is the request struct for the read routing-protocols request.
+//
+// swagger:model
+type ReadRoutingProtocolsRequest struct {
+	ReadRequest
+}
+
+// ReadRoutingProtocolsResponse
+//
+// ReadRoutingProtocolsResponse is the response struct for the read routing-protocols response.
+//
+// swagger:model
+type ReadRoutingProtocolsResponse struct {
+	RoutingProtocols device.RoutingProtocolsComponent `yaml:"routing_protocols" json:"routing_protocols" xml:"routing_protocols"`
+	ReadResponse
+}
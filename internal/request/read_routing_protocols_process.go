@@ -0,0 +1,25 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/pkg/errors"
+)
+
+func (r *ReadRoutingProtocolsRequest) process(ctx context.Context) (Response, error) {
+	com, err := GetCommunicator(ctx, r.BaseRequest)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get communicator")
+	}
+
+	result, err := com.GetRoutingProtocolsComponent(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't get routing protocols components")
+	}
+
+	return &ReadRoutingProtocolsResponse{
+		RoutingProtocols: result,
+	}, nil
+}
@@ -0,0 +1,25 @@
+package request
+
+import "github.com/inexio/thola/internal/snmpsim"
+
+// RecordRequest
+//
+// RecordRequest is the request struct for the record request.
+// It records the SNMP data of a device, e.g. to create test data or to reproduce bugs.
+type RecordRequest struct {
+	// OIDs contains the subtrees which are walked. The whole device is walked if neither OIDs nor Requests are set.
+	OIDs []string `yaml:"oids" json:"oids" xml:"oids"`
+	// Requests records exactly the data which is requested by identify and the read requests of all available components.
+	Requests bool `yaml:"requests" json:"requests" xml:"requests"`
+	// Anonymize replaces communities, IP addresses and serial numbers in the recorded data.
+	Anonymize bool `yaml:"anonymize" json:"anonymize" xml:"anonymize"`
+	BaseRequest
+}
+
+// RecordResponse
+//
+// RecordResponse is the response struct for the record request.
+type RecordResponse struct {
+	Recording *snmpsim.Recording `yaml:"-" json:"-" xml:"-"`
+	BaseResponse
+}
@@ -0,0 +1,127 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+)
+
+// recordRootOIDs are walked if no subtrees are given. A single ".1" can't be encoded,
+// so the whole device is walked as ".1.0" (e.g. LLDP-MIB in iso.std) and ".1.3" (iso.org).
+var recordRootOIDs = []string{".1.0", ".1.3"}
+
+func (r *RecordRequest) validate(ctx context.Context) error {
+	for _, oid := range r.OIDs {
+		if err := network.OID(oid).Validate(); err != nil {
+			return errors.Wrapf(err, "invalid oid '%s'", oid)
+		}
+	}
+	return r.BaseRequest.validate(ctx)
+}
+
+func (r *RecordRequest) process(ctx context.Context) (Response, error) {
+	con, ok := network.DeviceConnectionFromContext(ctx)
+	if !ok || con.SNMP == nil || con.SNMP.SnmpClient == nil {
+		return nil, errors.New("no snmp connection available")
+	}
+	recorder := snmpsim.NewRecorder(con.SNMP.SnmpClient)
+
+	if r.Requests {
+		if err := r.recordRequests(network.NewContextWithSNMPClient(ctx, recorder)); err != nil {
+			return nil, err
+		}
+	}
+
+	oids := r.OIDs
+	if len(oids) == 0 && !r.Requests {
+		oids = recordRootOIDs
+	}
+	for _, oid := range oids {
+		log.Ctx(ctx).Debug().Str("oid", oid).Msg("walking subtree")
+		_, err := recorder.SNMPWalk(ctx, network.OID(oid))
+		if err != nil && !tholaerr.IsNotFoundError(err) {
+			return nil, errors.Wrapf(err, "failed to walk '%s'", oid)
+		}
+	}
+
+	rec, err := recorder.Recording()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create recording")
+	}
+	if rec.Len() == 0 {
+		return nil, errors.New("no snmp data recorded")
+	}
+
+	if r.Anonymize {
+		rec, err = snmpsim.Anonymize(rec, r.anonymizeOptions(ctx, con.SNMP.SnmpClient, rec))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to anonymize recording")
+		}
+	}
+
+	return &RecordResponse{
+		Recording: rec,
+	}, nil
+}
+
+// recordRequests runs identify and the read requests of all available components with the recorder as snmp client.
+func (r *RecordRequest) recordRequests(ctx context.Context) error {
+	if _, err := ProcessRequest(ctx, &IdentifyRequest{BaseRequest: r.BaseRequest}); err != nil {
+		return errors.Wrap(err, "identify failed")
+	}
+
+	res, err := ProcessRequest(ctx, &ReadAvailableComponentsRequest{ReadRequest: ReadRequest{BaseRequest: r.BaseRequest}})
+	if err != nil {
+		return errors.Wrap(err, "read available components failed")
+	}
+	available := make(map[string]bool)
+	for _, component := range res.(*ReadAvailableComponentsResponse).AvailableComponents {
+		available[component] = true
+	}
+
+	for _, c := range ComponentReadRequests {
+		if !available[c.Component] {
+			continue
+		}
+		log.Ctx(ctx).Debug().Str("request", c.Name).Msg("recording request")
+		if _, err := ProcessRequest(ctx, c.New(ReadRequest{BaseRequest: r.BaseRequest})); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("request", c.Name).Msg("request failed, recording the data anyway")
+		}
+	}
+	return nil
+}
+
+// anonymizeOptions returns the credentials and the ip address of the request, the serial number is identified from the recording.
+func (r *RecordRequest) anonymizeOptions(ctx context.Context, client network.SNMPClient, rec *snmpsim.Recording) snmpsim.AnonymizeOptions {
+	opts := snmpsim.AnonymizeOptions{
+		Communities: append([]string{client.GetCommunity()}, r.DeviceData.ConnectionData.SNMP.Communities...),
+		IPAddresses: []string{r.DeviceData.IPAddress},
+	}
+	for _, s := range []*string{client.GetV3User(), client.GetV3ContextName()} {
+		if s != nil {
+			opts.Communities = append(opts.Communities, *s)
+		}
+	}
+
+	con := network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{
+			SNMP: &network.SNMPConnectionData{},
+		},
+		SNMP: &network.RequestDeviceConnectionSNMP{
+			SnmpClient: snmpsim.NewSNMPClient(rec, client.GetCommunity()),
+		},
+	}
+	identify, err := (&IdentifyRequest{}).identify(network.NewContextWithDeviceConnection(ctx, &con))
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to identify recording, serial number is only anonymized if it is in the entity mib")
+	} else if identify.Properties.SerialNumber != nil {
+		opts.SerialNumbers = append(opts.SerialNumbers, *identify.Properties.SerialNumber)
+	}
+	return opts
+}
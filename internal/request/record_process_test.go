@@ -0,0 +1,72 @@
+//go:build !client
+// +build !client
+
+package request
+
+import (
+	"context"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/snmpsim"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRecordRequest_requests(t *testing.T) {
+	viper.Set("db.no-cache", true)
+
+	full, err := snmpsim.ReadRecordingFile("../../test/testdata/devices/ios/7206VXR/public.snmprec")
+	if !assert.NoError(t, err) {
+		return
+	}
+	agent := snmpsim.NewAgent()
+	agent.AddRecording("secret", full)
+	addr, stop, err := agent.Start("127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer stop()
+
+	parallelRequests, timeout, retries := 1, 2, 0
+	req := RecordRequest{
+		Requests:  true,
+		Anonymize: true,
+		BaseRequest: BaseRequest{
+			DeviceData: DeviceData{
+				IPAddress: addr.IP.String(),
+				ConnectionData: network.ConnectionData{
+					SNMP: &network.SNMPConnectionData{
+						Communities: []string{"secret"},
+						Versions:    []string{"2c"},
+						Ports:       []int{addr.Port},
+
+						DiscoverParallelRequests: &parallelRequests,
+						DiscoverTimeout:          &timeout,
+						DiscoverRetries:          &retries,
+					},
+				},
+			},
+		},
+	}
+
+	res, err := ProcessRequest(context.Background(), &req)
+	if !assert.NoError(t, err) {
+		return
+	}
+	rec := res.(*RecordResponse).Recording
+	assert.True(t, rec.Len() > 0 && rec.Len() < full.Len(), "only the requested oids are recorded")
+
+	// the anonymized recording is still identified as the same device
+	con := network.RequestDeviceConnection{
+		RawConnectionData: network.ConnectionData{SNMP: &network.SNMPConnectionData{}},
+		SNMP:              &network.RequestDeviceConnectionSNMP{SnmpClient: snmpsim.NewSNMPClient(rec, "public")},
+	}
+	identify, err := (&IdentifyRequest{}).identify(network.NewContextWithDeviceConnection(context.Background(), &con))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ios", identify.Class)
+		if assert.NotNil(t, identify.Properties.SerialNumber) {
+			assert.NotEqual(t, "4279256517", *identify.Properties.SerialNumber)
+			assert.Len(t, *identify.Properties.SerialNumber, 10)
+		}
+	}
+}
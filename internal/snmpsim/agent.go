@@ -0,0 +1,334 @@
+package snmpsim
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"io"
+	stdlog "log"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxPacketSize is the maximum size of a response, larger GETBULK responses are truncated.
+	maxPacketSize = 65507
+
+	// maxBulkVarbinds limits the amount of varbinds of a single GETBULK response.
+	maxBulkVarbinds = 1000
+
+	// snmprecExtension is the file extension of recordings.
+	snmprecExtension = ".snmprec"
+)
+
+// usmStatsUnknownEngineIDs is reported to SNMP v3 engine discovery requests.
+const usmStatsUnknownEngineIDs = ".1.3.6.1.6.3.15.1.1.4.0"
+
+// discardLogger is needed by gosnmp to encode and decode SNMP v3 packets.
+var discardLogger = stdlog.New(io.Discard, "", 0)
+
+// Agent is an SNMP agent which responds with the data of recordings.
+// Like in snmpsim, the recording is selected by the community (SNMP v1/v2c) or the context name (SNMP v3),
+// which is the path of the snmprec file relative to the data directory without the file extension, e.g. "ios/7206VXR/public".
+// SNMP v3 is only supported with the security level noAuthNoPriv, the username is ignored.
+type Agent struct {
+	lock       sync.RWMutex
+	recordings map[string]*Recording
+
+	engineID    string
+	engineStart time.Time
+}
+
+// NewAgent returns a new agent without recordings.
+func NewAgent() *Agent {
+	return &Agent{
+		recordings:  make(map[string]*Recording),
+		engineID:    "\x80\x00\x00\x00\x04thola-snmpsim",
+		engineStart: time.Now(),
+	}
+}
+
+// AddRecording adds a recording which is served for the given community.
+func (a *Agent) AddRecording(community string, rec *Recording) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.recordings[community] = rec
+}
+
+// LoadDir recursively adds all snmprec files in the directory.
+func (a *Agent) LoadDir(dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(info.Name(), snmprecExtension) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return errors.Wrap(err, "failed to get relative path of snmprec file")
+		}
+		rec, err := ReadRecordingFile(path)
+		if err != nil {
+			return err
+		}
+		a.AddRecording(filepath.ToSlash(strings.TrimSuffix(rel, snmprecExtension)), rec)
+		return nil
+	})
+}
+
+// Communities returns the communities of all recordings in alphabetical order.
+func (a *Agent) Communities() []string {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	res := make([]string, 0, len(a.recordings))
+	for community := range a.recordings {
+		res = append(res, community)
+	}
+	sort.Strings(res)
+	return res
+}
+
+func (a *Agent) getRecording(community string) (*Recording, bool) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	rec, ok := a.recordings[community]
+	return rec, ok
+}
+
+// ListenAndServe listens on the given UDP address (e.g. "0.0.0.0:161") until the context is canceled.
+func (a *Agent) ListenAndServe(ctx context.Context, addr string) error {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return errors.Wrap(err, "failed to listen")
+	}
+	return a.Serve(ctx, conn)
+}
+
+// Start listens on the given UDP address (e.g. "127.0.0.1:0") and serves requests in the background,
+// which is useful for tests. It returns the address of the listener and a function that stops the agent.
+func (a *Agent) Start(addr string) (*net.UDPAddr, func(), error) {
+	conn, err := net.ListenPacket("udp", addr)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to listen")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = a.Serve(ctx, conn)
+	}()
+	stop := func() {
+		cancel()
+		<-done
+	}
+	return conn.LocalAddr().(*net.UDPAddr), stop, nil
+}
+
+// Serve answers the requests received on the connection until the context is canceled. The connection is closed afterwards.
+func (a *Agent) Serve(ctx context.Context, conn net.PacketConn) error {
+	go func() {
+		<-ctx.Done()
+		_ = conn.Close()
+	}()
+
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return errors.Wrap(err, "failed to read packet")
+		}
+
+		res, err := a.handle(buf[:n])
+		if err != nil {
+			log.Ctx(ctx).Debug().Err(err).Str("source", addr.String()).Msg("dropped snmp request")
+			continue
+		}
+		if _, err := conn.WriteTo(res, addr); err != nil {
+			log.Ctx(ctx).Debug().Err(err).Str("source", addr.String()).Msg("failed to send snmp response")
+		}
+	}
+}
+
+// handle decodes a request and returns the encoded response.
+func (a *Agent) handle(packet []byte) ([]byte, error) {
+	decoder := gosnmp.GoSNMP{
+		Logger:             discardLogger,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{Logger: discardLogger},
+	}
+	req, err := decoder.SnmpDecodePacket(packet)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode packet")
+	}
+
+	res := gosnmp.SnmpPacket{
+		Version:   req.Version,
+		Community: req.Community,
+		PDUType:   gosnmp.GetResponse,
+		RequestID: req.RequestID,
+		Logger:    discardLogger,
+	}
+
+	community := req.Community
+	if req.Version == gosnmp.Version3 {
+		if req.MsgFlags&gosnmp.AuthPriv != gosnmp.NoAuthNoPriv {
+			return nil, errors.New("only snmp v3 requests with security level noAuthNoPriv are supported")
+		}
+		usm, ok := req.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+		if !ok {
+			return nil, errors.New("unsupported snmp v3 security model")
+		}
+
+		res.MsgID = req.MsgID
+		res.MsgFlags = gosnmp.NoAuthNoPriv
+		res.SecurityModel = gosnmp.UserSecurityModel
+		res.ContextEngineID = a.engineID
+		res.ContextName = req.ContextName
+		res.SecurityParameters = &gosnmp.UsmSecurityParameters{
+			UserName:                 usm.UserName,
+			AuthoritativeEngineID:    a.engineID,
+			AuthoritativeEngineBoots: 1,
+			AuthoritativeEngineTime:  uint32(time.Since(a.engineStart).Seconds()),
+			Logger:                   discardLogger,
+		}
+
+		if usm.AuthoritativeEngineID == "" {
+			// engine id discovery
+			res.PDUType = gosnmp.Report
+			res.Variables = []gosnmp.SnmpPDU{{Name: usmStatsUnknownEngineIDs, Type: gosnmp.Counter32, Value: uint32(1)}}
+			return res.MarshalMsg()
+		}
+		community = req.ContextName
+	}
+
+	rec, ok := a.getRecording(community)
+	if !ok {
+		return nil, errors.Errorf("no recording for community '%s'", community)
+	}
+
+	switch req.PDUType {
+	case gosnmp.GetRequest:
+		res.Variables = a.get(rec, req)
+	case gosnmp.GetNextRequest:
+		res.Variables = a.getNext(rec, req)
+	case gosnmp.GetBulkRequest:
+		if req.Version == gosnmp.Version1 {
+			return nil, errors.New("getbulk is not supported in snmp v1")
+		}
+		req.MaxRepetitions, err = getBulkMaxRepetitions(packet)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode max repetitions")
+		}
+		res.Variables = a.getBulk(rec, req)
+	default:
+		return nil, errors.Errorf("unsupported pdu type %#x", byte(req.PDUType))
+	}
+
+	if req.Version == gosnmp.Version1 {
+		// snmp v1 has no exceptions, the first varbind that is not available is reported as error
+		for i, v := range res.Variables {
+			if v.Type == gosnmp.NoSuchObject || v.Type == gosnmp.NoSuchInstance || v.Type == gosnmp.EndOfMibView {
+				res.Error = gosnmp.NoSuchName
+				res.ErrorIndex = uint8(i + 1)
+				res.Variables = req.Variables
+				for j := range res.Variables {
+					res.Variables[j].Type = gosnmp.Null
+					res.Variables[j].Value = nil
+				}
+				break
+			}
+		}
+	}
+
+	msg, err := res.MarshalMsg()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode response")
+	}
+
+	// truncate getbulk responses that are too large
+	for len(msg) > maxPacketSize && req.PDUType == gosnmp.GetBulkRequest && len(res.Variables) > 1 {
+		res.Variables = res.Variables[:len(res.Variables)/2]
+		msg, err = res.MarshalMsg()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode response")
+		}
+	}
+	if len(msg) > maxPacketSize {
+		res.Error = gosnmp.TooBig
+		res.Variables = nil
+		return res.MarshalMsg()
+	}
+	return msg, nil
+}
+
+func (a *Agent) get(rec *Recording, req *gosnmp.SnmpPacket) []gosnmp.SnmpPDU {
+	res := make([]gosnmp.SnmpPDU, len(req.Variables))
+	for i, v := range req.Variables {
+		if pdu, ok := rec.Get(v.Name); ok {
+			res[i] = pdu
+		} else {
+			res[i] = gosnmp.SnmpPDU{Name: v.Name, Type: gosnmp.NoSuchObject}
+		}
+	}
+	return res
+}
+
+func (a *Agent) getNext(rec *Recording, req *gosnmp.SnmpPacket) []gosnmp.SnmpPDU {
+	res := make([]gosnmp.SnmpPDU, len(req.Variables))
+	for i, v := range req.Variables {
+		res[i] = next(rec, v.Name)
+	}
+	return res
+}
+
+func (a *Agent) getBulk(rec *Recording, req *gosnmp.SnmpPacket) []gosnmp.SnmpPDU {
+	nonRepeaters := int(req.NonRepeaters)
+	if nonRepeaters > len(req.Variables) {
+		nonRepeaters = len(req.Variables)
+	}
+
+	var res []gosnmp.SnmpPDU
+	for _, v := range req.Variables[:nonRepeaters] {
+		res = append(res, next(rec, v.Name))
+	}
+
+	repeaters := req.Variables[nonRepeaters:]
+	if len(repeaters) == 0 {
+		return res
+	}
+	current := make([]string, len(repeaters))
+	for i, v := range repeaters {
+		current[i] = v.Name
+	}
+	for r := 0; r < int(req.MaxRepetitions) && len(res)+len(current) <= maxBulkVarbinds; r++ {
+		endOfMibView := true
+		for i, oid := range current {
+			pdu := next(rec, oid)
+			if pdu.Type != gosnmp.EndOfMibView {
+				endOfMibView = false
+			}
+			res = append(res, pdu)
+			current[i] = pdu.Name
+		}
+		if endOfMibView {
+			break
+		}
+	}
+	return res
+}
+
+func next(rec *Recording, oid string) gosnmp.SnmpPDU {
+	if pdu, ok := rec.GetNext(oid); ok {
+		return pdu
+	}
+	return gosnmp.SnmpPDU{Name: oid, Type: gosnmp.EndOfMibView}
+}
@@ -0,0 +1,165 @@
+package snmpsim
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/network"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const testRecording = `1.3.6.1.2.1.1.1.0|4|Test Device
+1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.9.1.222
+1.3.6.1.2.1.1.3.0|67|13866832
+1.3.6.1.2.1.2.2.1.2.1|4x|657468302f30
+1.3.6.1.2.1.2.2.1.2.2|4|eth1
+1.3.6.1.2.1.2.2.1.2.10|4|eth10
+1.3.6.1.2.1.31.1.1.1.6.1|70|18446744073709551615
+`
+
+func startTestAgent(t *testing.T) int {
+	t.Helper()
+	rec, err := ReadRecording(strings.NewReader(testRecording))
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	agent := NewAgent()
+	agent.AddRecording("test/device", rec)
+
+	addr, stop, err := agent.Start("127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	t.Cleanup(stop)
+	return addr.Port
+}
+
+func responseValues(t *testing.T, responses []network.SNMPResponse) []string {
+	t.Helper()
+	var res []string
+	for _, r := range responses {
+		v, err := r.GetValue()
+		if assert.NoError(t, err) {
+			res = append(res, v.String())
+		}
+	}
+	return res
+}
+
+func TestAgent_v2c(t *testing.T) {
+	port := startTestAgent(t)
+	ctx := context.Background()
+
+	client, err := network.NewSNMPClient(ctx, "127.0.0.1", "2c", "test/device", port, 1, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Disconnect()
+
+	res, err := client.SNMPGet(ctx, "1.3.6.1.2.1.1.1.0", "1.3.6.1.2.1.1.3.0", "1.3.6.1.2.1.1.4.0")
+	if assert.NoError(t, err) && assert.Len(t, res, 3) {
+		assert.Equal(t, []string{"Test Device", "13866832"}, responseValues(t, res[:2]))
+		assert.False(t, res[2].WasSuccessful())
+	}
+
+	client.SetMaxRepetitions(2)
+	res, err = client.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"eth0/0", "eth1", "eth10"}, responseValues(t, res))
+		assert.Equal(t, network.OID(".1.3.6.1.2.1.2.2.1.2.10"), res[2].GetOID())
+	}
+
+	res, err = client.SNMPGet(ctx, "1.3.6.1.2.1.31.1.1.1.6.1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"18446744073709551615"}, responseValues(t, res))
+	}
+}
+
+func TestAgent_handle_unknownCommunity(t *testing.T) {
+	req := gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: "unknown",
+		PDUType:   gosnmp.GetRequest,
+		Variables: []gosnmp.SnmpPDU{{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.Null}},
+	}
+	packet, err := req.MarshalMsg()
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = NewAgent().handle(packet)
+	assert.Error(t, err, "requests with unknown communities must not be answered")
+}
+
+func TestAgent_v1(t *testing.T) {
+	port := startTestAgent(t)
+	ctx := context.Background()
+
+	client, err := network.NewSNMPClient(ctx, "127.0.0.1", "1", "test/device", port, 1, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Disconnect()
+
+	res, err := client.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"eth0/0", "eth1", "eth10"}, responseValues(t, res))
+	}
+}
+
+func TestAgent_v3(t *testing.T) {
+	port := startTestAgent(t)
+	ctx := context.Background()
+
+	level, user, contextName := "noAuthNoPriv", "thola", "test/device"
+	client, err := network.NewSNMPv3Client(ctx, "127.0.0.1", port, 1, 0, network.SNMPv3ConnectionData{
+		Level:       &level,
+		User:        &user,
+		ContextName: &contextName,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer client.Disconnect()
+
+	res, err := client.SNMPGet(ctx, "1.3.6.1.2.1.1.2.0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{".1.3.6.1.4.1.9.1.222"}, responseValues(t, res))
+	}
+}
+
+func TestAgent_getBulk(t *testing.T) {
+	rec, err := ReadRecording(strings.NewReader(testRecording))
+	if !assert.NoError(t, err) {
+		return
+	}
+	agent := NewAgent()
+
+	res := agent.getBulk(rec, &gosnmp.SnmpPacket{
+		NonRepeaters:   1,
+		MaxRepetitions: 3,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1"},
+			{Name: ".1.3.6.1.2.1.2.2.1.2.2"},
+			{Name: ".1.3.6.1.2.1.31.1.1.1.6"},
+		},
+	})
+	var names []string
+	for _, pdu := range res {
+		names = append(names, pdu.Name)
+	}
+	assert.Equal(t, []string{
+		".1.3.6.1.2.1.1.1.0",
+		".1.3.6.1.2.1.2.2.1.2.10", ".1.3.6.1.2.1.31.1.1.1.6.1",
+		".1.3.6.1.2.1.31.1.1.1.6.1", ".1.3.6.1.2.1.31.1.1.1.6.1",
+		".1.3.6.1.2.1.31.1.1.1.6.1", ".1.3.6.1.2.1.31.1.1.1.6.1",
+	}, names)
+	assert.Equal(t, gosnmp.EndOfMibView, res[len(res)-1].Type)
+}
+
+func TestAgent_LoadDir(t *testing.T) {
+	agent := NewAgent()
+	if assert.NoError(t, agent.LoadDir("../../test/testdata/devices")) {
+		assert.Contains(t, agent.Communities(), "ios/7206VXR/public")
+	}
+}
@@ -0,0 +1,280 @@
+package snmpsim
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// entPhysicalSerialNum contains the serial numbers of all physical entities (ENTITY-MIB).
+const entPhysicalSerialNum = ".1.3.6.1.2.1.47.1.1.1.1.11"
+
+// anonymizedCommunity replaces all communities in the recording.
+const anonymizedCommunity = "public"
+
+// minSerialLength is the minimum length of serial numbers that are also replaced inside of other values.
+// Shorter serial numbers are only replaced in values that match them exactly.
+const minSerialLength = 6
+
+// ipInOIDStart is the position in OIDs from which on IP addresses in table indices are replaced.
+// It skips the OIDs of the tables themselves, e.g. ".1.3.6.1.2.1.4.20.1.1" in ".1.3.6.1.2.1.4.20.1.1.<ip>".
+const ipInOIDStart = 9
+
+var ipInString = regexp.MustCompile(`\b(?:[0-9]{1,3}\.){3}[0-9]{1,3}\b`)
+
+// AnonymizeOptions contains the data which has to be anonymized in addition to the data that is found in the recording.
+type AnonymizeOptions struct {
+	// Communities are replaced by "public" in all values.
+	Communities []string
+	// IPAddresses are anonymized like the IP addresses in the recording, e.g. the IP address of the device.
+	IPAddresses []string
+	// SerialNumbers are anonymized like the serial numbers of the ENTITY-MIB, e.g. the serial number found by identify.
+	SerialNumbers []string
+}
+
+// Anonymize returns a copy of the recording in which communities, IP addresses and serial numbers are replaced.
+// IP addresses are replaced by addresses from 10.0.0.0/8 while keeping the last octet, so that addresses of the same /24 network
+// are still in the same network. They are replaced in all IP address values, in table indices and in strings.
+// Serial numbers are replaced by random values with the same pattern of digits and upper and lower case letters,
+// so that they still match the patterns of the device classes.
+func Anonymize(rec *Recording, opts AnonymizeOptions) (*Recording, error) {
+	a, err := newAnonymizer(rec, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	pdus := rec.PDUs()
+	for i, pdu := range pdus {
+		pdus[i], err = a.anonymizePDU(pdu)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to anonymize oid '%s'", pdu.Name)
+		}
+	}
+	return NewRecording(pdus)
+}
+
+type anonymizer struct {
+	key         []byte
+	communities []string
+	ips         map[[4]byte][4]byte
+	serials     map[string]string
+
+	// serialsInValues contains the serials with at least minSerialLength characters, longest first
+	serialsInValues []string
+}
+
+func newAnonymizer(rec *Recording, opts AnonymizeOptions) (*anonymizer, error) {
+	a := anonymizer{
+		key:     make([]byte, 32),
+		ips:     make(map[[4]byte][4]byte),
+		serials: make(map[string]string),
+	}
+	if _, err := rand.Read(a.key); err != nil {
+		return nil, errors.Wrap(err, "failed to generate key")
+	}
+
+	for _, community := range opts.Communities {
+		if community != "" && community != anonymizedCommunity {
+			a.communities = append(a.communities, community)
+		}
+	}
+	// longer communities first, in case a community contains another one
+	sort.Slice(a.communities, func(i, j int) bool {
+		return len(a.communities[i]) > len(a.communities[j])
+	})
+
+	var ips []net.IP
+	for _, ip := range opts.IPAddresses {
+		if parsed := net.ParseIP(ip).To4(); parsed != nil {
+			ips = append(ips, parsed)
+		}
+	}
+	serials := opts.SerialNumbers
+	for _, pdu := range rec.PDUs() {
+		switch {
+		case pdu.Type == gosnmp.IPAddress:
+			if ip := ipValue(pdu.Value); ip != nil {
+				ips = append(ips, ip)
+			}
+		case pdu.Type == gosnmp.OctetString && strings.HasPrefix(pdu.Name, entPhysicalSerialNum+"."):
+			if b, err := bytesValue(pdu.Value); err == nil {
+				serials = append(serials, string(b))
+			}
+		}
+	}
+
+	a.mapIPs(ips)
+	for _, serial := range serials {
+		serial = strings.TrimSpace(serial)
+		if _, ok := a.serials[serial]; ok || serial == "" {
+			continue
+		}
+		a.serials[serial] = a.anonymizeSerial(serial)
+		if len(serial) >= minSerialLength {
+			a.serialsInValues = append(a.serialsInValues, serial)
+		}
+	}
+	sort.Slice(a.serialsInValues, func(i, j int) bool {
+		return len(a.serialsInValues[i]) > len(a.serialsInValues[j])
+	})
+	return &a, nil
+}
+
+// mapIPs assigns a 10.0.0.0/8 network to every /24 network and keeps the last octet.
+func (a *anonymizer) mapIPs(ips []net.IP) {
+	sort.Slice(ips, func(i, j int) bool {
+		return bytes.Compare(ips[i], ips[j]) < 0
+	})
+	networks := make(map[[3]byte][3]byte)
+	for _, ip := range ips {
+		if !isAnonymizableIP(ip) {
+			continue
+		}
+		var network [3]byte
+		copy(network[:], ip[:3])
+		mapped, ok := networks[network]
+		if !ok {
+			n := len(networks) + 1
+			mapped = [3]byte{10, byte(n >> 8), byte(n)}
+			networks[network] = mapped
+		}
+		var orig [4]byte
+		copy(orig[:], ip)
+		a.ips[orig] = [4]byte{mapped[0], mapped[1], mapped[2], ip[3]}
+	}
+}
+
+// isAnonymizableIP returns false for special addresses and netmasks, which don't identify anything.
+func isAnonymizableIP(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() {
+		return false
+	}
+	ones, bits := net.IPMask(ip).Size()
+	return ones == 0 && bits == 0
+}
+
+// anonymizeSerial replaces every digit by a digit and every letter by a letter of the same case.
+func (a *anonymizer) anonymizeSerial(serial string) string {
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(serial))
+	random := mac.Sum(nil)
+
+	res := []byte(serial)
+	for i, c := range res {
+		r := random[i%len(random)] ^ byte(i/len(random))
+		switch {
+		case c >= '0' && c <= '9':
+			res[i] = '0' + r%10
+		case c >= 'A' && c <= 'Z':
+			res[i] = 'A' + r%26
+		case c >= 'a' && c <= 'z':
+			res[i] = 'a' + r%26
+		}
+	}
+	return string(res)
+}
+
+func (a *anonymizer) anonymizePDU(pdu gosnmp.SnmpPDU) (gosnmp.SnmpPDU, error) {
+	oid, err := parseOID(pdu.Name)
+	if err != nil {
+		return gosnmp.SnmpPDU{}, err
+	}
+	pdu.Name = formatOID(a.anonymizeOID(oid))
+
+	switch pdu.Type {
+	case gosnmp.IPAddress:
+		if ip := ipValue(pdu.Value); ip != nil {
+			pdu.Value = a.anonymizeIP(ip).String()
+		}
+	case gosnmp.OctetString:
+		b, err := bytesValue(pdu.Value)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, err
+		}
+		pdu.Value = a.anonymizeBytes(b)
+	}
+	return pdu, nil
+}
+
+// anonymizeOID replaces IP addresses in table indices.
+func (a *anonymizer) anonymizeOID(oid []uint32) []uint32 {
+	for i := ipInOIDStart; i+4 <= len(oid); i++ {
+		var ip [4]byte
+		valid := true
+		for j := 0; j < 4; j++ {
+			if oid[i+j] > 255 {
+				valid = false
+				break
+			}
+			ip[j] = byte(oid[i+j])
+		}
+		if !valid {
+			continue
+		}
+		if mapped, ok := a.ips[ip]; ok {
+			for j := 0; j < 4; j++ {
+				oid[i+j] = uint32(mapped[j])
+			}
+			i += 3
+		}
+	}
+	return oid
+}
+
+func (a *anonymizer) anonymizeIP(ip net.IP) net.IP {
+	var orig [4]byte
+	copy(orig[:], ip.To4())
+	if mapped, ok := a.ips[orig]; ok {
+		return net.IP(mapped[:])
+	}
+	return ip
+}
+
+func (a *anonymizer) anonymizeBytes(b []byte) []byte {
+	// binary ip addresses, e.g. of the type InetAddressIPv4
+	if len(b) == 4 {
+		var orig [4]byte
+		copy(orig[:], b)
+		if mapped, ok := a.ips[orig]; ok {
+			return mapped[:]
+		}
+	}
+
+	s := string(b)
+	if serial, ok := a.serials[strings.TrimSpace(s)]; ok {
+		return []byte(strings.Replace(s, strings.TrimSpace(s), serial, 1))
+	}
+	for _, serial := range a.serialsInValues {
+		s = strings.ReplaceAll(s, serial, a.serials[serial])
+	}
+	for _, community := range a.communities {
+		s = strings.ReplaceAll(s, community, anonymizedCommunity)
+	}
+	s = ipInString.ReplaceAllStringFunc(s, func(match string) string {
+		if ip := net.ParseIP(match).To4(); ip != nil {
+			return a.anonymizeIP(ip).String()
+		}
+		return match
+	})
+	return []byte(s)
+}
+
+// ipValue returns the IP address of an IPAddress value, which is a string or 4 bytes.
+func ipValue(v interface{}) net.IP {
+	switch x := v.(type) {
+	case string:
+		return net.ParseIP(x).To4()
+	case []byte:
+		if len(x) == 4 {
+			return net.IP(x)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package snmpsim
+
+import (
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestAnonymize(t *testing.T) {
+	rec, err := NewRecording([]gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Device FOX1234X5Y6 at 192.168.100.66, community s3cret")},
+		{Name: ".1.3.6.1.2.1.4.20.1.1.192.168.100.66", Type: gosnmp.IPAddress, Value: "192.168.100.66"},
+		{Name: ".1.3.6.1.2.1.4.20.1.3.192.168.100.66", Type: gosnmp.IPAddress, Value: "255.255.255.0"},
+		{Name: ".1.3.6.1.2.1.4.20.1.1.192.168.100.67", Type: gosnmp.IPAddress, Value: []byte{192, 168, 100, 67}},
+		{Name: ".1.3.6.1.2.1.4.20.1.1.127.0.0.1", Type: gosnmp.IPAddress, Value: "127.0.0.1"},
+		{Name: ".1.3.6.1.2.1.47.1.1.1.1.11.1", Type: gosnmp.OctetString, Value: []byte("FOX1234X5Y6")},
+		{Name: ".1.3.6.1.2.1.47.1.1.1.1.11.2", Type: gosnmp.OctetString, Value: []byte("1")},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	anonymized, err := Anonymize(rec, AnonymizeOptions{
+		Communities: []string{"s3cret"},
+		IPAddresses: []string{"172.16.0.1"},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+	pdus := anonymized.PDUs()
+	if !assert.Len(t, pdus, 7) {
+		return
+	}
+
+	serial := regexp.MustCompile(`^[A-Z]{3}[0-9]{4}[A-Z][0-9][A-Z][0-9]$`)
+
+	assert.Equal(t, ".1.3.6.1.2.1.1.1.0", pdus[0].Name)
+	assert.Regexp(t, `^Device [A-Z]{3}[0-9]{4}[A-Z][0-9][A-Z][0-9] at 10\.0\.2\.66, community public$`, string(pdus[0].Value.([]byte)))
+	assert.NotContains(t, string(pdus[0].Value.([]byte)), "FOX1234X5Y6")
+
+	// 127.0.0.1 is not anonymized, 172.16.0.1 is mapped to 10.0.1.1
+	assert.Equal(t, gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.2.66", Type: gosnmp.IPAddress, Value: "10.0.2.66"}, pdus[1])
+	assert.Equal(t, gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.2.67", Type: gosnmp.IPAddress, Value: "10.0.2.67"}, pdus[2])
+	assert.Equal(t, gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.127.0.0.1", Type: gosnmp.IPAddress, Value: "127.0.0.1"}, pdus[3])
+	assert.Equal(t, gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.3.10.0.2.66", Type: gosnmp.IPAddress, Value: "255.255.255.0"}, pdus[4])
+
+	assert.Regexp(t, serial, string(pdus[5].Value.([]byte)))
+	assert.NotEqual(t, "FOX1234X5Y6", string(pdus[5].Value.([]byte)))
+	assert.Regexp(t, `^[0-9]$`, string(pdus[6].Value.([]byte)))
+}
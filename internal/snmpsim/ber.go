@@ -0,0 +1,94 @@
+package snmpsim
+
+import (
+	"github.com/pkg/errors"
+)
+
+// tlv is a BER encoded element.
+type tlv struct {
+	tag   byte
+	value []byte
+}
+
+// parseTLVs parses a sequence of BER encoded elements.
+func parseTLVs(b []byte) ([]tlv, error) {
+	var res []tlv
+	for len(b) > 0 {
+		if len(b) < 2 {
+			return nil, errors.New("truncated ber element")
+		}
+		tag := b[0]
+		length := int(b[1])
+		offset := 2
+		if length&0x80 != 0 {
+			n := length & 0x7f
+			if n == 0 || n > 4 || len(b) < 2+n {
+				return nil, errors.New("invalid ber length")
+			}
+			length = 0
+			for _, x := range b[2 : 2+n] {
+				length = length<<8 | int(x)
+			}
+			offset += n
+		}
+		if length < 0 || len(b) < offset+length {
+			return nil, errors.New("truncated ber element")
+		}
+		res = append(res, tlv{tag: tag, value: b[offset : offset+length]})
+		b = b[offset+length:]
+	}
+	return res, nil
+}
+
+// getBulkMaxRepetitions returns the max-repetitions field of an unencrypted GETBULK request.
+// gosnmp does not decode this field of requests, as it is only needed by agents.
+func getBulkMaxRepetitions(packet []byte) (uint32, error) {
+	message, err := parseTLVs(packet)
+	if err != nil {
+		return 0, err
+	}
+	if len(message) != 1 {
+		return 0, errors.New("invalid snmp message")
+	}
+	fields, err := parseTLVs(message[0].value)
+	if err != nil {
+		return 0, err
+	}
+
+	var pdu tlv
+	switch {
+	case len(fields) == 3: // v1/v2c: version, community, pdu
+		pdu = fields[2]
+	case len(fields) == 4: // v3: version, header, security parameters, scoped pdu
+		scopedPDU, err := parseTLVs(fields[3].value)
+		if err != nil {
+			return 0, err
+		}
+		if len(scopedPDU) != 3 {
+			return 0, errors.New("invalid scoped pdu")
+		}
+		pdu = scopedPDU[2]
+	default:
+		return 0, errors.New("invalid snmp message")
+	}
+
+	pduFields, err := parseTLVs(pdu.value)
+	if err != nil {
+		return 0, err
+	}
+	if len(pduFields) < 3 || pduFields[2].tag != 0x02 || len(pduFields[2].value) == 0 || len(pduFields[2].value) > 5 {
+		return 0, errors.New("invalid getbulk pdu")
+	}
+	if pduFields[2].value[0]&0x80 != 0 {
+		// negative values are treated as zero
+		return 0, nil
+	}
+	var res uint64
+	for _, x := range pduFields[2].value {
+		res = res<<8 | uint64(x)
+	}
+	if res > 0x7fffffff {
+		res = 0x7fffffff
+	}
+	return uint32(res), nil
+}
@@ -0,0 +1,178 @@
+package snmpsim
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog/log"
+	"net"
+	"strings"
+)
+
+// recordingClient is an SNMP client which answers requests directly from a recording without any network communication.
+type recordingClient struct {
+	rec            *Recording
+	community      string
+	maxRepetitions uint32
+}
+
+// NewSNMPClient returns an SNMP client which answers all requests with the data of the recording.
+// The responses are the same as the ones of a real SNMP v2c client requesting the data from an agent serving the recording.
+func NewSNMPClient(rec *Recording, community string) network.SNMPClient {
+	return &recordingClient{
+		rec:            rec,
+		community:      community,
+		maxRepetitions: 10,
+	}
+}
+
+// SNMPGet returns the PDUs of the given OIDs, OIDs that are not available are returned as NoSuchObject.
+func (c *recordingClient) SNMPGet(ctx context.Context, oid ...network.OID) ([]network.SNMPResponse, error) {
+	var res []network.SNMPResponse
+	successful := false
+	for _, o := range oid {
+		pdu, ok := c.rec.Get(o.String())
+		if !ok {
+			log.Ctx(ctx).Trace().Str("network_request", "snmpget").Str("oid", o.String()).Msg("No Such Object available on this agent at this OID")
+			res = append(res, network.NewSNMPResponse(o, gosnmp.NoSuchObject, nil))
+			continue
+		}
+		response := toSNMPResponse(pdu)
+		if response.WasSuccessful() {
+			successful = true
+		}
+		res = append(res, response)
+	}
+	if !successful {
+		return nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")
+	}
+	return res, nil
+}
+
+// SNMPWalk returns all PDUs below the given OID. Like gosnmp, the OID itself is requested if there is nothing below it.
+func (c *recordingClient) SNMPWalk(ctx context.Context, oid network.OID) ([]network.SNMPResponse, error) {
+	var pdus []gosnmp.SnmpPDU
+	root := "." + strings.Trim(oid.String(), ".")
+	for _, pdu := range c.rec.Walk(root) {
+		if pdu.Name != root {
+			pdus = append(pdus, pdu)
+		}
+	}
+	if len(pdus) == 0 {
+		if pdu, ok := c.rec.Get(root); ok {
+			pdus = append(pdus, pdu)
+		}
+	}
+	if len(pdus) == 0 {
+		log.Ctx(ctx).Trace().Str("network_request", "snmpwalk").Str("oid", oid.String()).Msg("No Such Object available on this agent at this OID")
+		return nil, tholaerr.NewNotFoundError("No Such Object available on this agent at this OID")
+	}
+
+	res := make([]network.SNMPResponse, len(pdus))
+	for i, pdu := range pdus {
+		res[i] = toSNMPResponse(pdu)
+	}
+	return res, nil
+}
+
+// toSNMPResponse converts the PDU to a response with the value types returned by gosnmp.
+func toSNMPResponse(pdu gosnmp.SnmpPDU) network.SNMPResponse {
+	v := pdu.Value
+	switch x := v.(type) {
+	case uint32:
+		v = uint(x)
+	case []byte:
+		if pdu.Type == gosnmp.IPAddress {
+			v = net.IP(x).String()
+		}
+	}
+	return network.NewSNMPResponse(network.OID(pdu.Name), pdu.Type, v)
+}
+
+// UseCache does nothing, the recording does not need to be cached.
+func (c *recordingClient) UseCache(bool) {}
+
+// HasSuccessfulCachedRequest always returns false.
+func (c *recordingClient) HasSuccessfulCachedRequest() bool {
+	return false
+}
+
+// Disconnect does nothing, the client is not connected to anything.
+func (c *recordingClient) Disconnect() error {
+	return nil
+}
+
+// GetCommunity returns the community string
+func (c *recordingClient) GetCommunity() string {
+	return c.community
+}
+
+// SetCommunity updates the community string.
+func (c *recordingClient) SetCommunity(community string) {
+	c.community = community
+}
+
+// GetPort returns the default snmp port.
+func (c *recordingClient) GetPort() int {
+	return 161
+}
+
+// GetVersion returns the snmp version.
+func (c *recordingClient) GetVersion() string {
+	return "2c"
+}
+
+// GetMaxRepetitions returns the max repetitions.
+func (c *recordingClient) GetMaxRepetitions() uint32 {
+	return c.maxRepetitions
+}
+
+// SetMaxRepetitions sets the maximum repetitions.
+func (c *recordingClient) SetMaxRepetitions(maxRepetitions uint32) {
+	c.maxRepetitions = maxRepetitions
+}
+
+// SetMaxOIDs checks the max oids, there is no limit when reading from a recording.
+func (c *recordingClient) SetMaxOIDs(maxOIDs int) error {
+	if maxOIDs < 1 {
+		return errors.New("invalid max oids")
+	}
+	return nil
+}
+
+// GetV3Level returns nil, snmp v3 is not used.
+func (c *recordingClient) GetV3Level() *string {
+	return nil
+}
+
+// GetV3ContextName returns nil, snmp v3 is not used.
+func (c *recordingClient) GetV3ContextName() *string {
+	return nil
+}
+
+// GetV3User returns nil, snmp v3 is not used.
+func (c *recordingClient) GetV3User() *string {
+	return nil
+}
+
+// GetV3AuthKey returns nil, snmp v3 is not used.
+func (c *recordingClient) GetV3AuthKey() *string {
+	return nil
+}
+
+// GetV3AuthProto returns nil, snmp v3 is not used.
+func (c *recordingClient) GetV3AuthProto() *string {
+	return nil
+}
+
+// GetV3PrivKey returns nil, snmp v3 is not used.
+func (c *recordingClient) GetV3PrivKey() *string {
+	return nil
+}
+
+// GetV3PrivProto returns nil, snmp v3 is not used.
+func (c *recordingClient) GetV3PrivProto() *string {
+	return nil
+}
@@ -0,0 +1,43 @@
+package snmpsim
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRecordingClient(t *testing.T) {
+	rec, err := ReadRecording(strings.NewReader(testRecording))
+	if !assert.NoError(t, err) {
+		return
+	}
+	client := NewSNMPClient(rec, "public")
+	ctx := context.Background()
+
+	res, err := client.SNMPGet(ctx, "1.3.6.1.2.1.1.1.0", "1.3.6.1.2.1.1.3.0", "1.3.6.1.2.1.1.4.0")
+	if assert.NoError(t, err) && assert.Len(t, res, 3) {
+		assert.Equal(t, []string{"Test Device", "13866832"}, responseValues(t, res[:2]))
+		assert.Equal(t, gosnmp.TimeTicks, res[1].GetSNMPType())
+		assert.False(t, res[2].WasSuccessful())
+	}
+
+	_, err = client.SNMPGet(ctx, "1.3.6.1.2.1.1.4.0")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+
+	res, err = client.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"eth0/0", "eth1", "eth10"}, responseValues(t, res))
+	}
+
+	// like gosnmp, the oid itself is requested if there is nothing below it
+	res, err = client.SNMPWalk(ctx, "1.3.6.1.2.1.1.1.0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"Test Device"}, responseValues(t, res))
+	}
+
+	_, err = client.SNMPWalk(ctx, "1.3.6.1.2.1.4")
+	assert.True(t, tholaerr.IsNotFoundError(err))
+}
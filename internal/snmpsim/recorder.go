@@ -0,0 +1,61 @@
+package snmpsim
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/network"
+	"sync"
+)
+
+// Recorder is an SNMP client which records all successful responses of the wrapped client,
+// so that exactly the data which was requested can be saved as recording.
+type Recorder struct {
+	network.SNMPClient
+
+	lock sync.Mutex
+	pdus []gosnmp.SnmpPDU
+}
+
+// NewRecorder returns a recorder which wraps the given client.
+func NewRecorder(client network.SNMPClient) *Recorder {
+	return &Recorder{
+		SNMPClient: client,
+	}
+}
+
+// SNMPGet sends the request with the wrapped client and records the response.
+func (r *Recorder) SNMPGet(ctx context.Context, oid ...network.OID) ([]network.SNMPResponse, error) {
+	res, err := r.SNMPClient.SNMPGet(ctx, oid...)
+	r.record(res)
+	return res, err
+}
+
+// SNMPWalk sends the request with the wrapped client and records the response.
+func (r *Recorder) SNMPWalk(ctx context.Context, oid network.OID) ([]network.SNMPResponse, error) {
+	res, err := r.SNMPClient.SNMPWalk(ctx, oid)
+	r.record(res)
+	return res, err
+}
+
+// Disconnect does nothing, the wrapped client has to be disconnected by its owner.
+// This way the recorder can be used for multiple requests.
+func (r *Recorder) Disconnect() error {
+	return nil
+}
+
+func (r *Recorder) record(responses []network.SNMPResponse) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	for _, response := range responses {
+		if response.WasSuccessful() && response.GetSNMPType() != gosnmp.EndOfMibView {
+			r.pdus = append(r.pdus, response.GetPDU())
+		}
+	}
+}
+
+// Recording returns a recording of all recorded responses.
+func (r *Recorder) Recording() (*Recording, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return NewRecording(r.pdus)
+}
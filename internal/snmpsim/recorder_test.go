@@ -0,0 +1,36 @@
+package snmpsim
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	rec, err := ReadRecording(strings.NewReader(testRecording))
+	if !assert.NoError(t, err) {
+		return
+	}
+	recorder := NewRecorder(NewSNMPClient(rec, "public"))
+	ctx := context.Background()
+
+	_, err = recorder.SNMPGet(ctx, "1.3.6.1.2.1.1.1.0", "1.3.6.1.2.1.1.4.0")
+	assert.NoError(t, err)
+	_, err = recorder.SNMPWalk(ctx, "1.3.6.1.2.1.2.2.1.2")
+	assert.NoError(t, err)
+	_, err = recorder.SNMPWalk(ctx, "1.3.6.1.2.1.4")
+	assert.Error(t, err)
+
+	recorded, err := recorder.Recording()
+	if assert.NoError(t, err) {
+		var oids []string
+		for _, pdu := range recorded.PDUs() {
+			oids = append(oids, pdu.Name)
+		}
+		assert.Equal(t, []string{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.2.2.1.2.1", ".1.3.6.1.2.1.2.2.1.2.2", ".1.3.6.1.2.1.2.2.1.2.10"}, oids)
+		pdu, _ := recorded.Get("1.3.6.1.2.1.1.1.0")
+		assert.Equal(t, gosnmp.OctetString, pdu.Type)
+	}
+}
@@ -0,0 +1,289 @@
+// Package snmpsim contains an SNMP agent which serves recorded SNMP data from snmprec files, as known from snmpsim,
+// and an SNMP client which reads the recorded data directly without any network communication.
+package snmpsim
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Recording contains the SNMP data of a single device ordered by OID.
+type Recording struct {
+	records []record
+}
+
+type record struct {
+	oid []uint32
+	pdu gosnmp.SnmpPDU
+}
+
+// ReadRecordingFile reads a recording from a snmprec file.
+// Files without the extension ".snmprec" are read as output of "snmpwalk -On".
+func ReadRecordingFile(file string) (*Recording, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open recording")
+	}
+	defer f.Close()
+
+	if !strings.HasSuffix(file, snmprecExtension) {
+		rec, err := ReadSNMPWalk(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read snmpwalk file '%s'", file)
+		}
+		return rec, nil
+	}
+
+	rec, err := ReadRecording(f)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read snmprec file '%s'", file)
+	}
+	return rec, nil
+}
+
+// ReadRecording reads a recording in the snmprec format ("<oid>|<tag>|<value>" per line).
+func ReadRecording(r io.Reader) (*Recording, error) {
+	var pdus []gosnmp.SnmpPDU
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pdu, err := parseRecord(line)
+		if err != nil {
+			return nil, errors.Wrapf(err, "line %d", lineNumber)
+		}
+		pdus = append(pdus, pdu)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read snmprec data")
+	}
+
+	return NewRecording(pdus)
+}
+
+// NewRecording returns a recording which contains the given PDUs.
+// If an OID occurs multiple times, the last PDU is used.
+func NewRecording(pdus []gosnmp.SnmpPDU) (*Recording, error) {
+	records := make([]record, 0, len(pdus))
+	for _, pdu := range pdus {
+		oid, err := parseOID(pdu.Name)
+		if err != nil {
+			return nil, err
+		}
+		pdu.Name = formatOID(oid)
+		records = append(records, record{oid: oid, pdu: pdu})
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return compareOIDs(records[i].oid, records[j].oid) < 0
+	})
+
+	// remove duplicates, the last occurrence wins
+	res := records[:0]
+	for _, rec := range records {
+		if len(res) > 0 && compareOIDs(res[len(res)-1].oid, rec.oid) == 0 {
+			res[len(res)-1] = rec
+			continue
+		}
+		res = append(res, rec)
+	}
+
+	return &Recording{records: res}, nil
+}
+
+// Len returns the amount of OIDs in the recording.
+func (r *Recording) Len() int {
+	return len(r.records)
+}
+
+// PDUs returns all PDUs of the recording ordered by OID.
+func (r *Recording) PDUs() []gosnmp.SnmpPDU {
+	res := make([]gosnmp.SnmpPDU, len(r.records))
+	for i, rec := range r.records {
+		res[i] = rec.pdu
+	}
+	return res
+}
+
+// Get returns the PDU with the given OID.
+func (r *Recording) Get(oid string) (gosnmp.SnmpPDU, bool) {
+	o, err := parseOID(oid)
+	if err != nil {
+		return gosnmp.SnmpPDU{}, false
+	}
+	i := r.search(o)
+	if i < len(r.records) && compareOIDs(r.records[i].oid, o) == 0 {
+		return r.records[i].pdu, true
+	}
+	return gosnmp.SnmpPDU{}, false
+}
+
+// GetNext returns the first PDU whose OID is lexicographically greater than the given OID.
+func (r *Recording) GetNext(oid string) (gosnmp.SnmpPDU, bool) {
+	o, err := parseOID(oid)
+	if err != nil {
+		return gosnmp.SnmpPDU{}, false
+	}
+	i := r.search(o)
+	if i < len(r.records) && compareOIDs(r.records[i].oid, o) == 0 {
+		i++
+	}
+	if i < len(r.records) {
+		return r.records[i].pdu, true
+	}
+	return gosnmp.SnmpPDU{}, false
+}
+
+// Walk returns all PDUs whose OIDs are located below the given OID.
+func (r *Recording) Walk(oid string) []gosnmp.SnmpPDU {
+	o, err := parseOID(oid)
+	if err != nil {
+		return nil
+	}
+	var res []gosnmp.SnmpPDU
+	for i := r.search(o); i < len(r.records); i++ {
+		if !hasOIDPrefix(r.records[i].oid, o) {
+			break
+		}
+		res = append(res, r.records[i].pdu)
+	}
+	return res
+}
+
+// search returns the index of the first record whose OID is greater or equal to the given OID.
+func (r *Recording) search(oid []uint32) int {
+	return sort.Search(len(r.records), func(i int) bool {
+		return compareOIDs(r.records[i].oid, oid) >= 0
+	})
+}
+
+func parseRecord(line string) (gosnmp.SnmpPDU, error) {
+	parts := strings.SplitN(line, "|", 3)
+	if len(parts) != 3 {
+		return gosnmp.SnmpPDU{}, errors.New("record has to look like '<oid>|<tag>|<value>'")
+	}
+	name, tag, v := parts[0], parts[1], parts[2]
+
+	hexEncoded := strings.HasSuffix(tag, "x")
+	tag = strings.TrimSuffix(tag, "x")
+	if hexEncoded {
+		decoded, err := hex.DecodeString(v)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, errors.Wrap(err, "invalid hex value")
+		}
+		v = string(decoded)
+	}
+
+	pdu := gosnmp.SnmpPDU{Name: name}
+	switch tag {
+	case "2":
+		i, err := strconv.ParseInt(v, 10, 32)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, errors.Wrap(err, "invalid integer value")
+		}
+		pdu.Type = gosnmp.Integer
+		pdu.Value = int(i)
+	case "4":
+		pdu.Type = gosnmp.OctetString
+		pdu.Value = []byte(v)
+	case "5":
+		pdu.Type = gosnmp.Null
+	case "6":
+		oid, err := parseOID(v)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, err
+		}
+		pdu.Type = gosnmp.ObjectIdentifier
+		pdu.Value = formatOID(oid)
+	case "64":
+		pdu.Type = gosnmp.IPAddress
+		if hexEncoded {
+			pdu.Value = []byte(v)
+		} else {
+			pdu.Value = v
+		}
+	case "65", "66", "67":
+		i, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, errors.Wrap(err, "invalid unsigned integer value")
+		}
+		pdu.Type = map[string]gosnmp.Asn1BER{"65": gosnmp.Counter32, "66": gosnmp.Gauge32, "67": gosnmp.TimeTicks}[tag]
+		pdu.Value = uint32(i)
+	case "68":
+		// opaque values can't be encoded by gosnmp, they are served as octet strings
+		pdu.Type = gosnmp.OctetString
+		pdu.Value = []byte(v)
+	case "70":
+		i, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, errors.Wrap(err, "invalid counter64 value")
+		}
+		pdu.Type = gosnmp.Counter64
+		pdu.Value = i
+	default:
+		return gosnmp.SnmpPDU{}, fmt.Errorf("unsupported tag '%s'", parts[1])
+	}
+	return pdu, nil
+}
+
+func parseOID(s string) ([]uint32, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), ".")
+	if s == "" {
+		return nil, errors.New("empty oid")
+	}
+	parts := strings.Split(s, ".")
+	oid := make([]uint32, len(parts))
+	for i, p := range parts {
+		n, err := strconv.ParseUint(p, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid oid '%s'", s)
+		}
+		oid[i] = uint32(n)
+	}
+	return oid, nil
+}
+
+func formatOID(oid []uint32) string {
+	var b strings.Builder
+	for _, n := range oid {
+		b.WriteString(".")
+		b.WriteString(strconv.FormatUint(uint64(n), 10))
+	}
+	return b.String()
+}
+
+func compareOIDs(a, b []uint32) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+func hasOIDPrefix(oid, prefix []uint32) bool {
+	return len(oid) >= len(prefix) && compareOIDs(oid[:len(prefix)], prefix) == 0
+}
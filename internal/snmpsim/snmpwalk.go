@@ -0,0 +1,196 @@
+package snmpsim
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// snmpwalkLine matches the beginning of a variable in the output of net-snmp, e.g. ".1.3.6.1.2.1.1.3.0 = Timeticks: (1) 0:00:00.01".
+var snmpwalkLine = regexp.MustCompile(`^\.?[0-9]+(\.[0-9]+)* = `)
+
+// ReadSNMPWalk reads a recording from the output of net-snmp's snmpwalk with numeric OIDs ("snmpwalk -On ...").
+func ReadSNMPWalk(r io.Reader) (*Recording, error) {
+	var pdus []gosnmp.SnmpPDU
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	// values can span multiple lines, so a variable is parsed when the next one starts
+	var current string
+	var currentLine, lineNumber int
+	parse := func() error {
+		if current == "" {
+			return nil
+		}
+		pdu, ok, err := parseSNMPWalkVariable(current)
+		if err != nil {
+			return errors.Wrapf(err, "line %d", currentLine)
+		}
+		if ok {
+			pdus = append(pdus, pdu)
+		}
+		return nil
+	}
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if snmpwalkLine.MatchString(line) {
+			if err := parse(); err != nil {
+				return nil, err
+			}
+			current, currentLine = line, lineNumber
+			continue
+		}
+		if current == "" {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: variable has to look like '<numeric oid> = <type>: <value>', use 'snmpwalk -On'", lineNumber)
+		}
+		current += "\n" + line
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read snmpwalk data")
+	}
+	if err := parse(); err != nil {
+		return nil, err
+	}
+
+	return NewRecording(pdus)
+}
+
+// parseSNMPWalkVariable parses a single variable. Exceptions like "No Such Object" are skipped.
+func parseSNMPWalkVariable(s string) (gosnmp.SnmpPDU, bool, error) {
+	parts := strings.SplitN(s, " = ", 2)
+	pdu := gosnmp.SnmpPDU{Name: parts[0]}
+	v := parts[1]
+
+	if v == `""` {
+		pdu.Type = gosnmp.OctetString
+		pdu.Value = []byte{}
+		return pdu, true, nil
+	}
+	if strings.HasPrefix(v, "No Such ") || strings.HasPrefix(v, "No more variables") {
+		return pdu, false, nil
+	}
+
+	typ := v
+	v = ""
+	if i := strings.Index(typ, ":"); i >= 0 {
+		typ, v = typ[:i], strings.TrimPrefix(typ[i+1:], " ")
+	}
+	// values of the wrong type are printed like "Wrong Type (should be INTEGER): Gauge32: 1"
+	if strings.HasPrefix(typ, "Wrong Type") {
+		return parseSNMPWalkVariable(pdu.Name + " = " + v)
+	}
+
+	switch typ {
+	case "STRING":
+		pdu.Type = gosnmp.OctetString
+		pdu.Value = []byte(unquote(v))
+	case "Hex-STRING", "BITS":
+		b, err := parseHexString(v)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, false, err
+		}
+		pdu.Type = gosnmp.OctetString
+		pdu.Value = b
+	case "Opaque":
+		pdu.Type = gosnmp.OctetString
+		pdu.Value = []byte(v)
+	case "INTEGER":
+		i, err := strconv.ParseInt(enumValue(v), 10, 32)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, false, errors.Wrap(err, "invalid integer value")
+		}
+		pdu.Type = gosnmp.Integer
+		pdu.Value = int(i)
+	case "Counter32", "Gauge32", "Unsigned32", "Timeticks":
+		i, err := strconv.ParseUint(enumValue(v), 10, 32)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, false, errors.Wrap(err, "invalid unsigned integer value")
+		}
+		pdu.Type = map[string]gosnmp.Asn1BER{"Counter32": gosnmp.Counter32, "Gauge32": gosnmp.Gauge32, "Unsigned32": gosnmp.Gauge32, "Timeticks": gosnmp.TimeTicks}[typ]
+		pdu.Value = uint32(i)
+	case "Counter64":
+		i, err := strconv.ParseUint(enumValue(v), 10, 64)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, false, errors.Wrap(err, "invalid counter64 value")
+		}
+		pdu.Type = gosnmp.Counter64
+		pdu.Value = i
+	case "OID":
+		oid, err := parseOID(v)
+		if err != nil {
+			return gosnmp.SnmpPDU{}, false, errors.Wrap(err, "only numeric oids are supported, use 'snmpwalk -On'")
+		}
+		pdu.Type = gosnmp.ObjectIdentifier
+		pdu.Value = formatOID(oid)
+	case "IpAddress", "Network Address":
+		ip := net.ParseIP(v).To4()
+		if ip == nil {
+			// network addresses are printed as hex, e.g. "0A:00:00:01"
+			b, err := parseHexString(strings.ReplaceAll(v, ":", " "))
+			if err != nil || len(b) != 4 {
+				return gosnmp.SnmpPDU{}, false, fmt.Errorf("invalid ip address '%s'", v)
+			}
+			ip = b
+		}
+		pdu.Type = gosnmp.IPAddress
+		pdu.Value = ip.String()
+	case "NULL":
+		pdu.Type = gosnmp.Null
+	default:
+		return gosnmp.SnmpPDU{}, false, fmt.Errorf("unsupported type '%s'", typ)
+	}
+	return pdu, true, nil
+}
+
+// enumValue returns the numeric value of values like "up(1)", "(123) 0:00:01.23" or "5 kB".
+func enumValue(v string) string {
+	if i := strings.Index(v, "("); i >= 0 {
+		if j := strings.Index(v[i:], ")"); j >= 0 {
+			return v[i+1 : i+j]
+		}
+	}
+	if fields := strings.Fields(v); len(fields) > 0 {
+		return fields[0]
+	}
+	return v
+}
+
+// parseHexString parses hex values like "00 1A 2B", trailing text like the names of bits is ignored.
+func parseHexString(v string) ([]byte, error) {
+	var res []byte
+	for _, field := range strings.Fields(v) {
+		if len(field) != 2 {
+			break
+		}
+		b, err := hex.DecodeString(field)
+		if err != nil {
+			break
+		}
+		res = append(res, b...)
+	}
+	if res == nil && strings.TrimSpace(v) != "" {
+		return nil, fmt.Errorf("invalid hex value '%s'", v)
+	}
+	return res, nil
+}
+
+func unquote(v string) string {
+	if len(v) >= 2 && strings.HasPrefix(v, `"`) && strings.HasSuffix(v, `"`) {
+		v = v[1 : len(v)-1]
+		v = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(v)
+	}
+	return v
+}
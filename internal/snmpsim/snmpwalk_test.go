@@ -0,0 +1,48 @@
+package snmpsim
+
+import (
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+const testSNMPWalk = `.1.3.6.1.2.1.1.1.0 = STRING: "Test Device
+second line with \"quotes\""
+.1.3.6.1.2.1.1.2.0 = OID: .1.3.6.1.4.1.9.1.222
+.1.3.6.1.2.1.1.3.0 = Timeticks: (13866832) 1 day, 14:31:08.32
+.1.3.6.1.2.1.1.4.0 = ""
+.1.3.6.1.2.1.2.2.1.3.1 = INTEGER: ethernetCsmacd(6)
+.1.3.6.1.2.1.2.2.1.4.1 = INTEGER: 1500
+.1.3.6.1.2.1.2.2.1.6.1 = Hex-STRING: 00 1A 2B 3C 4D 5E 
+.1.3.6.1.2.1.2.2.1.10.1 = Counter32: 4294967295
+.1.3.6.1.2.1.4.20.1.1.10.0.0.1 = IpAddress: 10.0.0.1
+.1.3.6.1.2.1.25.2.2.0 = INTEGER: 2048 KBytes
+.1.3.6.1.2.1.31.1.1.1.6.1 = Counter64: 18446744073709551615
+.1.3.6.1.2.1.31.1.1.1.7.1 = No Such Instance currently exists at this OID
+`
+
+func TestReadSNMPWalk(t *testing.T) {
+	rec, err := ReadSNMPWalk(strings.NewReader(testSNMPWalk))
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Test Device\nsecond line with \"quotes\"")},
+		{Name: ".1.3.6.1.2.1.1.2.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.9.1.222"},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(13866832)},
+		{Name: ".1.3.6.1.2.1.1.4.0", Type: gosnmp.OctetString, Value: []byte{}},
+		{Name: ".1.3.6.1.2.1.2.2.1.3.1", Type: gosnmp.Integer, Value: 6},
+		{Name: ".1.3.6.1.2.1.2.2.1.4.1", Type: gosnmp.Integer, Value: 1500},
+		{Name: ".1.3.6.1.2.1.2.2.1.6.1", Type: gosnmp.OctetString, Value: []byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}},
+		{Name: ".1.3.6.1.2.1.2.2.1.10.1", Type: gosnmp.Counter32, Value: uint32(4294967295)},
+		{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"},
+		{Name: ".1.3.6.1.2.1.25.2.2.0", Type: gosnmp.Integer, Value: 2048},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.6.1", Type: gosnmp.Counter64, Value: uint64(18446744073709551615)},
+	}, rec.PDUs())
+}
+
+func TestReadSNMPWalk_symbolicOIDs(t *testing.T) {
+	_, err := ReadSNMPWalk(strings.NewReader("SNMPv2-MIB::sysDescr.0 = STRING: Test Device\n"))
+	assert.EqualError(t, err, "line 1: variable has to look like '<numeric oid> = <type>: <value>', use 'snmpwalk -On'")
+}
@@ -0,0 +1,87 @@
+package snmpsim
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+)
+
+// WriteRecording writes the recording in the snmprec format ("<oid>|<tag>|<value>" per line).
+func WriteRecording(w io.Writer, rec *Recording) error {
+	bw := bufio.NewWriter(w)
+	for _, pdu := range rec.PDUs() {
+		line, err := formatRecord(pdu)
+		if err != nil {
+			return errors.Wrapf(err, "failed to format oid '%s'", pdu.Name)
+		}
+		if _, err := bw.WriteString(line + "\n"); err != nil {
+			return errors.Wrap(err, "failed to write snmprec data")
+		}
+	}
+	return errors.Wrap(bw.Flush(), "failed to write snmprec data")
+}
+
+func formatRecord(pdu gosnmp.SnmpPDU) (string, error) {
+	name := strings.TrimPrefix(pdu.Name, ".")
+	switch pdu.Type {
+	case gosnmp.Integer:
+		return fmt.Sprintf("%s|2|%d", name, pdu.Value), nil
+	case gosnmp.OctetString, gosnmp.Opaque:
+		tag := "4"
+		if pdu.Type == gosnmp.Opaque {
+			tag = "68"
+		}
+		b, err := bytesValue(pdu.Value)
+		if err != nil {
+			return "", err
+		}
+		if isPrintable(b) {
+			return fmt.Sprintf("%s|%s|%s", name, tag, b), nil
+		}
+		return fmt.Sprintf("%s|%sx|%s", name, tag, hex.EncodeToString(b)), nil
+	case gosnmp.Null:
+		return name + "|5|", nil
+	case gosnmp.ObjectIdentifier:
+		return fmt.Sprintf("%s|6|%s", name, strings.TrimPrefix(fmt.Sprint(pdu.Value), ".")), nil
+	case gosnmp.IPAddress:
+		if b, ok := pdu.Value.([]byte); ok {
+			return fmt.Sprintf("%s|64x|%s", name, hex.EncodeToString(b)), nil
+		}
+		return fmt.Sprintf("%s|64|%s", name, pdu.Value), nil
+	case gosnmp.Counter32:
+		return fmt.Sprintf("%s|65|%d", name, pdu.Value), nil
+	case gosnmp.Gauge32, gosnmp.Uinteger32:
+		return fmt.Sprintf("%s|66|%d", name, pdu.Value), nil
+	case gosnmp.TimeTicks:
+		return fmt.Sprintf("%s|67|%d", name, pdu.Value), nil
+	case gosnmp.Counter64:
+		return fmt.Sprintf("%s|70|%d", name, pdu.Value), nil
+	}
+	return "", fmt.Errorf("unsupported type '%s'", pdu.Type)
+}
+
+func bytesValue(v interface{}) ([]byte, error) {
+	switch x := v.(type) {
+	case []byte:
+		return x, nil
+	case string:
+		return []byte(x), nil
+	case nil:
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unsupported octet string value of type %T", v)
+}
+
+// isPrintable returns whether the value can be written without hex encoding.
+func isPrintable(b []byte) bool {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
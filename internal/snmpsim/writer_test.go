@@ -0,0 +1,44 @@
+package snmpsim
+
+import (
+	"bytes"
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestWriteRecording(t *testing.T) {
+	rec, err := NewRecording([]gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Test Device")},
+		{Name: ".1.3.6.1.2.1.1.2.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.9.1.222"},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(13866832)},
+		{Name: ".1.3.6.1.2.1.2.2.1.3.1", Type: gosnmp.Integer, Value: 6},
+		{Name: ".1.3.6.1.2.1.2.2.1.6.1", Type: gosnmp.OctetString, Value: []byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}},
+		{Name: ".1.3.6.1.2.1.2.2.1.10.1", Type: gosnmp.Counter32, Value: uint(4294967295)},
+		{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.6.1", Type: gosnmp.Counter64, Value: uint64(18446744073709551615)},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if !assert.NoError(t, WriteRecording(&buf, rec)) {
+		return
+	}
+	assert.Equal(t, `1.3.6.1.2.1.1.1.0|4|Test Device
+1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.9.1.222
+1.3.6.1.2.1.1.3.0|67|13866832
+1.3.6.1.2.1.2.2.1.3.1|2|6
+1.3.6.1.2.1.2.2.1.6.1|4x|001a2b3c4d5e
+1.3.6.1.2.1.2.2.1.10.1|65|4294967295
+1.3.6.1.2.1.4.20.1.1.10.0.0.1|64|10.0.0.1
+1.3.6.1.2.1.31.1.1.1.6.1|70|18446744073709551615
+`, buf.String())
+
+	read, err := ReadRecording(strings.NewReader(buf.String()))
+	if assert.NoError(t, err) {
+		assert.Equal(t, rec.Len(), read.Len())
+	}
+}
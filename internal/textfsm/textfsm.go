@@ -0,0 +1,85 @@
+// Package textfsm parses semi-structured text (e.g. the output of CLI commands) with TextFSM templates.
+//
+// A template starts with the definition of the values, followed by a blank line and the states:
+//
+//	Value Required Interface (\S+)
+//	Value Status (up|down)
+//
+//	Start
+//	  ^${Interface} is ${Status} -> Record
+//
+// Templates are processed by github.com/sirikothe/gotextfsm, which follows the Python TextFSM
+// implementation, so that templates like the ntc-templates can be used as they are.
+package textfsm
+
+import (
+	"github.com/pkg/errors"
+	"github.com/sirikothe/gotextfsm"
+)
+
+// Record is a single result row of a parsed text. Values with the option List are returned as []string,
+// all other values as string.
+type Record map[string]interface{}
+
+// Template is a parsed TextFSM template. It can be used concurrently.
+type Template struct {
+	template string
+	values   map[string]struct{}
+}
+
+// NewTemplate parses a TextFSM template.
+func NewTemplate(template string) (*Template, error) {
+	fsm, err := parseTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	t := Template{
+		template: template,
+		values:   make(map[string]struct{}),
+	}
+	for name := range fsm.Values {
+		t.values[name] = struct{}{}
+	}
+	return &t, nil
+}
+
+// MustNewTemplate is like NewTemplate but panics if the template cannot be parsed.
+func MustNewTemplate(template string) *Template {
+	t, err := NewTemplate(template)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func parseTemplate(template string) (gotextfsm.TextFSM, error) {
+	var fsm gotextfsm.TextFSM
+	if err := fsm.ParseString(template); err != nil {
+		return gotextfsm.TextFSM{}, errors.Wrap(err, "invalid textfsm template")
+	}
+	return fsm, nil
+}
+
+// HasValue returns whether the value is defined in the template.
+func (t *Template) HasValue(name string) bool {
+	_, ok := t.values[name]
+	return ok
+}
+
+// Parse parses the text and returns the resulting records.
+func (t *Template) Parse(text string) ([]Record, error) {
+	// gotextfsm keeps the state of the parser in the parsed template, so every text needs its own copy
+	fsm, err := parseTemplate(t.template)
+	if err != nil {
+		return nil, err
+	}
+	var output gotextfsm.ParserOutput
+	if err := output.ParseTextString(text, fsm, true); err != nil {
+		return nil, err
+	}
+	records := make([]Record, 0, len(output.Dict))
+	for _, record := range output.Dict {
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,114 @@
+package textfsm
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+const showInterfacesTemplate = `# interfaces of a cisco device
+Value Required Interface (\S+)
+Value Status (up|down|administratively down)
+Value Description (.*)
+Value List Addresses (\d+\.\d+\.\d+\.\d+)
+
+Start
+  ^${Interface} is ${Status}, -> Continue
+  ^\S+ is .* -> Interface
+
+Interface
+  ^\s+Description: ${Description}
+  ^\s+Internet address is ${Addresses}
+  ^\S+ is .* -> Continue.Record
+  ^${Interface} is ${Status}, -> Interface
+`
+
+const showInterfacesOutput = `GigabitEthernet0/1 is up, line protocol is up
+  Description: uplink
+  Internet address is 192.0.2.1/24
+  Internet address is 198.51.100.1/24 secondary
+GigabitEthernet0/2 is administratively down, line protocol is down
+Loopback0 is up, line protocol is up
+  Internet address is 203.0.113.1/32
+`
+
+func TestTemplate_Parse(t *testing.T) {
+	template, err := NewTemplate(showInterfacesTemplate)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.True(t, template.HasValue("Addresses"))
+	assert.False(t, template.HasValue("Unknown"))
+
+	records, err := template.Parse(showInterfacesOutput)
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Record{
+			{"Interface": "GigabitEthernet0/1", "Status": "up", "Description": "uplink", "Addresses": []string{"192.0.2.1", "198.51.100.1"}},
+			{"Interface": "GigabitEthernet0/2", "Status": "administratively down", "Description": "", "Addresses": []string{}},
+			{"Interface": "Loopback0", "Status": "up", "Description": "", "Addresses": []string{"203.0.113.1"}},
+		}, records)
+	}
+}
+
+func TestTemplate_ParseFilldown(t *testing.T) {
+	template, err := NewTemplate(`Value Filldown Chassis (\d+)
+Value Required Slot (\d+)
+Value Fillup Version (\S+)
+
+Start
+  ^Chassis ${Chassis}
+  ^\s+Slot ${Slot} -> Record
+  ^Version ${Version}
+  ^END -> End
+`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	records, err := template.Parse("Chassis 1\n  Slot 1\n  Slot 2\nVersion 15.1\nChassis 2\n  Slot 1\nEND\n  Slot 9\n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Record{
+			{"Chassis": "1", "Slot": "1", "Version": "15.1"},
+			{"Chassis": "1", "Slot": "2", "Version": "15.1"},
+			{"Chassis": "2", "Slot": "1", "Version": "15.1"},
+		}, records)
+	}
+}
+
+func TestTemplate_ParseError(t *testing.T) {
+	template, err := NewTemplate(`Value Version (\S+)
+
+Start
+  ^Version ${Version}$$
+  ^% Invalid -> Error "invalid command"
+`)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	records, err := template.Parse("Version 1.0\n")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Record{{"Version": "1.0"}}, records)
+	}
+
+	_, err = template.Parse("% Invalid input detected\n")
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "invalid command")
+	}
+}
+
+func TestNewTemplate_Invalid(t *testing.T) {
+	invalid := []string{
+		"",
+		"Value Version\n\nStart\n",
+		"Value Unknown Version (\\S+)\n\nStart\n",
+		"Value Version \\S+\n\nStart\n",
+		"Value Version (\\S+)\n\nOther\n  ^x\n",
+		"Value Version (\\S+)\n\nStart\n  ^x -> Missing\n",
+		"Value Version (\\S+)\n\nStart\n  ^x -> Continue Start\n",
+		"Value Version (\\S+)\n\nStart\n  x\n",
+	}
+	for _, s := range invalid {
+		_, err := NewTemplate(s)
+		assert.Error(t, err, s)
+	}
+}
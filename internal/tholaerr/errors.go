@@ -40,6 +40,34 @@ func (e HTTPError) networkError() bool {
 	return true
 }
 
+// SSHError is an error returned by ssh functions.
+type SSHError struct {
+	error
+}
+
+// NewSSHError returns an ssh error.
+func NewSSHError(msg string) error {
+	return SSHError{errors.New(msg)}
+}
+
+func (e SSHError) networkError() bool {
+	return true
+}
+
+// GNMIError is an error returned by gnmi functions.
+type GNMIError struct {
+	error
+}
+
+// NewGNMIError returns a gnmi error.
+func NewGNMIError(msg string) error {
+	return GNMIError{errors.New(msg)}
+}
+
+func (e GNMIError) networkError() bool {
+	return true
+}
+
 type notFoundError interface {
 	notFoundError() bool
 }
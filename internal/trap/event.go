@@ -0,0 +1,209 @@
+// Package trap implements a receiver for SNMP traps and informs.
+package trap
+
+import (
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/mapping"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/value"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	sysUpTimeOID   = ".1.3.6.1.2.1.1.3.0"
+	snmpTrapOIDOID = ".1.3.6.1.6.3.1.1.4.1.0"
+
+	// snmpTrapsOID is the prefix of the generic traps (RFC 3584, section 3.1).
+	snmpTrapsOID = ".1.3.6.1.6.3.1.1.5"
+)
+
+// Event represents a received SNMP trap or inform.
+type Event struct {
+	// Time when the event was received.
+	Time time.Time `json:"time"`
+	// Source is the IP address the event was sent from.
+	Source string `json:"source"`
+	// AgentAddress is the agent address of a SNMP v1 trap.
+	AgentAddress string `json:"agent_address,omitempty"`
+	// Version is the SNMP version of the event.
+	Version string `json:"version"`
+	// Community of a SNMP v1/v2c event.
+	Community string `json:"community,omitempty"`
+	// User of a SNMP v3 event.
+	User string `json:"user,omitempty"`
+	// Inform is true if the event was an inform request.
+	Inform bool `json:"inform"`
+
+	// DeviceClass is the cached class of the source device.
+	DeviceClass string `json:"device_class,omitempty"`
+	// Vendor is the cached vendor of the source device.
+	Vendor *string `json:"vendor,omitempty"`
+	// Model is the cached model of the source device.
+	Model *string `json:"model,omitempty"`
+
+	// TrapOID is the snmpTrapOID of the event. SNMP v1 traps are converted as described in RFC 3584.
+	TrapOID string `json:"trap_oid"`
+	// Trap is the name of the trap, if known.
+	Trap string `json:"trap,omitempty"`
+	// Uptime of the sending agent in hundredths of a second.
+	Uptime *uint64 `json:"uptime,omitempty"`
+	// Varbinds of the event, without sysUpTime and snmpTrapOID.
+	Varbinds []Varbind `json:"varbinds"`
+}
+
+// Varbind represents a single decoded variable binding of an event.
+type Varbind struct {
+	OID string `json:"oid"`
+	// Name of the object, if known.
+	Name string `json:"name,omitempty"`
+	// Index of the object, if the name is known.
+	Index string `json:"index,omitempty"`
+	Type  string `json:"type"`
+	// Value is the decoded value. If a mapping exists for the object, the mapped value is returned.
+	Value string `json:"value"`
+	// RawValue is the value before it was mapped. It is only set if the value was mapped.
+	RawValue string `json:"raw_value,omitempty"`
+}
+
+type varbindDefinition struct {
+	oid  string
+	name string
+
+	// mapping is the file in config/mapping that is used to map the value.
+	mapping string
+}
+
+// varbindDefinitions contains all objects that are known to be sent in traps.
+var varbindDefinitions = []varbindDefinition{
+	// IF-MIB
+	{oid: ".1.3.6.1.2.1.2.2.1.1", name: "ifIndex"},
+	{oid: ".1.3.6.1.2.1.2.2.1.2", name: "ifDescr"},
+	{oid: ".1.3.6.1.2.1.2.2.1.3", name: "ifType", mapping: "ifType.yaml"},
+	{oid: ".1.3.6.1.2.1.2.2.1.7", name: "ifAdminStatus", mapping: "ifStatus.yaml"},
+	{oid: ".1.3.6.1.2.1.2.2.1.8", name: "ifOperStatus", mapping: "ifStatus.yaml"},
+	{oid: ".1.3.6.1.2.1.31.1.1.1.1", name: "ifName"},
+	{oid: ".1.3.6.1.2.1.31.1.1.1.18", name: "ifAlias"},
+
+	// BGP4-MIB
+	{oid: ".1.3.6.1.2.1.15.3.1.2", name: "bgpPeerState", mapping: "bgpPeerState.yaml"},
+	{oid: ".1.3.6.1.2.1.15.3.1.14", name: "bgpPeerLastError"},
+
+	// OSPF-MIB
+	{oid: ".1.3.6.1.2.1.14.10.1.3", name: "ospfNbrRtrId"},
+	{oid: ".1.3.6.1.2.1.14.10.1.6", name: "ospfNbrState", mapping: "ospfNbrState.yaml"},
+
+	// ISIS-MIB
+	{oid: ".1.3.6.1.2.1.138.1.6.1.1.2", name: "isisISAdjState", mapping: "isisISAdjState.yaml"},
+
+	// ENTITY-MIB
+	{oid: ".1.3.6.1.2.1.47.1.1.1.1.2", name: "entPhysicalDescr"},
+	{oid: ".1.3.6.1.2.1.47.1.1.1.1.5", name: "entPhysicalClass", mapping: "entPhysicalClass.yaml"},
+	{oid: ".1.3.6.1.2.1.47.1.1.1.1.7", name: "entPhysicalName"},
+
+	// CISCO-ENVMON-MIB
+	{oid: ".1.3.6.1.4.1.9.9.13.1.2.1.2", name: "ciscoEnvMonVoltageStatusDescr"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.2.1.3", name: "ciscoEnvMonVoltageStatusValue"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.2.1.7", name: "ciscoEnvMonVoltageState", mapping: "ios_CiscoEnvMonState.yaml"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.3.1.2", name: "ciscoEnvMonTemperatureStatusDescr"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.3.1.3", name: "ciscoEnvMonTemperatureStatusValue"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.3.1.6", name: "ciscoEnvMonTemperatureState", mapping: "ios_CiscoEnvMonState.yaml"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.4.1.2", name: "ciscoEnvMonFanStatusDescr"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.4.1.3", name: "ciscoEnvMonFanState", mapping: "ios_CiscoEnvMonState.yaml"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.5.1.2", name: "ciscoEnvMonSupplyStatusDescr"},
+	{oid: ".1.3.6.1.4.1.9.9.13.1.5.1.3", name: "ciscoEnvMonSupplyState", mapping: "ios_CiscoEnvMonState.yaml"},
+}
+
+// NewEvent decodes a received SNMP packet into an event.
+func NewEvent(packet *gosnmp.SnmpPacket, source string) Event {
+	event := Event{
+		Time:     time.Now(),
+		Source:   source,
+		Version:  packet.Version.String(),
+		Inform:   packet.PDUType == gosnmp.InformRequest,
+		Varbinds: []Varbind{},
+	}
+
+	if packet.Version == gosnmp.Version3 {
+		if usm, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters); ok {
+			event.User = usm.UserName
+		}
+	} else {
+		event.Community = packet.Community
+	}
+
+	if packet.PDUType == gosnmp.Trap {
+		event.AgentAddress = packet.AgentAddress
+		event.TrapOID = getV1TrapOID(packet.SnmpTrap)
+		uptime := uint64(packet.Timestamp)
+		event.Uptime = &uptime
+	}
+
+	for _, pdu := range packet.Variables {
+		oid := normalizeOID(pdu.Name)
+		switch oid {
+		case sysUpTimeOID:
+			if uptime, err := value.New(pdu.Value).UInt64(); err == nil {
+				event.Uptime = &uptime
+			}
+			continue
+		case snmpTrapOIDOID:
+			if trapOID, ok := pdu.Value.(string); ok {
+				event.TrapOID = normalizeOID(trapOID)
+			}
+			continue
+		}
+		event.Varbinds = append(event.Varbinds, newVarbind(oid, pdu))
+	}
+
+	if name, err := mapping.GetMappedValue("snmpTrapOID.yaml", event.TrapOID); err == nil {
+		event.Trap = name
+	}
+
+	return event
+}
+
+func newVarbind(oid string, pdu gosnmp.SnmpPDU) Varbind {
+	varbind := Varbind{
+		OID:  oid,
+		Type: pdu.Type.String(),
+	}
+
+	res := network.NewSNMPResponse(network.OID(oid), pdu.Type, pdu.Value)
+	if v, err := res.GetValue(); err == nil {
+		varbind.Value = v.String()
+	}
+
+	for _, def := range varbindDefinitions {
+		if !strings.HasPrefix(oid, def.oid+".") {
+			continue
+		}
+		varbind.Name = def.name
+		varbind.Index = strings.TrimPrefix(oid, def.oid+".")
+		if def.mapping != "" {
+			if mapped, err := mapping.GetMappedValue(def.mapping, varbind.Value); err == nil {
+				varbind.RawValue = varbind.Value
+				varbind.Value = mapped
+			}
+		}
+		break
+	}
+
+	return varbind
+}
+
+// getV1TrapOID converts the header of a SNMP v1 trap into a snmpTrapOID as described in RFC 3584, section 3.1.
+func getV1TrapOID(trap gosnmp.SnmpTrap) string {
+	if trap.GenericTrap >= 0 && trap.GenericTrap < 6 {
+		return snmpTrapsOID + "." + strconv.Itoa(trap.GenericTrap+1)
+	}
+	return normalizeOID(trap.Enterprise) + ".0." + strconv.Itoa(trap.SpecificTrap)
+}
+
+func normalizeOID(oid string) string {
+	if oid == "" || strings.HasPrefix(oid, ".") {
+		return oid
+	}
+	return "." + oid
+}
@@ -0,0 +1,216 @@
+package trap
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/device"
+	"github.com/inexio/thola/internal/network"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/stretchr/testify/assert"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestNewEvent_V2cLinkDown(t *testing.T) {
+	packet := &gosnmp.SnmpPacket{
+		Version:   gosnmp.Version2c,
+		Community: "public",
+		PDUType:   gosnmp.SNMPv2Trap,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(12345)},
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+			{Name: ".1.3.6.1.2.1.2.2.1.1.3", Type: gosnmp.Integer, Value: 3},
+			{Name: ".1.3.6.1.2.1.2.2.1.7.3", Type: gosnmp.Integer, Value: 1},
+			{Name: ".1.3.6.1.2.1.2.2.1.8.3", Type: gosnmp.Integer, Value: 2},
+			{Name: ".1.3.6.1.4.1.99999.1", Type: gosnmp.OctetString, Value: []byte("custom")},
+		},
+	}
+
+	event := NewEvent(packet, "192.0.2.1")
+
+	assert.Equal(t, "192.0.2.1", event.Source)
+	assert.Equal(t, "2c", event.Version)
+	assert.Equal(t, "public", event.Community)
+	assert.False(t, event.Inform)
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.3", event.TrapOID)
+	assert.Equal(t, "linkDown", event.Trap)
+	if assert.NotNil(t, event.Uptime) {
+		assert.Equal(t, uint64(12345), *event.Uptime)
+	}
+	assert.Equal(t, []Varbind{
+		{OID: ".1.3.6.1.2.1.2.2.1.1.3", Name: "ifIndex", Index: "3", Type: "Integer", Value: "3"},
+		{OID: ".1.3.6.1.2.1.2.2.1.7.3", Name: "ifAdminStatus", Index: "3", Type: "Integer", Value: string(device.StatusUp), RawValue: "1"},
+		{OID: ".1.3.6.1.2.1.2.2.1.8.3", Name: "ifOperStatus", Index: "3", Type: "Integer", Value: string(device.StatusDown), RawValue: "2"},
+		{OID: ".1.3.6.1.4.1.99999.1", Type: "OctetString", Value: "custom"},
+	}, event.Varbinds)
+}
+
+func TestNewEvent_V1Trap(t *testing.T) {
+	packet := &gosnmp.SnmpPacket{
+		Version:   gosnmp.Version1,
+		Community: "public",
+		PDUType:   gosnmp.Trap,
+		SnmpTrap: gosnmp.SnmpTrap{
+			Enterprise:   ".1.3.6.1.4.1.9.9.13.3",
+			AgentAddress: "192.0.2.2",
+			GenericTrap:  6,
+			SpecificTrap: 5,
+			Timestamp:    300,
+		},
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.4.1.9.9.13.1.5.1.2.1", Type: gosnmp.OctetString, Value: []byte("PS1")},
+			{Name: ".1.3.6.1.4.1.9.9.13.1.5.1.3.1", Type: gosnmp.Integer, Value: 3},
+		},
+	}
+
+	event := NewEvent(packet, "192.0.2.1")
+
+	assert.Equal(t, "1", event.Version)
+	assert.Equal(t, "192.0.2.2", event.AgentAddress)
+	assert.Equal(t, ".1.3.6.1.4.1.9.9.13.3.0.5", event.TrapOID)
+	assert.Equal(t, "ciscoEnvMonRedundantSupplyNotification", event.Trap)
+	if assert.NotNil(t, event.Uptime) {
+		assert.Equal(t, uint64(300), *event.Uptime)
+	}
+	if assert.Len(t, event.Varbinds, 2) {
+		assert.Equal(t, "PS1", event.Varbinds[0].Value)
+		assert.Equal(t, "ciscoEnvMonSupplyState", event.Varbinds[1].Name)
+		assert.Equal(t, string(device.HardwareHealthComponentStateCritical), event.Varbinds[1].Value)
+	}
+
+	packet.GenericTrap = 0
+	assert.Equal(t, ".1.3.6.1.6.3.1.1.5.1", NewEvent(packet, "192.0.2.1").TrapOID)
+}
+
+type testForwarder chan Event
+
+func (f testForwarder) Forward(_ context.Context, event Event) error {
+	f <- event
+	return nil
+}
+
+func TestReceiver_Listen(t *testing.T) {
+	listener, err := network.NewSNMPTrapListener(nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	addr := conn.LocalAddr().(*net.UDPAddr)
+	_ = conn.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	forwarder := make(testForwarder, 1)
+	db := newTestDatabase(device.Device{Class: "ios"})
+	receiver := NewReceiver(listener, db, forwarder, []string{"public"})
+
+	done := make(chan error)
+	go func() {
+		done <- receiver.Listen(ctx, addr.String())
+	}()
+
+	select {
+	case <-listener.Listening():
+	case <-time.After(5 * time.Second):
+		t.Fatal("listener did not start")
+	}
+
+	for _, community := range []string{"private", "public"} {
+		sender := &gosnmp.GoSNMP{
+			Target:    "127.0.0.1",
+			Port:      uint16(addr.Port),
+			Transport: "udp",
+			Community: community,
+			Version:   gosnmp.Version2c,
+			Timeout:   time.Second,
+			MaxOids:   gosnmp.MaxOids,
+		}
+		if !assert.NoError(t, sender.Connect()) {
+			return
+		}
+		_, err = sender.SendTrap(gosnmp.SnmpTrap{
+			Variables: []gosnmp.SnmpPDU{
+				{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.4"},
+			},
+		})
+		assert.NoError(t, err)
+		_ = sender.Conn.Close()
+	}
+
+	select {
+	case event := <-forwarder:
+		assert.Equal(t, "public", event.Community)
+		assert.Equal(t, "linkUp", event.Trap)
+		assert.Equal(t, "ios", event.DeviceClass)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("receiver did not stop")
+	}
+}
+
+func newTestDatabase(d device.Device) *deviceDatabase {
+	return &deviceDatabase{device: d}
+}
+
+// deviceDatabase returns the same device for every IP address.
+type deviceDatabase struct {
+	device device.Device
+}
+
+func (d *deviceDatabase) SetDeviceProperties(_ context.Context, _ string, _ device.Device) error {
+	return nil
+}
+
+func (d *deviceDatabase) GetDeviceProperties(_ context.Context, _ string) (device.Device, error) {
+	return d.device, nil
+}
+
+func (d *deviceDatabase) SetConnectionData(_ context.Context, _ string, _ network.ConnectionData) error {
+	return nil
+}
+
+func (d *deviceDatabase) GetConnectionData(_ context.Context, _ string) (network.ConnectionData, error) {
+	return network.ConnectionData{}, tholaerr.NewNotFoundError("not found")
+}
+
+func (d *deviceDatabase) SetInterfaceCounters(_ context.Context, _ string, _ database.InterfaceCounterSnapshot) error {
+	return nil
+}
+
+func (d *deviceDatabase) GetInterfaceCounters(_ context.Context, _ string) (database.InterfaceCounterSnapshot, error) {
+	return database.InterfaceCounterSnapshot{}, tholaerr.NewNotFoundError("not found")
+}
+
+func (d *deviceDatabase) SetDeviceRecord(_ context.Context, _ string, _ database.DeviceRecord) error {
+	return nil
+}
+
+func (d *deviceDatabase) GetDeviceRecord(_ context.Context, _ string) (database.DeviceRecord, error) {
+	return database.DeviceRecord{}, tholaerr.NewNotFoundError("not found")
+}
+
+func (d *deviceDatabase) GetDeviceRecords(_ context.Context) ([]database.DeviceRecord, error) {
+	return nil, nil
+}
+
+func (d *deviceDatabase) CheckConnection(_ context.Context) error {
+	return nil
+}
+
+func (d *deviceDatabase) CloseConnection(_ context.Context) error {
+	return nil
+}
@@ -0,0 +1,64 @@
+package trap
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-resty/resty/v2"
+	"github.com/pkg/errors"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Forwarder forwards received events.
+type Forwarder interface {
+	Forward(ctx context.Context, event Event) error
+}
+
+type writerForwarder struct {
+	sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriterForwarder returns a forwarder that writes the events as newline-delimited JSON to the writer.
+func NewWriterForwarder(w io.Writer) Forwarder {
+	return &writerForwarder{
+		enc: json.NewEncoder(w),
+	}
+}
+
+func (f *writerForwarder) Forward(_ context.Context, event Event) error {
+	f.Lock()
+	defer f.Unlock()
+
+	return f.enc.Encode(event)
+}
+
+type webhookForwarder struct {
+	url    string
+	client *resty.Client
+}
+
+// NewWebhookForwarder returns a forwarder that sends each event as JSON in a POST request to the URL.
+func NewWebhookForwarder(url string, timeout time.Duration) Forwarder {
+	return &webhookForwarder{
+		url:    url,
+		client: resty.New().SetTimeout(timeout),
+	}
+}
+
+func (f *webhookForwarder) Forward(ctx context.Context, event Event) error {
+	res, err := f.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(event).
+		Post(f.url)
+	if err != nil {
+		return errors.Wrap(err, "failed to send event to webhook")
+	}
+	if res.IsError() {
+		return errors.New("webhook returned status code " + strconv.Itoa(res.StatusCode()))
+	}
+	return nil
+}
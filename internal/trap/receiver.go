@@ -0,0 +1,101 @@
+package trap
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"github.com/inexio/thola/internal/database"
+	"github.com/inexio/thola/internal/tholaerr"
+	"github.com/inexio/thola/internal/utility"
+	"github.com/rs/zerolog/log"
+	"net"
+)
+
+// eventQueueSize is the number of events that can be queued for forwarding before new events are dropped.
+const eventQueueSize = 1000
+
+// Receiver receives SNMP traps and informs, enriches them with the cached device class and forwards them.
+type Receiver struct {
+	listener    *gosnmp.TrapListener
+	db          database.Database
+	forwarder   Forwarder
+	communities []string
+}
+
+// NewReceiver returns a new receiver.
+// If communities is not empty, SNMP v1/v2c events with other communities are dropped.
+func NewReceiver(listener *gosnmp.TrapListener, db database.Database, forwarder Forwarder, communities []string) *Receiver {
+	return &Receiver{
+		listener:    listener,
+		db:          db,
+		forwarder:   forwarder,
+		communities: communities,
+	}
+}
+
+// Listen listens on the given address (e.g. "0.0.0.0:162") until the context is canceled.
+func (r *Receiver) Listen(ctx context.Context, addr string) error {
+	events := make(chan Event, eventQueueSize)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range events {
+			if err := r.forwarder.Forward(ctx, event); err != nil {
+				log.Ctx(ctx).Error().Err(err).Str("source", event.Source).Str("trap_oid", event.TrapOID).Msg("failed to forward event")
+			}
+		}
+	}()
+
+	r.listener.OnNewTrap = func(packet *gosnmp.SnmpPacket, addr *net.UDPAddr) {
+		event, ok := r.handle(ctx, packet, addr)
+		if !ok {
+			return
+		}
+		select {
+		case events <- event:
+		default:
+			log.Ctx(ctx).Warn().Str("source", event.Source).Str("trap_oid", event.TrapOID).Msg("event queue is full, dropping event")
+		}
+	}
+
+	go func() {
+		<-ctx.Done()
+		r.listener.Close()
+	}()
+
+	log.Ctx(ctx).Info().Msgf("listening for SNMP traps on '%s'", addr)
+	err := r.listener.Listen(addr)
+
+	close(events)
+	<-done
+
+	if ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (r *Receiver) handle(ctx context.Context, packet *gosnmp.SnmpPacket, addr *net.UDPAddr) (Event, bool) {
+	source := addr.IP.String()
+
+	if packet.Version != gosnmp.Version3 && len(r.communities) > 0 && !utility.StringSliceContains(r.communities, packet.Community) {
+		log.Ctx(ctx).Debug().Str("source", source).Msg("dropped event with unknown community")
+		return Event{}, false
+	}
+
+	event := NewEvent(packet, source)
+
+	properties, err := r.db.GetDeviceProperties(ctx, source)
+	if err != nil {
+		if !tholaerr.IsNotFoundError(err) {
+			log.Ctx(ctx).Error().Err(err).Str("source", source).Msg("failed to get device properties from cache")
+		}
+	} else {
+		event.DeviceClass = properties.Class
+		event.Vendor = properties.Properties.Vendor
+		event.Model = properties.Properties.Model
+	}
+
+	log.Ctx(ctx).Debug().Str("source", source).Str("trap_oid", event.TrapOID).Msg("received event")
+
+	return event, true
+}
@@ -0,0 +1,175 @@
+// Package yamlschema validates YAML documents against JSON Schemas.
+// Unlike converting the document to JSON first, all problems are reported with the line and column
+// of the YAML node they were found at.
+//
+// The subset of JSON Schema draft-07 which is needed for the thola configuration files is supported:
+// type, enum, const, properties, additionalProperties, propertyNames, required, minProperties,
+// maxProperties, items, minItems, minLength, pattern, format "regex", minimum, allOf, anyOf, oneOf,
+// not, if/then/else and references to definitions of the same schema.
+package yamlschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/pkg/errors"
+	"regexp"
+	"strings"
+)
+
+// definitionsRefPrefix is the prefix of all supported references.
+const definitionsRefPrefix = "#/definitions/"
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	root *schema
+}
+
+// schema is a single (sub-)schema. Boolean schemas are represented by boolean.
+type schema struct {
+	boolean *bool
+
+	Ref                  string             `json:"$ref"`
+	Title                string             `json:"title"`
+	Type                 typeList           `json:"type"`
+	Enum                 []interface{}      `json:"enum"`
+	Const                json.RawMessage    `json:"const"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	PropertyNames        *schema            `json:"propertyNames"`
+	Required             []string           `json:"required"`
+	MinProperties        *int               `json:"minProperties"`
+	MaxProperties        *int               `json:"maxProperties"`
+	Items                *schema            `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	MinLength            *int               `json:"minLength"`
+	Pattern              string             `json:"pattern"`
+	Format               string             `json:"format"`
+	Minimum              *float64           `json:"minimum"`
+	AllOf                []*schema          `json:"allOf"`
+	AnyOf                []*schema          `json:"anyOf"`
+	OneOf                []*schema          `json:"oneOf"`
+	Not                  *schema            `json:"not"`
+	If                   *schema            `json:"if"`
+	Then                 *schema            `json:"then"`
+	Else                 *schema            `json:"else"`
+	Definitions          map[string]*schema `json:"definitions"`
+
+	ref      *schema
+	constVal interface{}
+	pattern  *regexp.Regexp
+}
+
+// typeList is the value of the "type" keyword, which is either a single type or a list of types.
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*t = typeList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return errors.New("type needs to be a string or an array of strings")
+	}
+	*t = list
+	return nil
+}
+
+func (s *schema) UnmarshalJSON(b []byte) error {
+	var boolean bool
+	if err := json.Unmarshal(b, &boolean); err == nil {
+		s.boolean = &boolean
+		return nil
+	}
+	type plain schema
+	return json.Unmarshal(b, (*plain)(s))
+}
+
+// Compile parses a JSON Schema.
+func Compile(data []byte) (*Schema, error) {
+	var root schema
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, errors.Wrap(err, "failed to parse schema")
+	}
+	if err := root.compile(&root, "#"); err != nil {
+		return nil, err
+	}
+	return &Schema{root: &root}, nil
+}
+
+// compile resolves references and compiles the patterns of the schema and all its sub-schemas.
+func (s *schema) compile(root *schema, path string) error {
+	if s == nil || s.boolean != nil {
+		return nil
+	}
+	if s.Ref != "" {
+		if !strings.HasPrefix(s.Ref, definitionsRefPrefix) {
+			return fmt.Errorf("%s: unsupported reference '%s'", path, s.Ref)
+		}
+		s.ref = root.Definitions[strings.TrimPrefix(s.Ref, definitionsRefPrefix)]
+		if s.ref == nil {
+			return fmt.Errorf("%s: unknown reference '%s'", path, s.Ref)
+		}
+	}
+	if len(s.Const) != 0 {
+		if err := json.Unmarshal(s.Const, &s.constVal); err != nil {
+			return errors.Wrapf(err, "%s: invalid const", path)
+		}
+	}
+	if s.Pattern != "" {
+		var err error
+		s.pattern, err = regexp.Compile(s.Pattern)
+		if err != nil {
+			return errors.Wrapf(err, "%s: invalid pattern", path)
+		}
+	}
+	for _, t := range s.Type {
+		switch t {
+		case "object", "array", "string", "integer", "number", "boolean", "null":
+		default:
+			return fmt.Errorf("%s: unknown type '%s'", path, t)
+		}
+	}
+
+	children := map[string]*schema{
+		"additionalProperties": s.AdditionalProperties,
+		"propertyNames":        s.PropertyNames,
+		"items":                s.Items,
+		"not":                  s.Not,
+		"if":                   s.If,
+		"then":                 s.Then,
+		"else":                 s.Else,
+	}
+	for name, child := range s.Properties {
+		children["properties/"+name] = child
+	}
+	for name, child := range s.Definitions {
+		children["definitions/"+name] = child
+	}
+	for keyword, list := range map[string][]*schema{"allOf": s.AllOf, "anyOf": s.AnyOf, "oneOf": s.OneOf} {
+		for i, child := range list {
+			children[fmt.Sprintf("%s/%d", keyword, i)] = child
+		}
+	}
+	for name, child := range children {
+		if err := child.compile(root, path+"/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// describe returns a short description of the schema which is used in error messages.
+func (s *schema) describe() string {
+	if s.Title != "" {
+		return s.Title
+	}
+	if s.ref != nil {
+		return strings.TrimPrefix(s.Ref, definitionsRefPrefix)
+	}
+	if len(s.Required) != 0 {
+		return strings.Join(s.Required, " and ")
+	}
+	return "schema"
+}
@@ -0,0 +1,446 @@
+package yamlschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// mergeKey is the YAML merge key, mappings that use it are merged by the YAML parser and not validated.
+const mergeKey = "<<"
+
+// Problem is a violation of the schema.
+type Problem struct {
+	Line   int
+	Column int
+	// Path is the path of the node in the document, e.g. "components.interfaces.count[0]".
+	Path    string
+	Message string
+}
+
+// String returns the problem in the format "<line>:<column>: <path>: <message>".
+func (p Problem) String() string {
+	if p.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", p.Line, p.Column, p.Path, p.Message)
+}
+
+// Validate validates the YAML document and returns all problems ordered by their position.
+// Duplicate keys are reported as well, as they are not allowed in YAML but silently overwrite each other
+// when decoding into maps.
+func (s *Schema) Validate(document *yaml.Node) []Problem {
+	node := document
+	if node.Kind == yaml.DocumentNode {
+		if len(node.Content) == 0 {
+			node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Line: 1, Column: 1}
+		} else {
+			node = node.Content[0]
+		}
+	}
+
+	problems := append(duplicateKeys(node, ""), validate(s.root, node, "")...)
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+
+	// problems can be found multiple times, e.g. if a definition is referenced by multiple branches
+	var res []Problem
+	seen := make(map[Problem]bool)
+	for _, p := range problems {
+		if !seen[p] {
+			seen[p] = true
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+func validate(s *schema, n *yaml.Node, path string) []Problem {
+	if s == nil {
+		return nil
+	}
+	n = resolveAlias(n)
+	if s.boolean != nil {
+		if *s.boolean {
+			return nil
+		}
+		return []Problem{newProblem(n, path, "is not allowed")}
+	}
+	// in draft-07 all other keywords are ignored if a reference is used
+	if s.ref != nil {
+		return validate(s.ref, n, path)
+	}
+
+	typ := nodeType(n)
+	if len(s.Type) != 0 && !matchesType(s.Type, typ) {
+		return []Problem{newProblem(n, path, fmt.Sprintf("expected %s, got %s", strings.Join(s.Type, " or "), typ))}
+	}
+
+	var problems []Problem
+	if s.Enum != nil {
+		if v, ok := scalarValue(n); !ok || !containsValue(s.Enum, v) {
+			problems = append(problems, newProblem(n, path, enumMessage(n, s.Enum)))
+		}
+	}
+	if len(s.Const) != 0 {
+		if v, ok := scalarValue(n); !ok || !equalValues(s.constVal, v) {
+			problems = append(problems, newProblem(n, path, "expected "+string(s.Const)))
+		}
+	}
+
+	switch typ {
+	case "object":
+		problems = append(problems, validateObject(s, n, path)...)
+	case "array":
+		problems = append(problems, validateArray(s, n, path)...)
+	case "string":
+		problems = append(problems, validateString(s, n, path)...)
+	case "integer", "number":
+		if s.Minimum != nil {
+			if v, ok := scalarValue(n); ok && v.(float64) < *s.Minimum {
+				problems = append(problems, newProblem(n, path, fmt.Sprintf("must be at least %v", *s.Minimum)))
+			}
+		}
+	}
+
+	for _, sub := range s.AllOf {
+		problems = append(problems, validate(sub, n, path)...)
+	}
+	if len(s.AnyOf) != 0 {
+		matched := false
+		for _, sub := range s.AnyOf {
+			if isValid(sub, n) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			problems = append(problems, newProblem(n, path, "needs to match at least one of: "+describeAll(s.AnyOf)))
+		}
+	}
+	if len(s.OneOf) != 0 {
+		var matched []*schema
+		for _, sub := range s.OneOf {
+			if isValid(sub, n) {
+				matched = append(matched, sub)
+			}
+		}
+		switch {
+		case len(matched) == 0:
+			problems = append(problems, newProblem(n, path, "needs to match exactly one of: "+describeAll(s.OneOf)))
+		case len(matched) > 1:
+			problems = append(problems, newProblem(n, path, "needs to match exactly one of: "+describeAll(s.OneOf)+", but matches "+describeAll(matched)))
+		}
+	}
+	if s.Not != nil && isValid(s.Not, n) {
+		problems = append(problems, newProblem(n, path, "must not match "+s.Not.describe()))
+	}
+	if s.If != nil {
+		if isValid(s.If, n) {
+			problems = append(problems, validate(s.Then, n, path)...)
+		} else {
+			problems = append(problems, validate(s.Else, n, path)...)
+		}
+	}
+	return problems
+}
+
+func validateObject(s *schema, n *yaml.Node, path string) []Problem {
+	var problems []Problem
+	keys := make(map[string]bool)
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		key, val := resolveAlias(n.Content[i]), n.Content[i+1]
+		if key.Value == mergeKey {
+			continue
+		}
+		keys[key.Value] = true
+		keyPath := joinPath(path, key.Value)
+
+		if s.PropertyNames != nil {
+			problems = append(problems, validate(s.PropertyNames, key, keyPath)...)
+		}
+		if sub, ok := s.Properties[key.Value]; ok {
+			problems = append(problems, validate(sub, val, keyPath)...)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if s.AdditionalProperties.boolean != nil && !*s.AdditionalProperties.boolean {
+			msg := fmt.Sprintf("property %q is not allowed", key.Value)
+			if suggestion := suggest(key.Value, s.allowedProperties()); suggestion != "" {
+				msg += fmt.Sprintf(", did you mean %q?", suggestion)
+			}
+			problems = append(problems, newProblem(key, path, msg))
+			continue
+		}
+		problems = append(problems, validate(s.AdditionalProperties, val, keyPath)...)
+	}
+
+	for _, req := range s.Required {
+		if !keys[req] {
+			problems = append(problems, newProblem(n, path, fmt.Sprintf("property %q is missing", req)))
+		}
+	}
+	if s.MinProperties != nil && len(keys) < *s.MinProperties {
+		problems = append(problems, newProblem(n, path, fmt.Sprintf("needs to have at least %d properties", *s.MinProperties)))
+	}
+	if s.MaxProperties != nil && len(keys) > *s.MaxProperties {
+		problems = append(problems, newProblem(n, path, fmt.Sprintf("must not have more than %d properties", *s.MaxProperties)))
+	}
+	return problems
+}
+
+func validateArray(s *schema, n *yaml.Node, path string) []Problem {
+	var problems []Problem
+	if s.MinItems != nil && len(n.Content) < *s.MinItems {
+		if *s.MinItems == 1 {
+			problems = append(problems, newProblem(n, path, "must not be empty"))
+		} else {
+			problems = append(problems, newProblem(n, path, fmt.Sprintf("needs to have at least %d items", *s.MinItems)))
+		}
+	}
+	if s.Items != nil {
+		for i, item := range n.Content {
+			problems = append(problems, validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return problems
+}
+
+func validateString(s *schema, n *yaml.Node, path string) []Problem {
+	var problems []Problem
+	if s.MinLength != nil && utf8.RuneCountInString(n.Value) < *s.MinLength {
+		if *s.MinLength == 1 {
+			problems = append(problems, newProblem(n, path, "must not be empty"))
+		} else {
+			problems = append(problems, newProblem(n, path, fmt.Sprintf("needs to have at least %d characters", *s.MinLength)))
+		}
+	}
+	if s.pattern != nil && !s.pattern.MatchString(n.Value) {
+		problems = append(problems, newProblem(n, path, fmt.Sprintf("%q does not match the pattern %s", n.Value, s.Pattern)))
+	}
+	if s.Format == "regex" {
+		if _, err := regexp.Compile(n.Value); err != nil {
+			problems = append(problems, newProblem(n, path, "invalid regex: "+err.Error()))
+		}
+	}
+	return problems
+}
+
+// allowedProperties returns the names of all properties which are allowed by the schema.
+func (s *schema) allowedProperties() []string {
+	var res []string
+	for name, sub := range s.Properties {
+		if sub.boolean == nil || *sub.boolean {
+			res = append(res, name)
+		}
+	}
+	return res
+}
+
+func isValid(s *schema, n *yaml.Node) bool {
+	return len(validate(s, n, "")) == 0
+}
+
+func describeAll(schemas []*schema) string {
+	var res []string
+	for _, s := range schemas {
+		res = append(res, s.describe())
+	}
+	return strings.Join(res, ", ")
+}
+
+func enumMessage(n *yaml.Node, enum []interface{}) string {
+	var values []string
+	for _, v := range enum {
+		if s, ok := v.(string); ok {
+			values = append(values, s)
+		} else {
+			values = append(values, fmt.Sprint(v))
+		}
+	}
+	if n.Kind != yaml.ScalarNode {
+		return "expected one of: " + strings.Join(values, ", ")
+	}
+	if suggestion := suggest(n.Value, values); suggestion != "" {
+		return fmt.Sprintf("invalid value %q, did you mean %q?", n.Value, suggestion)
+	}
+	return fmt.Sprintf("invalid value %q, expected one of: %s", n.Value, strings.Join(values, ", "))
+}
+
+// duplicateKeys returns a problem for every key that is defined more than once in the same mapping.
+func duplicateKeys(n *yaml.Node, path string) []Problem {
+	var problems []Problem
+	switch n.Kind {
+	case yaml.MappingNode:
+		seen := make(map[string]bool)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key := n.Content[i]
+			if seen[key.Value] && key.Value != mergeKey {
+				problems = append(problems, newProblem(key, path, fmt.Sprintf("property %q is already defined", key.Value)))
+			}
+			seen[key.Value] = true
+			problems = append(problems, duplicateKeys(n.Content[i+1], joinPath(path, key.Value))...)
+		}
+	case yaml.SequenceNode:
+		for i, item := range n.Content {
+			problems = append(problems, duplicateKeys(item, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return problems
+}
+
+func newProblem(n *yaml.Node, path, message string) Problem {
+	return Problem{
+		Line:    n.Line,
+		Column:  n.Column,
+		Path:    path,
+		Message: message,
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func resolveAlias(n *yaml.Node) *yaml.Node {
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+	return n
+}
+
+// nodeType returns the JSON type of the node.
+func nodeType(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!bool":
+		return "boolean"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+func matchesType(types []string, typ string) bool {
+	for _, t := range types {
+		if t == typ || (t == "number" && typ == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// scalarValue returns the value of a scalar node like it is decoded from JSON, so numbers are float64.
+func scalarValue(n *yaml.Node) (interface{}, bool) {
+	if n.Kind != yaml.ScalarNode {
+		return nil, false
+	}
+	switch nodeType(n) {
+	case "integer", "number":
+		var f float64
+		if err := n.Decode(&f); err != nil {
+			return nil, false
+		}
+		return f, true
+	case "boolean":
+		var b bool
+		if err := n.Decode(&b); err != nil {
+			return nil, false
+		}
+		return b, true
+	case "null":
+		return nil, true
+	}
+	return n.Value, true
+}
+
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, value := range values {
+		if equalValues(value, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func equalValues(a, b interface{}) bool {
+	switch a.(type) {
+	case string, float64, bool, nil:
+		return a == b
+	}
+	// objects and arrays are never equal to scalar nodes
+	x, _ := json.Marshal(a)
+	y, _ := json.Marshal(b)
+	return string(x) == string(y)
+}
+
+// suggest returns the candidate which is most similar to s if it is similar enough to be a typo.
+func suggest(s string, candidates []string) string {
+	best, bestDistance := "", -1
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(s), strings.ToLower(c))
+		if d <= len(c)/3 && d < 4 && (bestDistance == -1 || d < bestDistance || (d == bestDistance && c < best)) {
+			best, bestDistance = c, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and transpositions of adjacent
+// characters that are needed to change a into b (optimal string alignment distance).
+func editDistance(a, b string) int {
+	x, y := []rune(a), []rune(b)
+	d := make([][]int, len(x)+1)
+	for i := range d {
+		d[i] = make([]int, len(y)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(x); i++ {
+		for j := 1; j <= len(y); j++ {
+			cost := 1
+			if x[i-1] == y[j-1] {
+				cost = 0
+			}
+			d[i][j] = min3(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && x[i-1] == y[j-2] && x[i-2] == y[j-1] && d[i-2][j-2]+1 < d[i][j] {
+				d[i][j] = d[i-2][j-2] + 1
+			}
+		}
+	}
+	return d[len(x)][len(y)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,83 @@
+package yamlschema
+
+import (
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"testing"
+)
+
+const testSchema = `{
+  "$ref": "#/definitions/item",
+  "definitions": {
+    "item": {
+      "type": "object",
+      "properties": {
+        "name": {"type": "string", "minLength": 1},
+        "kind": {"enum": ["number", "text"]},
+        "value": true,
+        "tags": {"type": "array", "items": {"type": "string", "pattern": "^[a-z]+$"}}
+      },
+      "required": ["name", "kind"],
+      "additionalProperties": false,
+      "allOf": [
+        {
+          "if": {"properties": {"kind": {"const": "number"}}},
+          "then": {"properties": {"value": {"type": "number", "minimum": 0}}}
+        }
+      ]
+    }
+  }
+}`
+
+func validateDocument(t *testing.T, document string) []string {
+	schema, err := Compile([]byte(testSchema))
+	if !assert.NoError(t, err) {
+		return nil
+	}
+	var node yaml.Node
+	if !assert.NoError(t, yaml.Unmarshal([]byte(document), &node)) {
+		return nil
+	}
+	var res []string
+	for _, p := range schema.Validate(&node) {
+		res = append(res, p.String())
+	}
+	return res
+}
+
+func TestSchema_Validate(t *testing.T) {
+	assert.Empty(t, validateDocument(t, "name: a\nkind: number\nvalue: 1.5\ntags: [a, b]\n"))
+	assert.Empty(t, validateDocument(t, "name: a\nkind: text\nvalue: -1\n"))
+
+	assert.Equal(t, []string{
+		`1:1: property "kind" is missing`,
+		"1:7: name: must not be empty",
+		`2:1: property "nmae" is not allowed, did you mean "name"?`,
+		`3:8: tags[0]: "A" does not match the pattern ^[a-z]+$`,
+		"3:11: tags[1]: expected string, got integer",
+		`4:1: property "name" is already defined`,
+	}, validateDocument(t, "name: ''\nnmae: a\ntags: [A, 1]\nname: b\n"))
+
+	assert.Equal(t, []string{
+		`2:7: kind: invalid value "numbr", did you mean "number"?`,
+	}, validateDocument(t, "name: a\nkind: numbr\n"))
+
+	assert.Equal(t, []string{
+		"3:8: value: must be at least 0",
+	}, validateDocument(t, "name: a\nkind: number\nvalue: -1\n"))
+
+	assert.Equal(t, []string{
+		"1:1: expected object, got array",
+	}, validateDocument(t, "- a\n"))
+}
+
+func TestCompile(t *testing.T) {
+	_, err := Compile([]byte(`{"$ref": "#/definitions/missing"}`))
+	assert.Error(t, err)
+
+	_, err = Compile([]byte(`{"type": "text"}`))
+	assert.Error(t, err)
+
+	_, err = Compile([]byte(`{"pattern": "("}`))
+	assert.Error(t, err)
+}